@@ -3,18 +3,52 @@ package api
 import (
 	"attune/internal/models"
 	"context"
+	"time"
 )
 
 type TriggerType string
 
 type Trigger struct {
 	VendorID           string
+	SessionID          string
+	RoomID             string
 	Type               TriggerType
 	FocusSessionStatus models.FocusSessionStatus
+	Source             models.FocusSessionEventSource
+	FocusedDuration    time.Duration
+	RestDuration       time.Duration
+	PausedDuration     time.Duration
+	Pomodoro           *models.PomodoroProgress
+	Milestone          models.FocusMilestone
+	Remaining          time.Duration
+	Schedule           *models.FocusSchedule
+	DailyGoal          *models.DailyFocusGoalProgress
+	Report             *models.Report
+	Badges             []models.Badge
 }
 
 const (
 	TriggerTypeFinishSession TriggerType = "finish_session"
+	TriggerTypeBreakStart    TriggerType = "break_start"
+	TriggerTypeBreakEnd      TriggerType = "break_end"
+	TriggerTypeCycleComplete TriggerType = "cycle_complete"
+
+	TriggerTypeMilestoneProgress TriggerType = "milestone_progress"
+	TriggerTypeMilestoneTimeLeft TriggerType = "milestone_time_left"
+
+	TriggerTypeScheduledSession TriggerType = "scheduled_session"
+
+	TriggerTypeGoalReached TriggerType = "goal_reached"
+
+	TriggerTypeSessionAbandoned TriggerType = "session_abandoned"
+
+	TriggerTypeBreakFinished TriggerType = "break_finished"
+
+	TriggerTypeDayCheckIn TriggerType = "day_check_in"
+
+	TriggerTypeReport TriggerType = "report"
+
+	TriggerTypeBadgesEarned TriggerType = "badges_earned"
 )
 
 type ExternalAPI interface {
@@ -0,0 +1,127 @@
+package telegram
+
+import (
+	"attune/internal/api"
+	"attune/internal/dto"
+	"attune/internal/models"
+	"attune/pkg/apperrors"
+	"context"
+	"fmt"
+	"strconv"
+	"time"
+
+	tb "gopkg.in/telebot.v4"
+)
+
+const (
+	keyBreak5   = "break_5"
+	keyBreak15  = "break_15"
+	keyBreakEnd = "break_end"
+
+	msgBreakStarted  = "☕ *Enjoy your break!*\nDuration: "
+	msgBreakFinished = "☕ *Break's over!*\nRested: `%s`"
+	msgWorkRest      = "\n⚖️ Today: `%s` focus · `%s` rest"
+	msgWorkRestRatio = " (%.1f : 1)"
+)
+
+var (
+	ErrMsgBreakFinished = "failed to send break finished message"
+)
+
+func (a *API) registerBreakCallbacks() {
+	breaks := map[string]time.Duration{
+		keyBreak5:  5 * time.Minute,
+		keyBreak15: 15 * time.Minute,
+	}
+	for key, duration := range breaks {
+		k, d := key, duration
+		a.bot.Handle(&tb.InlineButton{Unique: k}, func(c tb.Context) error {
+			err := a.startBreak(c, d)
+			if err != nil {
+				a.logger.Error(context.Background(), "Error starting break", err, "duration", d.String(), "user", c.Sender().ID)
+			}
+			return err
+		})
+	}
+
+	a.bot.Handle(&tb.InlineButton{Unique: keyBreakEnd}, func(c tb.Context) error {
+		updateDTO := dto.UpdateFocusRequest{
+			VendorID: strconv.FormatInt(c.Sender().ID, 10),
+			Type:     dto.UpdateFocusRequestTypeStop,
+			Source:   models.FocusSessionEventSourceTelegram,
+		}
+		if err := a.services.FocusSessionService.Update(context.Background(), updateDTO); err != nil {
+			return c.Respond(&tb.CallbackResponse{Text: apperrors.GetMessage(err)})
+		}
+
+		return c.Respond()
+	})
+}
+
+func (a *API) startBreak(c tb.Context, duration time.Duration) error {
+	if _, err := a.bot.EditReplyMarkup(c.Message(), nil); err != nil {
+		a.logger.Error(context.Background(), "Failed to remove break buttons", err, "user", c.Sender().ID)
+	}
+	_ = c.Respond()
+
+	req := dto.CreateFocusSessionRequest{
+		VendorID: strconv.FormatInt(c.Sender().ID, 10),
+		Duration: duration,
+		Break:    true,
+		Source:   models.FocusSessionEventSourceTelegram,
+	}
+	confirmationMsg := msgBreakStarted + "`" + formatDuration(duration) + "`"
+
+	return a.launchFocusSession(c, req, confirmationMsg, breakControlMarkup())
+}
+
+// A break the system stopped made room for a session that is starting, so nothing is offered.
+func (a *API) finishBreak(ctx context.Context, vendorID string, trigger api.Trigger) error {
+	vendorChat, err := chatFromVendorID(vendorID)
+	if err != nil {
+		return err
+	}
+
+	a.finishLiveMessage(vendorID, trigger.SessionID)
+	if trigger.FocusSessionStatus == models.FocusSessionStatusStopped && trigger.Source == models.FocusSessionEventSourceSystem {
+		return nil
+	}
+
+	msg := fmt.Sprintf(msgBreakFinished, formatDuration(trigger.RestDuration))
+	balance, err := a.services.FocusSessionService.WorkRest(ctx, vendorID)
+	if err != nil {
+		a.logger.Error(ctx, "Failed to get work-to-rest balance", err, "user", vendorID)
+	} else {
+		msg += formatWorkRest(balance)
+	}
+
+	if _, err := a.bot.Send(vendorChat, msg, &tb.SendOptions{ParseMode: tb.ModeMarkdown}); err != nil {
+		return apperrors.NewInternal().WithDescriptionAndCause(ErrMsgBreakFinished, err)
+	}
+
+	return a.sendLabelMenuTo(vendorChat, vendorID)
+}
+
+func formatWorkRest(balance models.WorkRestBalance) string {
+	msg := fmt.Sprintf(msgWorkRest, formatDuration(balance.Focused), formatDuration(balance.Rest))
+	if ratio := balance.Ratio(); ratio > 0 {
+		msg += fmt.Sprintf(msgWorkRestRatio, ratio)
+	}
+
+	return msg
+}
+
+func breakButtons() []tb.InlineButton {
+	return []tb.InlineButton{
+		{Unique: keyBreak5, Text: "☕ 5 min break"},
+		{Unique: keyBreak15, Text: "☕ 15 min break"},
+	}
+}
+
+func breakControlMarkup() *tb.ReplyMarkup {
+	return &tb.ReplyMarkup{
+		InlineKeyboard: [][]tb.InlineButton{
+			{{Unique: keyBreakEnd, Text: "End break"}},
+		},
+	}
+}
@@ -0,0 +1,140 @@
+package telegram
+
+import (
+	"attune/internal/models"
+	"attune/pkg/apperrors"
+	"attune/pkg/chart"
+	"bytes"
+	"context"
+	"fmt"
+	"math"
+	"strconv"
+
+	tb "gopkg.in/telebot.v4"
+)
+
+const (
+	keyChartsPeriod = "charts_period"
+
+	msgChartsPrompt  = "📊 *Charts*\nWhich period would you like to see?"
+	msgChartsEmpty   = "📊 Nothing recorded in this period yet. Rate your days with /today and start a focus session."
+	msgChartsCaption = "📊 Your last %s"
+)
+
+var (
+	ErrMsgCharts       = "failed to send charts"
+	ErrMsgRenderCharts = "failed to render charts"
+)
+
+var chartPeriodLabels = []struct {
+	period models.ChartPeriod
+	text   string
+}{
+	{models.ChartPeriodWeek, "Week"},
+	{models.ChartPeriodMonth, "Month"},
+	{models.ChartPeriodYear, "Year"},
+}
+
+func (a *API) registerChartCommands() {
+	a.bot.Handle("/charts", func(c tb.Context) error {
+		buttons := make([]tb.InlineButton, 0, len(chartPeriodLabels))
+		for _, label := range chartPeriodLabels {
+			buttons = append(buttons, tb.InlineButton{Unique: keyChartsPeriod, Text: label.text, Data: string(label.period)})
+		}
+
+		opts := &tb.SendOptions{
+			ParseMode:   tb.ModeMarkdown,
+			ReplyMarkup: &tb.ReplyMarkup{InlineKeyboard: [][]tb.InlineButton{buttons}},
+		}
+		if err := c.Send(msgChartsPrompt, opts); err != nil {
+			a.logger.Error(context.Background(), "Error handling /charts command", err, "user", c.Sender().ID)
+			return apperrors.NewInternal().WithDescriptionAndCause(ErrMsgCharts, err)
+		}
+		return nil
+	})
+
+	a.bot.Handle(&tb.InlineButton{Unique: keyChartsPeriod}, func(c tb.Context) error {
+		err := a.sendCharts(c, models.ChartPeriod(c.Data()))
+		if err != nil {
+			a.logger.Error(context.Background(), "Error sending charts", err, "period", c.Data(), "user", c.Sender().ID)
+		}
+		return err
+	})
+}
+
+func (a *API) sendCharts(c tb.Context, period models.ChartPeriod) error {
+	vendorID := strconv.FormatInt(c.Sender().ID, 10)
+
+	data, err := a.services.ChartService.Data(context.Background(), vendorID, period)
+	if err != nil {
+		return c.Respond(&tb.CallbackResponse{Text: apperrors.GetMessage(err)})
+	}
+	_ = c.Respond()
+
+	if data.IsEmpty() {
+		return c.Send(msgChartsEmpty)
+	}
+
+	labels := make([]string, 0, len(data.Buckets))
+	quality := make([]float64, 0, len(data.Buckets))
+	focused := make([]float64, 0, len(data.Buckets))
+	for _, bucket := range data.Buckets {
+		labels = append(labels, chartLabel(period, bucket))
+		focused = append(focused, math.Round(bucket.FocusedDuration.Minutes()))
+		if bucket.RatedDays > 0 {
+			quality = append(quality, bucket.DayQuality)
+		} else {
+			quality = append(quality, math.NaN())
+		}
+	}
+
+	distributionLabels := make([]string, 0, len(data.SessionQuality))
+	distribution := make([]float64, 0, len(data.SessionQuality))
+	for i, sessions := range data.SessionQuality {
+		distributionLabels = append(distributionLabels, strconv.Itoa(i+1))
+		distribution = append(distribution, float64(sessions))
+	}
+
+	charts := []func(w *bytes.Buffer) error{
+		func(w *bytes.Buffer) error {
+			return chart.WriteLine(w, chart.Chart{Title: "Day quality", Labels: labels, Values: quality, Max: 10})
+		},
+		func(w *bytes.Buffer) error {
+			return chart.WriteBar(w, chart.Chart{Title: "Focused minutes", Labels: labels, Values: focused})
+		},
+		func(w *bytes.Buffer) error {
+			return chart.WriteBar(w, chart.Chart{Title: "Session quality", Labels: distributionLabels, Values: distribution})
+		},
+	}
+
+	album := make(tb.Album, 0, len(charts))
+	for i, write := range charts {
+		var buf bytes.Buffer
+		if err := write(&buf); err != nil {
+			return apperrors.NewInternal().WithDescriptionAndCause(ErrMsgRenderCharts, err)
+		}
+
+		photo := &tb.Photo{File: tb.FromReader(&buf)}
+		if i == 0 {
+			photo.Caption = fmt.Sprintf(msgChartsCaption, period)
+		}
+		album = append(album, photo)
+	}
+
+	if err := c.SendAlbum(album); err != nil {
+		return apperrors.NewInternal().WithDescriptionAndCause(ErrMsgCharts, err)
+	}
+
+	return nil
+}
+
+func chartLabel(period models.ChartPeriod, bucket models.ChartBucket) string {
+	switch period {
+	case models.ChartPeriodWeek:
+		return bucket.Start.Format("Mon")
+	case models.ChartPeriodYear:
+		return bucket.Start.Format("Jan")
+	}
+
+	return bucket.Start.Format("02 Jan")
+}
@@ -0,0 +1,88 @@
+package telegram
+
+import (
+	"attune/internal/dto"
+	"attune/pkg/apperrors"
+	"context"
+	"strconv"
+
+	tb "gopkg.in/telebot.v4"
+)
+
+const (
+	prefixPendingFocusSession = "pending_focus_session_"
+
+	keyFocusReplace = "focus_replace"
+	keyFocusKeep    = "focus_keep"
+
+	msgFocusConflict = "⚠️ *You already have a focus session running.*\nWhat would you like to do?"
+	msgKeepCurrent   = "👍 Keeping your current session."
+	msgPendingGone   = "This choice has expired, please start a new session."
+)
+
+var (
+	ErrMsgFocusConflict = "failed to send focus session conflict prompt"
+)
+
+type pendingFocusSession struct {
+	request         dto.CreateFocusSessionRequest
+	confirmationMsg string
+	controlMarkup   *tb.ReplyMarkup
+}
+
+func (a *API) registerFocusConflictCallbacks() {
+	a.bot.Handle(&tb.InlineButton{Unique: keyFocusReplace}, func(c tb.Context) error {
+		err := a.replaceFocusSession(c)
+		if err != nil {
+			a.logger.Error(context.Background(), "Error replacing focus session", err, "user", c.Sender().ID)
+		}
+		return err
+	})
+
+	a.bot.Handle(&tb.InlineButton{Unique: keyFocusKeep}, func(c tb.Context) error {
+		vendorID := strconv.FormatInt(c.Sender().ID, 10)
+		a.cache.Delete(prefixPendingFocusSession + vendorID)
+
+		if _, err := a.bot.Edit(c.Message(), msgKeepCurrent); err != nil {
+			a.logger.Error(context.Background(), "Failed to close focus session conflict prompt", err, "user", c.Sender().ID)
+		}
+		return c.Respond()
+	})
+}
+
+func (a *API) askToReplaceFocusSession(c tb.Context, pending pendingFocusSession) error {
+	a.cache.Set(prefixPendingFocusSession+pending.request.VendorID, pending)
+
+	opts := &tb.SendOptions{
+		ParseMode: tb.ModeMarkdown,
+		ReplyMarkup: &tb.ReplyMarkup{
+			InlineKeyboard: [][]tb.InlineButton{
+				{{Unique: keyFocusReplace, Text: "Stop current and start new"}},
+				{{Unique: keyFocusKeep, Text: "Keep current"}},
+			},
+		},
+	}
+	if _, err := a.bot.Send(c.Sender(), msgFocusConflict, opts); err != nil {
+		return apperrors.NewInternal().WithDescriptionAndCause(ErrMsgFocusConflict, err)
+	}
+
+	return nil
+}
+
+func (a *API) replaceFocusSession(c tb.Context) error {
+	vendorID := strconv.FormatInt(c.Sender().ID, 10)
+
+	v, ok := a.cache.Get(prefixPendingFocusSession + vendorID)
+	a.cache.Delete(prefixPendingFocusSession + vendorID)
+	pending, valid := v.(pendingFocusSession)
+	if !ok || !valid {
+		return c.Respond(&tb.CallbackResponse{Text: msgPendingGone})
+	}
+
+	if _, err := a.bot.EditReplyMarkup(c.Message(), nil); err != nil {
+		a.logger.Error(context.Background(), "Failed to close focus session conflict prompt", err, "user", c.Sender().ID)
+	}
+
+	pending.request.Replace = true
+	return a.launchFocusSession(c, pending.request, pending.confirmationMsg, pending.controlMarkup)
+}
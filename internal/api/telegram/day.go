@@ -0,0 +1,207 @@
+package telegram
+
+import (
+	"attune/internal/models"
+	"attune/pkg/apperrors"
+	"context"
+	"fmt"
+	"strconv"
+
+	tb "gopkg.in/telebot.v4"
+)
+
+const (
+	prefixDayMoodNote = "day_mood_note_"
+
+	keyDayQuality     = "day_quality"
+	keyDayMoodSkip    = "day_mood_skip"
+	keyDayEditQuality = "day_edit_quality"
+	keyDayEditMood    = "day_edit_mood"
+
+	msgDayQualityPrompt  = "🌙 *How was your day?*\nRate it from 0 to 10."
+	msgDayCheckIn        = "🌙 *Evening check-in*\nYou haven't rated today yet. How was it, from 0 to 10?"
+	msgDayRated          = "🌙 Today: *%d/10*"
+	msgDayInvalidQuality = "Invalid rating. Pick a number between 0 and 10."
+	msgDayMoodNotePrompt = "📝 *Anything to add?*\nReply with a short note about your mood, or skip."
+	msgDayMoodNoteSaved  = "📝 Note saved."
+	msgDayMoodSkipped    = "📝 No note this time."
+	msgDayMoodInvalid    = "❌ *Invalid note.*\n"
+	msgDayToday          = "🌙 *Today*\nQuality: *%d/10*\nMood: %s"
+)
+
+var (
+	ErrMsgDayPrompt = "failed to send day check-in prompt"
+	ErrMsgDayToday  = "failed to send today's day record"
+)
+
+func (a *API) registerDayCommands() {
+	a.bot.Handle("/today", func(c tb.Context) error {
+		err := a.handleToday(c)
+		if err != nil {
+			a.logger.Error(context.Background(), "Error handling /today command", err, "user", c.Sender().ID)
+		}
+		return err
+	})
+
+	a.bot.Handle(&tb.InlineButton{Unique: keyDayQuality}, func(c tb.Context) error {
+		err := a.rateDay(c)
+		if err != nil {
+			a.logger.Error(context.Background(), "Error rating day", err, "user", c.Sender().ID)
+		}
+		return err
+	})
+
+	a.bot.Handle(&tb.InlineButton{Unique: keyDayEditQuality}, func(c tb.Context) error {
+		_ = c.Respond()
+		return a.sendDayQualityPrompt(c.Sender(), msgDayQualityPrompt)
+	})
+
+	a.bot.Handle(&tb.InlineButton{Unique: keyDayEditMood}, func(c tb.Context) error {
+		vendorID := strconv.FormatInt(c.Sender().ID, 10)
+		record, err := a.services.DayService.Today(context.Background(), vendorID)
+		if err != nil {
+			return c.Respond(&tb.CallbackResponse{Text: apperrors.GetMessage(err)})
+		}
+		_ = c.Respond()
+
+		return a.sendEmotionPicker(c.Sender(), record.Mood.Emotions)
+	})
+
+	a.bot.Handle(&tb.InlineButton{Unique: keyDayMoodSkip}, func(c tb.Context) error {
+		userID := strconv.FormatInt(c.Sender().ID, 10)
+		a.cache.Delete(prefixDayMoodNote + userID)
+
+		if _, err := a.bot.Edit(c.Message(), msgDayMoodSkipped); err != nil {
+			a.logger.Error(context.Background(), "Failed to close day mood prompt", err, "user", c.Sender().ID)
+		}
+		return c.Respond()
+	})
+}
+
+func (a *API) handleToday(c tb.Context) error {
+	ctx := context.Background()
+
+	if err := a.ensureUser(ctx, c.Sender()); err != nil {
+		return err
+	}
+
+	vendorID := strconv.FormatInt(c.Sender().ID, 10)
+	record, err := a.services.DayService.Today(ctx, vendorID)
+	if err != nil {
+		if apperrors.IsCode(err, apperrors.NotFound) {
+			return a.sendDayQualityPrompt(c.Sender(), msgDayQualityPrompt)
+		}
+		return err
+	}
+
+	opts := &tb.SendOptions{ParseMode: tb.ModeMarkdown, ReplyMarkup: dayRecordMarkup()}
+	if _, err := a.bot.Send(c.Sender(), renderDayRecord(record), opts); err != nil {
+		return apperrors.NewInternal().WithDescriptionAndCause(ErrMsgDayToday, err)
+	}
+
+	return nil
+}
+
+func (a *API) remindDayCheckIn(_ context.Context, vendorID string) error {
+	vendorChat, err := chatFromVendorID(vendorID)
+	if err != nil {
+		return err
+	}
+
+	return a.sendDayQualityPrompt(vendorChat, msgDayCheckIn)
+}
+
+func (a *API) rateDay(c tb.Context) error {
+	quality, err := strconv.Atoi(c.Data())
+	if err != nil {
+		return c.Respond(&tb.CallbackResponse{Text: msgDayInvalidQuality})
+	}
+
+	vendorID := strconv.FormatInt(c.Sender().ID, 10)
+	record, err := a.services.DayService.RateToday(context.Background(), vendorID, quality)
+	if err != nil {
+		return c.Respond(&tb.CallbackResponse{Text: apperrors.GetMessage(err)})
+	}
+
+	msg := fmt.Sprintf(msgDayRated, record.Quality)
+	if _, err := a.bot.Edit(c.Message(), msg, &tb.SendOptions{ParseMode: tb.ModeMarkdown}); err != nil {
+		a.logger.Error(context.Background(), "Failed to close day quality prompt", err, "user", c.Sender().ID)
+	}
+	_ = c.Respond()
+
+	if len(record.Mood.Emotions) > 0 || record.Mood.Note != "" {
+		return nil
+	}
+
+	return a.sendEmotionPicker(c.Sender(), nil)
+}
+
+func (a *API) askDayMoodNote(to tb.Recipient) error {
+	opts := &tb.SendOptions{
+		ParseMode: tb.ModeMarkdown,
+		ReplyMarkup: &tb.ReplyMarkup{
+			InlineKeyboard: [][]tb.InlineButton{{{Unique: keyDayMoodSkip, Text: "Skip"}}},
+		},
+	}
+	if _, err := a.bot.Send(to, msgDayMoodNotePrompt, opts); err != nil {
+		return apperrors.NewInternal().WithDescriptionAndCause(ErrMsgDayPrompt, err)
+	}
+	a.cache.Set(prefixDayMoodNote+to.Recipient(), true)
+
+	return nil
+}
+
+func (a *API) handleDayMoodNoteInput(c tb.Context) error {
+	userID := strconv.FormatInt(c.Sender().ID, 10)
+
+	if _, err := a.services.DayService.SetTodayMoodNote(context.Background(), userID, c.Message().Text); err != nil {
+		if apperrors.IsCode(err, apperrors.BadRequest) {
+			_, _ = a.bot.Send(c.Sender(), msgDayMoodInvalid+apperrors.GetMessage(err), &tb.SendOptions{ParseMode: tb.ModeMarkdown})
+			return nil
+		}
+		return err
+	}
+
+	a.cache.Delete(prefixDayMoodNote + userID)
+	_, _ = a.bot.Send(c.Sender(), msgDayMoodNoteSaved)
+
+	return nil
+}
+
+func (a *API) sendDayQualityPrompt(to tb.Recipient, msg string) error {
+	opts := &tb.SendOptions{ParseMode: tb.ModeMarkdown, ReplyMarkup: dayQualityMarkup()}
+	if _, err := a.bot.Send(to, msg, opts); err != nil {
+		return apperrors.NewInternal().WithDescriptionAndCause(ErrMsgDayPrompt, err)
+	}
+
+	return nil
+}
+
+func renderDayRecord(record models.DayRecord) string {
+	return fmt.Sprintf(msgDayToday, record.Quality, formatMood(record.Mood))
+}
+
+func dayQualityMarkup() *tb.ReplyMarkup {
+	rows := make([][]tb.InlineButton, 2)
+	for quality := 0; quality <= 10; quality++ {
+		row := 0
+		if quality > 5 {
+			row = 1
+		}
+		value := strconv.Itoa(quality)
+		rows[row] = append(rows[row], tb.InlineButton{Unique: keyDayQuality, Text: value, Data: value})
+	}
+
+	return &tb.ReplyMarkup{InlineKeyboard: rows}
+}
+
+func dayRecordMarkup() *tb.ReplyMarkup {
+	return &tb.ReplyMarkup{
+		InlineKeyboard: [][]tb.InlineButton{
+			{
+				{Unique: keyDayEditQuality, Text: "✏️ Change quality"},
+				{Unique: keyDayEditMood, Text: "💭 Change mood"},
+			},
+		},
+	}
+}
@@ -0,0 +1,187 @@
+package telegram
+
+import (
+	"attune/internal/dto"
+	"attune/internal/models"
+	"attune/pkg/apperrors"
+	"context"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+
+	tb "gopkg.in/telebot.v4"
+)
+
+const (
+	keyFocusDistracted = "focus_distracted"
+
+	msgDistractionNoted   = "⚡ Interruption noted (%d this session)."
+	msgDistractionInvalid = "❌ *Couldn't note the interruption.*\n"
+	msgDistractionsLine   = "\n⚡ Interruptions: %d"
+	msgDistractionStats   = "⚡ *Interruptions, last 30 days*\n" +
+		"`%d` in `%d` sessions (%.1f per session)"
+	msgDistractionReasons = "\nMost common: %s"
+	msgDistractionQuality = "\n\n*Focus quality by interruptions*"
+	msgDistractionBucket  = "\n• %s: %.1f/10 (%d rated)"
+	msgNoDistractionStats = "⚡ You have no finished sessions in the last 30 days."
+	msgDistractionUsage   = "\n\n_During a session tap_ ⚡ Distracted _or send_ `/distracted <reason>`."
+)
+
+var (
+	ErrMsgDistractionStats = "failed to send focus distraction stats"
+)
+
+var distractionBuckets = []struct {
+	name     string
+	min, max int
+}{
+	{name: "none", min: 0, max: 0},
+	{name: "1–2", min: 1, max: 2},
+	{name: "3 or more", min: 3, max: -1},
+}
+
+func (a *API) registerDistractionCommands() {
+	a.bot.Handle(&tb.InlineButton{Unique: keyFocusDistracted}, func(c tb.Context) error {
+		msg, err := a.recordDistraction(c, "")
+		if err != nil {
+			return c.Respond(&tb.CallbackResponse{Text: apperrors.GetMessage(err)})
+		}
+		return c.Respond(&tb.CallbackResponse{Text: msg})
+	})
+
+	a.bot.Handle("/distracted", func(c tb.Context) error {
+		msg, err := a.recordDistraction(c, c.Message().Payload)
+		if err != nil {
+			if apperrors.IsCode(err, apperrors.BadRequest) {
+				_, _ = a.bot.Send(c.Sender(), msgDistractionInvalid+apperrors.GetMessage(err), &tb.SendOptions{ParseMode: tb.ModeMarkdown})
+				return nil
+			}
+			a.logger.Error(context.Background(), "Error handling /distracted command", err, "user", c.Sender().ID)
+			return err
+		}
+		_, err = a.bot.Send(c.Sender(), msg)
+		return err
+	})
+
+	a.bot.Handle("/distractions", func(c tb.Context) error {
+		err := a.sendDistractionStats(c)
+		if err != nil {
+			a.logger.Error(context.Background(), "Error handling /distractions command", err, "user", c.Sender().ID)
+		}
+		return err
+	})
+}
+
+func distractedButton() tb.InlineButton {
+	return tb.InlineButton{Unique: keyFocusDistracted, Text: "⚡ Distracted"}
+}
+
+func (a *API) recordDistraction(c tb.Context, reason string) (string, error) {
+	count, err := a.services.FocusSessionService.Distract(context.Background(), dto.CreateFocusDistractionRequest{
+		VendorID: strconv.FormatInt(c.Sender().ID, 10),
+		Reason:   reason,
+	})
+	if err != nil {
+		return "", err
+	}
+
+	return fmt.Sprintf(msgDistractionNoted, count), nil
+}
+
+func (a *API) formatSessionDistractions(ctx context.Context, vendorID, sessionID string) string {
+	distractions, err := a.services.FocusSessionService.Distractions(ctx, vendorID, sessionID)
+	if err != nil {
+		if !apperrors.IsCode(err, apperrors.NotFound) {
+			a.logger.Error(ctx, "Failed to list focus distractions", err, "session", sessionID)
+		}
+		return ""
+	}
+
+	counts := make(map[string]int)
+	var reasons []models.FocusDistractionReason
+	for _, distraction := range distractions {
+		if distraction.Reason == "" {
+			continue
+		}
+		if counts[distraction.Reason] == 0 {
+			reasons = append(reasons, models.FocusDistractionReason{Reason: distraction.Reason})
+		}
+		counts[distraction.Reason]++
+	}
+	for i := range reasons {
+		reasons[i].Count = counts[reasons[i].Reason]
+	}
+	sort.SliceStable(reasons, func(i, j int) bool { return reasons[i].Count > reasons[j].Count })
+
+	msg := fmt.Sprintf(msgDistractionsLine, len(distractions))
+	if len(reasons) > 0 {
+		msg += " (" + formatDistractionReasons(reasons) + ")"
+	}
+
+	return msg
+}
+
+func (a *API) sendDistractionStats(c tb.Context) error {
+	vendorID := strconv.FormatInt(c.Sender().ID, 10)
+
+	stats, err := a.services.FocusSessionService.DistractionStats(context.Background(), vendorID)
+	if err != nil && !apperrors.IsCode(err, apperrors.NotFound) {
+		return err
+	}
+
+	msg := msgNoDistractionStats
+	if stats.Sessions > 0 {
+		var sb strings.Builder
+		sb.WriteString(fmt.Sprintf(msgDistractionStats,
+			stats.Distractions, stats.Sessions, float64(stats.Distractions)/float64(stats.Sessions)))
+		if len(stats.TopReasons) > 0 {
+			sb.WriteString(fmt.Sprintf(msgDistractionReasons, formatDistractionReasons(stats.TopReasons)))
+		}
+		if quality := renderDistractionQuality(stats.Quality); quality != "" {
+			sb.WriteString(msgDistractionQuality + quality)
+		}
+		msg = sb.String()
+	}
+	msg += msgDistractionUsage
+
+	if _, err := a.bot.Send(c.Sender(), msg, &tb.SendOptions{ParseMode: tb.ModeMarkdown}); err != nil {
+		return apperrors.NewInternal().WithDescriptionAndCause(ErrMsgDistractionStats, err)
+	}
+
+	return nil
+}
+
+func renderDistractionQuality(quality []models.FocusDistractionQuality) string {
+	var sb strings.Builder
+	for _, bucket := range distractionBuckets {
+		var rated int
+		var sum float64
+		for _, q := range quality {
+			if q.Distractions < bucket.min || (bucket.max >= 0 && q.Distractions > bucket.max) {
+				continue
+			}
+			rated += q.RatedSessions
+			sum += q.AverageQuality * float64(q.RatedSessions)
+		}
+		if rated == 0 {
+			continue
+		}
+		sb.WriteString(fmt.Sprintf(msgDistractionBucket, bucket.name, sum/float64(rated), rated))
+	}
+
+	return sb.String()
+}
+
+func formatDistractionReasons(reasons []models.FocusDistractionReason) string {
+	parts := make([]string, 0, len(reasons))
+	for _, reason := range reasons {
+		part := markdownEscaper.Replace(reason.Reason)
+		if reason.Count > 1 {
+			part += fmt.Sprintf(" ×%d", reason.Count)
+		}
+		parts = append(parts, part)
+	}
+
+	return strings.Join(parts, ", ")
+}
@@ -1,10 +1,12 @@
 package telegram
 
 import (
+	"attune/internal/api"
 	"attune/internal/dto"
 	"attune/internal/models"
 	"attune/pkg/apperrors"
 	"context"
+	"fmt"
 	"strconv"
 	"time"
 
@@ -19,14 +21,24 @@ const (
 	keyFocusResume = "focus_resume"
 	keyFocusStop   = "focus_stop"
 
+	keyFocusExtend5  = "focus_extend_5"
+	keyFocusExtend15 = "focus_extend_15"
+
 	msgCustomPrompt    = "⌨️ _Please enter your desired duration_\n(e.g., `45m` for 45 minutes)."
 	msgSessionStarted  = "✅ *Your focus session has started!*\nDuration: "
 	msgInvalidDuration = "❌ *Invalid duration format.*\nPlease try again (e.g., `45m`)."
-	msgSessionPaused   = "⏸️ *Your focus session is paused.*"
-	msgSessionResumed  = "▶️ *Your focus session has resumed!*"
+	msgSessionPaused   = "⏸️ Your focus session is paused."
+	msgSessionResumed  = "▶️ Your focus session has resumed!"
 	msgSessionStopped  = "🛑 *Your focus session has been stopped.*"
 	msgFocusQuality    = "How was your focus quality? Please select a value between 1 and 10."
 	msgSessionFinished = "✅ *Your focus session has finished!*"
+	msgSessionExtended = "⏩ Added %s to your focus session."
+	msgSessionAbandon  = "⌛ *Your focus session was stopped.*\nIt stayed paused for too long."
+	msgFocusedTime     = "\nFocused: "
+	msgPausedTime      = "\nPaused: "
+
+	msgMilestoneProgress = "⏳ *%d%% done!* `%s` left, keep going!"
+	msgMilestoneTimeLeft = "⏰ Only `%s` left in your focus session."
 
 	msgInvalidRating      = "Invalid rating. Please send a number between 1 and 10."
 	msgRatingOutOfRange   = "Rating must be between 1 and 10."
@@ -34,14 +46,28 @@ const (
 	msgFailedUpdateRating = "Failed to update quality rating."
 )
 
+type focusRating struct {
+	SessionID string
+	RoomID    string
+	Status    models.FocusSessionStatus
+}
+
+func (a *API) awaitsFocusRating(vendorID string) bool {
+	_, ok := a.cache.Get(prefixRateFocusQuality + vendorID)
+	return ok
+}
+
 var (
 	ErrMsgFocusSessionMenu         = "failed to send focus session menu"
 	ErrMsgFocusSessionConfirmation = "failed to send focus session confirmation"
 	ErrMsgFocusSessionUpdate       = "failed to update focus session"
+	ErrMsgFocusSessionExtend       = "failed to extend focus session"
 	ErrMsgSendConfirmation         = "failed to send confirmation"
 	ErrMsgInvalidVendorID          = "invalid vendor ID"
 	ErrMsgFinishConfirmation       = "failed to send finish confirmation"
+	ErrMsgAbandonNotice            = "failed to send abandoned session notice"
 	ErrMsgFocusQualityPrompt       = "failed to send focus quality prompt"
+	ErrMsgFocusMilestone           = "failed to send focus milestone"
 )
 
 func (a *API) registerFocusSessionCallbacks() {
@@ -75,7 +101,27 @@ func (a *API) registerFocusSessionCallbacks() {
 	a.bot.Handle(tb.OnText, func(c tb.Context) error {
 		userID := strconv.FormatInt(c.Sender().ID, 10)
 
-		if _, ok := a.cache.Get(prefixCustomDuration + userID); ok {
+		if _, ok := a.cache.Get(prefixNewLabel + userID); ok {
+			return a.handleNewLabelInput(c)
+		} else if sessionID, ok := a.cache.Get(prefixFocusNote + userID); ok && !a.awaitsFocusRating(userID) {
+			sessionID, ok := sessionID.(string)
+			if !ok {
+				a.logger.Error(context.Background(), "Failed to type cast focus note session", nil, "user", c.Sender().ID)
+				return nil
+			}
+
+			return a.handleFocusNoteInput(c, sessionID)
+		} else if entryID, ok := a.cache.Get(prefixJournalEntry + userID); ok {
+			entryID, ok := entryID.(string)
+			if !ok {
+				a.logger.Error(context.Background(), "Failed to type cast journal entry", nil, "user", c.Sender().ID)
+				return nil
+			}
+
+			return a.handleJournalInput(c, entryID)
+		} else if _, ok := a.cache.Get(prefixDayMoodNote + userID); ok {
+			return a.handleDayMoodNoteInput(c)
+		} else if _, ok := a.cache.Get(prefixCustomDuration + userID); ok {
 			input := c.Message().Text
 
 			duration, err := time.ParseDuration(input)
@@ -92,10 +138,12 @@ func (a *API) registerFocusSessionCallbacks() {
 			}
 
 			return nil
-		} else if focusSessionStatus, ok := a.cache.Get(prefixRateFocusQuality + userID); ok { // Check if user is rating focus quality
-			focusSessionStatus, ok := focusSessionStatus.(models.FocusSessionStatus)
+		} else if _, ok := a.cache.Get(prefixCustomPomodoro + userID); ok {
+			return a.handleCustomPomodoroInput(c)
+		} else if pendingRating, ok := a.cache.Get(prefixRateFocusQuality + userID); ok { // Check if user is rating focus quality
+			pendingRating, ok := pendingRating.(focusRating)
 			if !ok {
-				a.logger.Error(context.Background(), "Failed to type cast focus session status", nil, "user", c.Sender().ID)
+				a.logger.Error(context.Background(), "Failed to type cast focus rating", nil, "user", c.Sender().ID)
 				return nil
 			}
 
@@ -114,10 +162,11 @@ func (a *API) registerFocusSessionCallbacks() {
 			}
 
 			updateDTO := dto.UpdateFocusRequest{
-				VendorID: userID,
-				Type:     dto.UpdateFocusRequestTypeQuality,
-				Quality:  rating,
-				Status:   focusSessionStatus,
+				VendorID:  userID,
+				SessionID: pendingRating.SessionID,
+				Type:      dto.UpdateFocusRequestTypeQuality,
+				Quality:   rating,
+				Status:    pendingRating.Status,
 			}
 			if err := a.services.FocusSessionService.Update(context.Background(), updateDTO); err != nil {
 				_, _ = a.bot.Send(c.Sender(), msgFailedUpdateRating, &tb.SendOptions{ParseMode: tb.ModeMarkdown})
@@ -128,9 +177,15 @@ func (a *API) registerFocusSessionCallbacks() {
 
 			_, _ = a.bot.Send(c.Sender(), msgThankRating, &tb.SendOptions{ParseMode: tb.ModeMarkdown})
 
-			if err := a.SendFocusSessionMenu(c, ""); err != nil {
-				a.logger.Error(context.Background(), "Failed to send focus session menu after rating", err, "user", c.Sender().ID)
-				return apperrors.NewInternal().WithDescriptionAndCause(ErrMsgFocusSessionMenu, err)
+			if pendingRating.RoomID != "" {
+				if err := a.refreshRoomSummary(context.Background(), pendingRating.RoomID); err != nil {
+					a.logger.Error(context.Background(), "Failed to refresh focus room summary", err, "room", pendingRating.RoomID)
+				}
+			}
+
+			if err := a.askFocusNote(c, pendingRating.SessionID); err != nil {
+				a.logger.Error(context.Background(), "Failed to send focus note prompt after rating", err, "user", c.Sender().ID)
+				return err
 			}
 
 			return nil
@@ -145,6 +200,14 @@ func (a *API) registerFocusSessionCallbacks() {
 		{key: keyFocusPause, handler: a.pauseFocusSession},
 		{key: keyFocusResume, handler: a.resumeFocusSession},
 		{key: keyFocusStop, handler: a.stopFocusSession},
+		{key: keyFocusSkipBreak, handler: a.skipPomodoroBreak},
+		{key: keyFocusEndCycle, handler: a.endPomodoroCycle},
+		{key: keyFocusExtend5, handler: func(c tb.Context) error {
+			return a.extendFocusSession(c, 5*time.Minute)
+		}},
+		{key: keyFocusExtend15, handler: func(c tb.Context) error {
+			return a.extendFocusSession(c, 15*time.Minute)
+		}},
 	}
 	for _, ctrl := range controls {
 		ctrlCopy := ctrl
@@ -160,9 +223,9 @@ func (a *API) registerFocusSessionCallbacks() {
 }
 
 func (a *API) createFocusSession(c tb.Context) error {
-	if err := a.SendFocusSessionMenu(c, ""); err != nil {
-		a.logger.Error(context.Background(), "Failed to send focus session menu", err, "user", c.Sender().ID)
-		return apperrors.NewInternal().WithDescriptionAndCause(ErrMsgFocusSessionMenu, err)
+	if err := a.sendLabelMenu(c); err != nil {
+		a.logger.Error(context.Background(), "Failed to send focus label menu", err, "user", c.Sender().ID)
+		return err
 	}
 
 	return nil
@@ -174,9 +237,29 @@ func (a *API) startFocusSession(c tb.Context, duration time.Duration) error {
 	req := dto.CreateFocusSessionRequest{
 		VendorID: vendorID,
 		Duration: duration,
+		Label:    a.takeFocusLabel(vendorID),
+		Source:   models.FocusSessionEventSourceTelegram,
 	}
+	confirmationMsg := msgSessionStarted + "`" + formatDuration(duration) + "`"
 
-	if err := a.services.FocusSessionService.Create(context.Background(), req); err != nil {
+	return a.launchFocusSession(c, req, confirmationMsg, focusControlMarkup(false))
+}
+
+func (a *API) launchFocusSession(
+	c tb.Context,
+	req dto.CreateFocusSessionRequest,
+	confirmationMsg string,
+	controlMarkup *tb.ReplyMarkup,
+) error {
+	ctx := context.Background()
+	if err := a.services.FocusSessionService.Create(ctx, req); err != nil {
+		if apperrors.IsCode(err, apperrors.Conflict) {
+			return a.askToReplaceFocusSession(c, pendingFocusSession{
+				request:         req,
+				confirmationMsg: confirmationMsg,
+				controlMarkup:   controlMarkup,
+			})
+		}
 		if apperrors.IsCode(err, apperrors.BadRequest) {
 
 			errorMsg := apperrors.GetMessage(err) + "\nPlease choose a new duration:"
@@ -187,25 +270,52 @@ func (a *API) startFocusSession(c tb.Context, duration time.Duration) error {
 
 		return err
 	}
-	controlMarkup := &tb.ReplyMarkup{
+
+	opts := &tb.SendOptions{
+		ParseMode:   tb.ModeMarkdown,
+		ReplyMarkup: controlMarkup,
+	}
+	sent, err := a.bot.Send(c.Sender(), confirmationMsg, opts)
+	if err != nil {
+		return apperrors.NewInternal().WithDescriptionAndCause(ErrMsgFocusSessionConfirmation, err)
+	}
+
+	state, err := a.services.FocusSessionService.State(ctx, req.VendorID)
+	if err != nil {
+		a.logger.Error(ctx, "Failed to get focus session state", err, "user", c.Sender().ID)
+		return nil
+	}
+	a.trackLiveMessage(req.VendorID, state.SessionID, sent)
+
+	return nil
+}
+
+func focusControlMarkup(paused bool) *tb.ReplyMarkup {
+	return &tb.ReplyMarkup{
 		InlineKeyboard: [][]tb.InlineButton{
 			{
-				{Unique: keyFocusPause, Text: "Pause"},
-				{Unique: keyFocusResume, Text: "Resume"},
+				pauseOrResumeButton(paused),
 				{Unique: keyFocusStop, Text: "Stop"},
 			},
+			extendButtons(),
+			{distractedButton()},
 		},
 	}
-	opts := &tb.SendOptions{
-		ParseMode:   tb.ModeMarkdown,
-		ReplyMarkup: controlMarkup,
+}
+
+func extendButtons() []tb.InlineButton {
+	return []tb.InlineButton{
+		{Unique: keyFocusExtend5, Text: "+5 min"},
+		{Unique: keyFocusExtend15, Text: "+15 min"},
 	}
-	confirmationMsg := msgSessionStarted + "`" + duration.String() + "`"
-	if _, err := a.bot.Send(c.Sender(), confirmationMsg, opts); err != nil {
-		return apperrors.NewInternal().WithDescriptionAndCause(ErrMsgFocusSessionConfirmation, err)
+}
+
+func pauseOrResumeButton(paused bool) tb.InlineButton {
+	if paused {
+		return tb.InlineButton{Unique: keyFocusResume, Text: "Resume"}
 	}
 
-	return nil
+	return tb.InlineButton{Unique: keyFocusPause, Text: "Pause"}
 }
 
 func (a *API) updateFocusSession(
@@ -218,11 +328,16 @@ func (a *API) updateFocusSession(
 	updateDTO := dto.UpdateFocusRequest{
 		VendorID: vendorID,
 		Type:     updateType,
+		Source:   models.FocusSessionEventSourceTelegram,
 	}
 	if err := a.services.FocusSessionService.Update(context.Background(), updateDTO); err != nil {
 		return apperrors.NewInternal().WithDescriptionAndCause(ErrMsgFocusSessionUpdate, err)
 	}
 
+	return a.updateFocusSessionReply(c, successMsg)
+}
+
+func (a *API) updateFocusSessionReply(c tb.Context, successMsg string) error {
 	opts := &tb.SendOptions{ParseMode: tb.ModeMarkdown}
 	if _, err := a.bot.Send(c.Sender(), successMsg, opts); err != nil {
 		return apperrors.NewInternal().WithDescriptionAndCause(ErrMsgSendConfirmation, err)
@@ -232,38 +347,205 @@ func (a *API) updateFocusSession(
 }
 
 func (a *API) pauseFocusSession(c tb.Context) error {
-	return a.updateFocusSession(c, dto.UpdateFocusRequestTypePause, msgSessionPaused)
+	return a.toggleFocusSession(c, dto.UpdateFocusRequestTypePause, msgSessionPaused)
 }
 
 func (a *API) resumeFocusSession(c tb.Context) error {
-	return a.updateFocusSession(c, dto.UpdateFocusRequestTypeResume, msgSessionResumed)
+	return a.toggleFocusSession(c, dto.UpdateFocusRequestTypeResume, msgSessionResumed)
+}
+
+func (a *API) toggleFocusSession(
+	c tb.Context,
+	updateType dto.UpdateFocusRequestType,
+	notice string,
+) error {
+	vendorID := strconv.FormatInt(c.Sender().ID, 10)
+
+	updateDTO := dto.UpdateFocusRequest{
+		VendorID: vendorID,
+		Type:     updateType,
+		Source:   models.FocusSessionEventSourceTelegram,
+	}
+	if err := a.services.FocusSessionService.Update(context.Background(), updateDTO); err != nil {
+		_ = c.Respond(&tb.CallbackResponse{Text: apperrors.GetMessage(err)})
+		return apperrors.NewInternal().WithDescriptionAndCause(ErrMsgFocusSessionUpdate, err)
+	}
+
+	if _, ok := a.getLiveMessage(vendorID); !ok {
+		return a.updateFocusSessionReply(c, notice)
+	}
+
+	a.refreshLiveMessage(context.Background(), vendorID, true)
+	if err := c.Respond(&tb.CallbackResponse{Text: notice}); err != nil {
+		return apperrors.NewInternal().WithDescriptionAndCause(ErrMsgSendConfirmation, err)
+	}
+
+	return nil
 }
 
 func (a *API) stopFocusSession(c tb.Context) error {
 	return a.updateFocusSession(c, dto.UpdateFocusRequestTypeStop, msgSessionStopped)
 }
 
+func (a *API) extendFocusSession(c tb.Context, delta time.Duration) error {
+	ctx := context.Background()
+	vendorID := strconv.FormatInt(c.Sender().ID, 10)
+
+	updateDTO := dto.UpdateFocusRequest{
+		VendorID: vendorID,
+		Type:     dto.UpdateFocusRequestTypeExtend,
+		Delta:    delta,
+		Source:   models.FocusSessionEventSourceTelegram,
+	}
+	if err := a.services.FocusSessionService.Update(ctx, updateDTO); err != nil {
+		_ = c.Respond(&tb.CallbackResponse{Text: apperrors.GetMessage(err)})
+		return apperrors.NewInternal().WithDescriptionAndCause(ErrMsgFocusSessionExtend, err)
+	}
+
+	notice := fmt.Sprintf(msgSessionExtended, formatDuration(delta))
+	if _, ok := a.getLiveMessage(vendorID); ok {
+		a.refreshLiveMessage(ctx, vendorID, true)
+		if err := c.Respond(&tb.CallbackResponse{Text: notice}); err != nil {
+			return apperrors.NewInternal().WithDescriptionAndCause(ErrMsgSendConfirmation, err)
+		}
+
+		return nil
+	}
+
+	// The session was reopened, so it is rated once it finishes again.
+	a.cache.Delete(prefixRateFocusQuality + vendorID)
+	if _, err := a.bot.EditReplyMarkup(c.Message(), nil); err != nil {
+		a.logger.Error(ctx, "Failed to remove extend buttons", err, "user", c.Sender().ID)
+	}
+
+	state, err := a.services.FocusSessionService.State(ctx, vendorID)
+	if err != nil {
+		return err
+	}
+
+	opts := &tb.SendOptions{
+		ParseMode:   tb.ModeMarkdown,
+		ReplyMarkup: liveMessageMarkup(state),
+	}
+	sent, err := a.bot.Send(c.Sender(), renderLiveMessage(state), opts)
+	if err != nil {
+		return apperrors.NewInternal().WithDescriptionAndCause(ErrMsgSendConfirmation, err)
+	}
+	a.trackLiveMessage(vendorID, state.SessionID, sent)
+
+	return c.Respond(&tb.CallbackResponse{Text: notice})
+}
+
 func (a *API) finishFocusSession(
-	_ context.Context,
+	ctx context.Context,
 	vendorID string,
-	focusSessionStatus models.FocusSessionStatus,
+	trigger api.Trigger,
 ) error {
-	chatID, err := strconv.ParseInt(vendorID, 10, 64)
+	// The group summary is posted even when the member can't be messaged directly.
+	if trigger.RoomID != "" {
+		defer func() {
+			if err := a.refreshRoomSummary(ctx, trigger.RoomID); err != nil {
+				a.logger.Error(ctx, "Failed to refresh focus room summary", err, "room", trigger.RoomID)
+			}
+		}()
+	}
+	// A note prompt left unanswered must not take the next session's rating.
+	a.cache.Delete(prefixFocusNote + vendorID)
+
+	vendorChat, err := chatFromVendorID(vendorID)
 	if err != nil {
-		return apperrors.NewInternal().WithDescriptionAndCause(ErrMsgInvalidVendorID, err)
+		return err
 	}
 
-	vendorChat := &tb.Chat{ID: chatID}
-	opts := &tb.SendOptions{ParseMode: tb.ModeMarkdown}
-	if _, err := a.bot.Send(vendorChat, msgSessionFinished, opts); err != nil {
+	a.finishLiveMessage(vendorID, trigger.SessionID)
+
+	finishMsg := msgSessionFinished + msgFocusedTime + "`" + formatDuration(trigger.FocusedDuration) + "`"
+	if trigger.PausedDuration > 0 {
+		finishMsg += msgPausedTime + "`" + formatDuration(trigger.PausedDuration) + "`"
+	}
+	finishMsg += a.formatSessionDistractions(ctx, vendorID, trigger.SessionID)
+	finishMsg += formatGoalProgress(trigger.DailyGoal)
+
+	finishMarkup := &tb.ReplyMarkup{}
+	// Room sessions end together, so they can't be extended afterwards.
+	if trigger.FocusSessionStatus == models.FocusSessionStatusCompleted && trigger.RoomID == "" {
+		finishMarkup.InlineKeyboard = append(finishMarkup.InlineKeyboard, extendButtons())
+	}
+	finishMarkup.InlineKeyboard = append(finishMarkup.InlineKeyboard, breakButtons())
+	finishOpts := &tb.SendOptions{ParseMode: tb.ModeMarkdown, ReplyMarkup: finishMarkup}
+	if _, err := a.bot.Send(vendorChat, finishMsg, finishOpts); err != nil {
 		return apperrors.NewInternal().WithDescriptionAndCause(ErrMsgFinishConfirmation, err)
 	}
 
+	opts := &tb.SendOptions{ParseMode: tb.ModeMarkdown}
+
 	if _, err := a.bot.Send(vendorChat, msgFocusQuality, opts); err != nil {
 		return apperrors.NewInternal().WithDescriptionAndCause(ErrMsgFocusQualityPrompt, err)
 	}
 
-	a.cache.Set(prefixRateFocusQuality+vendorID, focusSessionStatus)
+	a.cache.Set(prefixRateFocusQuality+vendorID, focusRating{
+		SessionID: trigger.SessionID,
+		RoomID:    trigger.RoomID,
+		Status:    trigger.FocusSessionStatus,
+	})
+
+	return nil
+}
+
+func (a *API) abandonFocusSession(ctx context.Context, vendorID string, trigger api.Trigger) error {
+	if trigger.RoomID != "" {
+		defer func() {
+			if err := a.refreshRoomSummary(ctx, trigger.RoomID); err != nil {
+				a.logger.Error(ctx, "Failed to refresh focus room summary", err, "room", trigger.RoomID)
+			}
+		}()
+	}
+	a.cache.Delete(prefixFocusNote + vendorID)
+
+	vendorChat, err := chatFromVendorID(vendorID)
+	if err != nil {
+		return err
+	}
+
+	a.finishLiveMessage(vendorID, trigger.SessionID)
+
+	msg := msgSessionAbandon + msgFocusedTime + "`" + formatDuration(trigger.FocusedDuration) + "`" +
+		formatGoalProgress(trigger.DailyGoal)
+	if _, err := a.bot.Send(vendorChat, msg, &tb.SendOptions{ParseMode: tb.ModeMarkdown}); err != nil {
+		return apperrors.NewInternal().WithDescriptionAndCause(ErrMsgAbandonNotice, err)
+	}
 
 	return nil
 }
+
+func (a *API) notifyFocusMilestone(_ context.Context, vendorID string, trigger api.Trigger) error {
+	vendorChat, err := chatFromVendorID(vendorID)
+	if err != nil {
+		return err
+	}
+
+	remaining := formatDuration(trigger.Remaining.Round(time.Minute))
+	msg := fmt.Sprintf(msgMilestoneTimeLeft, remaining)
+	if trigger.Type == api.TriggerTypeMilestoneProgress {
+		msg = fmt.Sprintf(msgMilestoneProgress, trigger.Milestone.Percent, remaining)
+	}
+
+	if _, err := a.bot.Send(vendorChat, msg, &tb.SendOptions{ParseMode: tb.ModeMarkdown}); err != nil {
+		return apperrors.NewInternal().WithDescriptionAndCause(ErrMsgFocusMilestone, err)
+	}
+
+	return nil
+}
+
+func chatFromVendorID(vendorID string) (*tb.Chat, error) {
+	chatID, err := strconv.ParseInt(vendorID, 10, 64)
+	if err != nil {
+		return nil, apperrors.NewInternal().WithDescriptionAndCause(ErrMsgInvalidVendorID, err)
+	}
+
+	return &tb.Chat{ID: chatID}, nil
+}
+
+func formatDuration(d time.Duration) string {
+	return d.Round(time.Second).String()
+}
@@ -0,0 +1,127 @@
+package telegram
+
+import (
+	"attune/internal/api"
+	"attune/internal/models"
+	"attune/pkg/apperrors"
+	"context"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	tb "gopkg.in/telebot.v4"
+)
+
+const (
+	msgGoalUsage = "🎯 *Daily focus goal*\n" +
+		"`/goal <minutes>` sets it (e.g. `/goal 120` or `/goal 2h`), `/goal off` turns it off."
+	msgGoalCurrent  = "🎯 Your daily focus goal is `%d min`."
+	msgGoalNone     = "🎯 You have no daily focus goal yet."
+	msgGoalSet      = "✅ Daily focus goal set to `%d min`."
+	msgGoalOff      = "✅ Daily focus goal turned off."
+	msgGoalInvalid  = "❌ *Invalid goal.*\n"
+	msgGoalProgress = "\n🎯 %d/%d min today"
+	msgGoalReached  = "🎉 *Daily goal reached!*\nYou focused `%d min` today, your goal was `%d min`. Great work!"
+)
+
+var (
+	ErrMsgGoal        = "failed to send daily focus goal"
+	ErrMsgGoalReached = "failed to send daily goal celebration"
+)
+
+func (a *API) registerGoalCommand() {
+	a.bot.Handle("/goal", func(c tb.Context) error {
+		err := a.handleGoal(c)
+		if err != nil {
+			a.logger.Error(context.Background(), "Error handling /goal command", err, "user", c.Sender().ID)
+		}
+		return err
+	})
+}
+
+func (a *API) handleGoal(c tb.Context) error {
+	ctx := context.Background()
+	vendorID := strconv.FormatInt(c.Sender().ID, 10)
+	opts := &tb.SendOptions{ParseMode: tb.ModeMarkdown}
+
+	args := c.Args()
+	if len(args) == 0 {
+		settings, err := a.services.UserSettingsService.Get(ctx, vendorID)
+		if err != nil {
+			return err
+		}
+
+		msg := msgGoalNone
+		if settings.DailyFocusGoal > 0 {
+			msg = fmt.Sprintf(msgGoalCurrent, int(settings.DailyFocusGoal.Minutes()))
+		}
+		if _, err := a.bot.Send(c.Sender(), msg+"\n\n"+msgGoalUsage, opts); err != nil {
+			return apperrors.NewInternal().WithDescriptionAndCause(ErrMsgGoal, err)
+		}
+		return nil
+	}
+
+	goal, err := parseGoal(args[0])
+	if err != nil {
+		_, _ = a.bot.Send(c.Sender(), msgGoalInvalid+msgGoalUsage, opts)
+		return nil
+	}
+
+	if err := a.services.UserSettingsService.SetDailyFocusGoal(ctx, vendorID, goal); err != nil {
+		if apperrors.IsCode(err, apperrors.BadRequest) {
+			_, _ = a.bot.Send(c.Sender(), msgGoalInvalid+apperrors.GetMessage(err), opts)
+			return nil
+		}
+		return err
+	}
+
+	msg := msgGoalOff
+	if goal > 0 {
+		msg = fmt.Sprintf(msgGoalSet, int(goal.Minutes()))
+	}
+	if _, err := a.bot.Send(c.Sender(), msg, opts); err != nil {
+		return apperrors.NewInternal().WithDescriptionAndCause(ErrMsgGoal, err)
+	}
+
+	return nil
+}
+
+func parseGoal(input string) (time.Duration, error) {
+	input = strings.ToLower(input)
+	if input == "off" {
+		return 0, nil
+	}
+	if minutes, err := strconv.Atoi(input); err == nil {
+		return time.Duration(minutes) * time.Minute, nil
+	}
+
+	return time.ParseDuration(input)
+}
+
+func (a *API) celebrateGoal(_ context.Context, vendorID string, trigger api.Trigger) error {
+	if trigger.DailyGoal == nil {
+		return nil
+	}
+
+	vendorChat, err := chatFromVendorID(vendorID)
+	if err != nil {
+		return err
+	}
+
+	goal := trigger.DailyGoal
+	msg := fmt.Sprintf(msgGoalReached, int(goal.Focused.Minutes()), int(goal.Goal.Minutes()))
+	if _, err := a.bot.Send(vendorChat, msg, &tb.SendOptions{ParseMode: tb.ModeMarkdown}); err != nil {
+		return apperrors.NewInternal().WithDescriptionAndCause(ErrMsgGoalReached, err)
+	}
+
+	return nil
+}
+
+func formatGoalProgress(progress *models.DailyFocusGoalProgress) string {
+	if progress == nil {
+		return ""
+	}
+
+	return fmt.Sprintf(msgGoalProgress, int(progress.Focused.Minutes()), int(progress.Goal.Minutes()))
+}
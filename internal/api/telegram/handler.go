@@ -33,19 +33,20 @@ type APITelegramMessagesConfig struct {
 }
 
 type API struct {
-	token       string
-	baseURL     string
-	pollTimeout time.Duration
-	bot         *tb.Bot
-	services    service.Services
-	logger      logger.Logger
-	cache       cache.Cache
-	apiCh       <-chan api.Trigger
+	token              string
+	baseURL            string
+	pollTimeout        time.Duration
+	liveUpdateInterval time.Duration
+	bot                *tb.Bot
+	services           service.Services
+	logger             logger.Logger
+	cache              cache.Cache
+	apiCh              <-chan api.Trigger
 }
 
 func NewTelegramAPI(
 	token, baseURL string,
-	pollTimeout time.Duration,
+	pollTimeout, liveUpdateInterval time.Duration,
 	services service.Services,
 	logger logger.Logger,
 	cache cache.Cache,
@@ -57,6 +58,9 @@ func NewTelegramAPI(
 	if pollTimeout == 0 {
 		pollTimeout = 10 * time.Second
 	}
+	if liveUpdateInterval == 0 {
+		liveUpdateInterval = 30 * time.Second
+	}
 
 	pref := tb.Settings{
 		Token:  token,
@@ -68,23 +72,41 @@ func NewTelegramAPI(
 	}
 
 	return &API{
-		token:       token,
-		baseURL:     baseURL,
-		bot:         bot,
-		pollTimeout: pollTimeout,
-		services:    services,
-		logger:      logger,
-		cache:       cache,
-		apiCh:       apiCh,
+		token:              token,
+		baseURL:            baseURL,
+		bot:                bot,
+		pollTimeout:        pollTimeout,
+		liveUpdateInterval: liveUpdateInterval,
+		services:           services,
+		logger:             logger,
+		cache:              cache,
+		apiCh:              apiCh,
 	}
 }
 
 func (a *API) Start(ctx context.Context) error {
 	registerStartCommand(a)
 	a.registerFocusSessionCallbacks()
+	a.registerPomodoroCallbacks()
+	a.registerFocusConflictCallbacks()
+	a.registerScheduleCommands()
+	a.registerLabelCallbacks()
+	a.registerGoalCommand()
+	a.registerRoomCommands()
+	a.registerNoteCallbacks()
+	a.registerDistractionCommands()
+	a.registerBreakCallbacks()
+	a.registerDayCommands()
+	a.registerMoodCommands()
+	a.registerJournalCommands()
+	a.registerChartCommands()
+	a.registerReportCommand()
+	a.registerInsightCommand()
+	a.registerProfileCommand()
 
 	go a.bot.Start()
 	go a.ListenTriggers(ctx)
+	go a.ListenLiveMessages(ctx)
 
 	<-ctx.Done()
 	a.bot.Stop()
@@ -95,7 +117,29 @@ func (a *API) Start(ctx context.Context) error {
 func (a *API) Trigger(ctx context.Context, vendorID string, trigger api.Trigger) error {
 	switch trigger.Type {
 	case api.TriggerTypeFinishSession:
-		return a.finishFocusSession(ctx, vendorID, trigger.FocusSessionStatus)
+		return a.finishFocusSession(ctx, vendorID, trigger)
+	case api.TriggerTypeBreakStart:
+		return a.startPomodoroBreak(ctx, vendorID, trigger)
+	case api.TriggerTypeBreakEnd:
+		return a.endPomodoroBreak(ctx, vendorID, trigger)
+	case api.TriggerTypeCycleComplete:
+		return a.completePomodoroCycle(ctx, vendorID, trigger)
+	case api.TriggerTypeMilestoneProgress, api.TriggerTypeMilestoneTimeLeft:
+		return a.notifyFocusMilestone(ctx, vendorID, trigger)
+	case api.TriggerTypeScheduledSession:
+		return a.promptScheduledSession(ctx, vendorID, trigger)
+	case api.TriggerTypeSessionAbandoned:
+		return a.abandonFocusSession(ctx, vendorID, trigger)
+	case api.TriggerTypeGoalReached:
+		return a.celebrateGoal(ctx, vendorID, trigger)
+	case api.TriggerTypeBreakFinished:
+		return a.finishBreak(ctx, vendorID, trigger)
+	case api.TriggerTypeDayCheckIn:
+		return a.remindDayCheckIn(ctx, vendorID)
+	case api.TriggerTypeReport:
+		return a.deliverReport(ctx, vendorID, trigger)
+	case api.TriggerTypeBadgesEarned:
+		return a.announceBadges(ctx, vendorID, trigger)
 	}
 	return nil
 }
@@ -0,0 +1,123 @@
+package telegram
+
+import (
+	"attune/internal/models"
+	"attune/pkg/apperrors"
+	"context"
+	"fmt"
+	"strconv"
+	"strings"
+
+	tb "gopkg.in/telebot.v4"
+)
+
+const (
+	msgInsightsTitle = "🔍 *Insights* · last %d days"
+	msgInsightsNone  = "No clear patterns yet. They show up once the numbers are convincing, not on a hunch."
+	msgInsightsGaps  = "⏳ *Still collecting*"
+	msgInsightsBasis = "_Based on %d %s, r = %.2f._"
+
+	msgInsightFocusMore       = "🎯 Days you focus more tend to be better days."
+	msgInsightFocusLess       = "🎯 Your better days tend to have less focus time. Rest may matter more than you think."
+	msgInsightLengthLonger    = "⏱ Your longer sessions tend to get better ratings."
+	msgInsightLengthShorter   = "⏱ Your shorter sessions tend to get better ratings."
+	msgInsightMoodInterrupted = "💭 Days with more interruptions tend to feel worse."
+	msgInsightMoodUndisturbed = "💭 Days with more interruptions tend to feel better, oddly enough."
+	msgInsightTimeOfDay       = "🕒 Your %s sessions rate best: %.1f/10 on average, against %.1f/10 in the %s."
+	msgInsightTimeOfDayBasis  = "_Based on %d rated sessions._"
+	msgInsightGapFocus        = "• Focus vs day quality: %d of %d rated days"
+	msgInsightGapLength       = "• Session length vs rating: %d of %d rated sessions"
+	msgInsightGapMood         = "• Interruptions vs mood: %d of %d days with emotions"
+	msgInsightGapTimeOfDay    = "• Time of day: 5 rated sessions in at least %d parts of the day (%d so far)"
+)
+
+var (
+	ErrMsgInsights = "failed to send insights"
+)
+
+func (a *API) registerInsightCommand() {
+	a.bot.Handle("/insights", func(c tb.Context) error {
+		err := a.sendInsights(c)
+		if err != nil {
+			a.logger.Error(context.Background(), "Error handling /insights command", err, "user", c.Sender().ID)
+		}
+		return err
+	})
+}
+
+func (a *API) sendInsights(c tb.Context) error {
+	vendorID := strconv.FormatInt(c.Sender().ID, 10)
+
+	insights, err := a.services.InsightService.Insights(context.Background(), vendorID)
+	if err != nil {
+		return err
+	}
+
+	if _, err := a.bot.Send(c.Sender(), renderInsights(insights), &tb.SendOptions{ParseMode: tb.ModeMarkdown}); err != nil {
+		return apperrors.NewInternal().WithDescriptionAndCause(ErrMsgInsights, err)
+	}
+
+	return nil
+}
+
+func renderInsights(insights models.Insights) string {
+	lines := []string{fmt.Sprintf(msgInsightsTitle, int(insights.Window.Hours()/24)), ""}
+
+	if len(insights.Insights) == 0 {
+		lines = append(lines, msgInsightsNone)
+	}
+	for _, insight := range insights.Insights {
+		lines = append(lines, renderInsight(insight), "")
+	}
+
+	if len(insights.Gaps) > 0 {
+		lines = append(lines, "", msgInsightsGaps)
+		for _, gap := range insights.Gaps {
+			lines = append(lines, renderInsightGap(gap))
+		}
+	}
+
+	return strings.TrimSpace(strings.Join(lines, "\n"))
+}
+
+func renderInsight(insight models.Insight) string {
+	positive := insight.Correlation > 0
+
+	var text, samples string
+	switch insight.Kind {
+	case models.InsightKindFocusDayQuality:
+		text, samples = pick(positive, msgInsightFocusMore, msgInsightFocusLess), "rated days"
+	case models.InsightKindSessionLength:
+		text, samples = pick(positive, msgInsightLengthLonger, msgInsightLengthShorter), "rated sessions"
+	case models.InsightKindMoodDistractions:
+		text, samples = pick(positive, msgInsightMoodUndisturbed, msgInsightMoodInterrupted), "days"
+	case models.InsightKindTimeOfDay:
+		text = fmt.Sprintf(msgInsightTimeOfDay, insight.Best, insight.BestAverage, insight.WorstAverage, insight.Worst)
+		return text + "\n" + fmt.Sprintf(msgInsightTimeOfDayBasis, insight.Samples)
+	}
+
+	return text + "\n" + fmt.Sprintf(msgInsightsBasis, insight.Samples, samples, insight.Correlation)
+}
+
+func renderInsightGap(gap models.InsightGap) string {
+	switch gap.Kind {
+	case models.InsightKindFocusDayQuality:
+		return fmt.Sprintf(msgInsightGapFocus, gap.Samples, gap.Needed)
+	case models.InsightKindSessionLength:
+		return fmt.Sprintf(msgInsightGapLength, gap.Samples, gap.Needed)
+	case models.InsightKindMoodDistractions:
+		return fmt.Sprintf(msgInsightGapMood, gap.Samples, gap.Needed)
+	case models.InsightKindTimeOfDay:
+		return fmt.Sprintf(msgInsightGapTimeOfDay, gap.Needed, gap.Samples)
+	}
+
+	return ""
+}
+
+func pick(condition bool, yes, no string) string {
+	if condition {
+		return yes
+	}
+
+	return no
+}
@@ -0,0 +1,281 @@
+package telegram
+
+import (
+	"attune/internal/dto"
+	"attune/internal/models"
+	"attune/pkg/apperrors"
+	"context"
+	"fmt"
+	"strconv"
+	"strings"
+
+	tb "gopkg.in/telebot.v4"
+)
+
+const (
+	prefixJournalEntry = "journal_entry_"
+
+	keyJournalNew    = "journal_new"
+	keyJournalEdit   = "journal_edit"
+	keyJournalDelete = "journal_delete"
+	keyJournalCancel = "journal_cancel"
+
+	journalRecentLimit   = 5
+	journalSearchLimit   = 10
+	journalPreviewLength = 300
+
+	msgJournalTitle     = "📓 *Journal*"
+	msgJournalEmpty     = "📓 Your journal is empty. Write your first entry with `/journal <text>`."
+	msgJournalUsage     = "Write with `/journal <text>`, search with `/search <text>`."
+	msgJournalPrompt    = "✍️ *New journal entry*\nWrite as much as you like."
+	msgJournalEdit      = "✏️ *Editing the entry of %s*\nSend the new text."
+	msgJournalSaved     = "📓 Saved to your journal for %s."
+	msgJournalUpdated   = "📓 Entry updated."
+	msgJournalDeleted   = "Entry deleted"
+	msgJournalCancelled = "📓 No changes."
+	msgJournalRateDay   = "\nHow was the day? Rate it with /today."
+	msgJournalInvalid   = "❌ *Invalid entry.*\n"
+	msgJournalDayRated  = " · 🌙"
+
+	msgSearchUsage   = "🔎 Search your journal with `/search <text>`, e.g. `/search \"long walk\" -rain`."
+	msgSearchTitle   = "🔎 *Entries matching* \"%s\""
+	msgSearchNoMatch = "🔎 No entries match \"%s\"."
+)
+
+var (
+	ErrMsgJournal       = "failed to send journal"
+	ErrMsgJournalPrompt = "failed to send journal entry prompt"
+	ErrMsgSearch        = "failed to send journal search results"
+)
+
+func (a *API) registerJournalCommands() {
+	a.bot.Handle("/journal", func(c tb.Context) error {
+		err := a.handleJournal(c)
+		if err != nil {
+			a.logger.Error(context.Background(), "Error handling /journal command", err, "user", c.Sender().ID)
+		}
+		return err
+	})
+
+	a.bot.Handle("/search", func(c tb.Context) error {
+		err := a.handleSearch(c)
+		if err != nil {
+			a.logger.Error(context.Background(), "Error handling /search command", err, "user", c.Sender().ID)
+		}
+		return err
+	})
+
+	a.bot.Handle(&tb.InlineButton{Unique: keyJournalNew}, func(c tb.Context) error {
+		_ = c.Respond()
+		return a.askJournalEntry(c.Sender(), "", msgJournalPrompt)
+	})
+
+	a.bot.Handle(&tb.InlineButton{Unique: keyJournalEdit}, func(c tb.Context) error {
+		vendorID := strconv.FormatInt(c.Sender().ID, 10)
+		entry, err := a.services.JournalEntryService.Get(context.Background(), vendorID, c.Data())
+		if err != nil {
+			return c.Respond(&tb.CallbackResponse{Text: apperrors.GetMessage(err)})
+		}
+		_ = c.Respond()
+
+		return a.askJournalEntry(c.Sender(), entry.ID, fmt.Sprintf(msgJournalEdit, formatJournalDate(entry)))
+	})
+
+	a.bot.Handle(&tb.InlineButton{Unique: keyJournalDelete}, func(c tb.Context) error {
+		err := a.deleteJournalEntry(c)
+		if err != nil {
+			a.logger.Error(context.Background(), "Error deleting journal entry", err, "user", c.Sender().ID)
+		}
+		return err
+	})
+
+	a.bot.Handle(&tb.InlineButton{Unique: keyJournalCancel}, func(c tb.Context) error {
+		userID := strconv.FormatInt(c.Sender().ID, 10)
+		a.cache.Delete(prefixJournalEntry + userID)
+
+		if _, err := a.bot.Edit(c.Message(), msgJournalCancelled); err != nil {
+			a.logger.Error(context.Background(), "Failed to close journal entry prompt", err, "user", c.Sender().ID)
+		}
+		return c.Respond()
+	})
+}
+
+func (a *API) handleJournal(c tb.Context) error {
+	ctx := context.Background()
+
+	if err := a.ensureUser(ctx, c.Sender()); err != nil {
+		return err
+	}
+
+	if text := strings.TrimSpace(c.Message().Payload); text != "" {
+		_, err := a.saveJournalEntry(c, "", text)
+		return err
+	}
+
+	msg, markup, err := a.renderJournal(ctx, strconv.FormatInt(c.Sender().ID, 10))
+	if err != nil {
+		return err
+	}
+	if _, err := a.bot.Send(c.Sender(), msg, &tb.SendOptions{ParseMode: tb.ModeMarkdown, ReplyMarkup: markup}); err != nil {
+		return apperrors.NewInternal().WithDescriptionAndCause(ErrMsgJournal, err)
+	}
+
+	return nil
+}
+
+func (a *API) handleSearch(c tb.Context) error {
+	vendorID := strconv.FormatInt(c.Sender().ID, 10)
+	query := strings.TrimSpace(c.Message().Payload)
+	if query == "" {
+		return c.Send(msgSearchUsage, &tb.SendOptions{ParseMode: tb.ModeMarkdown})
+	}
+
+	entries, err := a.services.JournalEntryService.Search(context.Background(), vendorID, query, journalSearchLimit)
+	if err != nil && !apperrors.IsCode(err, apperrors.NotFound) {
+		return err
+	}
+
+	msg := fmt.Sprintf(msgSearchNoMatch, markdownEscaper.Replace(query))
+	if len(entries) > 0 {
+		msg = fmt.Sprintf(msgSearchTitle, markdownEscaper.Replace(query)) + "\n\n" + renderJournalEntries(entries)
+	}
+
+	if _, err := a.bot.Send(c.Sender(), msg, &tb.SendOptions{ParseMode: tb.ModeMarkdown}); err != nil {
+		return apperrors.NewInternal().WithDescriptionAndCause(ErrMsgSearch, err)
+	}
+
+	return nil
+}
+
+func (a *API) askJournalEntry(to tb.Recipient, entryID, msg string) error {
+	opts := &tb.SendOptions{
+		ParseMode: tb.ModeMarkdown,
+		ReplyMarkup: &tb.ReplyMarkup{
+			InlineKeyboard: [][]tb.InlineButton{{{Unique: keyJournalCancel, Text: "Cancel"}}},
+		},
+	}
+	if _, err := a.bot.Send(to, msg, opts); err != nil {
+		return apperrors.NewInternal().WithDescriptionAndCause(ErrMsgJournalPrompt, err)
+	}
+	a.cache.Set(prefixJournalEntry+to.Recipient(), entryID)
+
+	return nil
+}
+
+func (a *API) handleJournalInput(c tb.Context, entryID string) error {
+	userID := strconv.FormatInt(c.Sender().ID, 10)
+
+	saved, err := a.saveJournalEntry(c, entryID, c.Message().Text)
+	if err != nil || !saved {
+		return err
+	}
+	a.cache.Delete(prefixJournalEntry + userID)
+
+	return nil
+}
+
+func (a *API) saveJournalEntry(c tb.Context, entryID, text string) (bool, error) {
+	ctx := context.Background()
+	vendorID := strconv.FormatInt(c.Sender().ID, 10)
+
+	var msg string
+	if entryID == "" {
+		entry, err := a.services.JournalEntryService.Create(ctx, dto.CreateJournalEntryRequest{
+			VendorID: vendorID,
+			Text:     text,
+		})
+		if err != nil {
+			return false, a.journalInputError(c, err)
+		}
+
+		msg = fmt.Sprintf(msgJournalSaved, formatJournalDate(entry))
+		if _, err := a.services.DayService.Today(ctx, vendorID); apperrors.IsCode(err, apperrors.NotFound) {
+			msg += msgJournalRateDay
+		}
+	} else {
+		_, err := a.services.JournalEntryService.Update(ctx, dto.UpdateJournalEntryRequest{
+			VendorID: vendorID,
+			EntryID:  entryID,
+			Text:     text,
+		})
+		if err != nil {
+			return false, a.journalInputError(c, err)
+		}
+
+		msg = msgJournalUpdated
+	}
+
+	_, _ = a.bot.Send(c.Sender(), msg)
+	return true, nil
+}
+
+func (a *API) journalInputError(c tb.Context, err error) error {
+	if apperrors.IsCode(err, apperrors.BadRequest) {
+		_, _ = a.bot.Send(c.Sender(), msgJournalInvalid+apperrors.GetMessage(err), &tb.SendOptions{ParseMode: tb.ModeMarkdown})
+		return nil
+	}
+
+	return err
+}
+
+func (a *API) deleteJournalEntry(c tb.Context) error {
+	ctx := context.Background()
+	vendorID := strconv.FormatInt(c.Sender().ID, 10)
+
+	if err := a.services.JournalEntryService.Delete(ctx, vendorID, c.Data()); err != nil {
+		return c.Respond(&tb.CallbackResponse{Text: apperrors.GetMessage(err)})
+	}
+
+	msg, markup, err := a.renderJournal(ctx, vendorID)
+	if err != nil {
+		return err
+	}
+	if _, err := a.bot.Edit(c.Message(), msg, &tb.SendOptions{ParseMode: tb.ModeMarkdown, ReplyMarkup: markup}); err != nil {
+		a.logger.Error(ctx, "Failed to update journal", err, "user", c.Sender().ID)
+	}
+
+	return c.Respond(&tb.CallbackResponse{Text: msgJournalDeleted})
+}
+
+func (a *API) renderJournal(ctx context.Context, vendorID string) (string, *tb.ReplyMarkup, error) {
+	entries, err := a.services.JournalEntryService.Recent(ctx, vendorID, journalRecentLimit)
+	if err != nil && !apperrors.IsCode(err, apperrors.NotFound) {
+		return "", nil, err
+	}
+
+	newEntry := []tb.InlineButton{{Unique: keyJournalNew, Text: "✍️ New entry"}}
+	if len(entries) == 0 {
+		return msgJournalEmpty, &tb.ReplyMarkup{InlineKeyboard: [][]tb.InlineButton{newEntry}}, nil
+	}
+
+	rows := make([][]tb.InlineButton, 0, len(entries)+1)
+	for i, entry := range entries {
+		number := strconv.Itoa(i + 1)
+		rows = append(rows, []tb.InlineButton{
+			{Unique: keyJournalEdit, Text: "✏️ Edit " + number, Data: entry.ID},
+			{Unique: keyJournalDelete, Text: "🗑 Delete " + number, Data: entry.ID},
+		})
+	}
+	rows = append(rows, newEntry)
+
+	msg := msgJournalTitle + "\n\n" + renderJournalEntries(entries) + "\n\n" + msgJournalUsage
+
+	return msg, &tb.ReplyMarkup{InlineKeyboard: rows}, nil
+}
+
+func renderJournalEntries(entries []models.JournalEntry) string {
+	lines := make([]string, 0, len(entries))
+	for i, entry := range entries {
+		header := fmt.Sprintf("%d. *%s*", i+1, formatJournalDate(entry))
+		if entry.DayRecordID != "" {
+			header += msgJournalDayRated
+		}
+		lines = append(lines, header+"\n"+markdownEscaper.Replace(truncate(entry.Text, journalPreviewLength)))
+	}
+
+	return strings.Join(lines, "\n\n")
+}
+
+func formatJournalDate(entry models.JournalEntry) string {
+	return entry.LocalDate.Format("Mon 02 Jan")
+}
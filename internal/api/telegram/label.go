@@ -0,0 +1,188 @@
+package telegram
+
+import (
+	"attune/internal/models"
+	"attune/pkg/apperrors"
+	"context"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	tb "gopkg.in/telebot.v4"
+)
+
+const (
+	prefixFocusLabel = "focus_label_"
+	prefixNewLabel   = "new_label_"
+
+	keyLabelPick = "label_pick"
+	keyLabelNew  = "label_new"
+	keyLabelNone = "label_none"
+
+	recentLabelsLimit = 6
+	focusLabelTTL     = 30 * time.Minute
+
+	msgLabelPrompt    = "🏷️ *What are you focusing on?*\nPick a recent label, add a new one or skip."
+	msgNewLabelPrompt = "⌨️ _Please enter a label for this session_\n(e.g., `Thesis`)."
+	msgInvalidLabel   = "❌ *Invalid label.*\n"
+	msgLabelStats     = "📊 *Focus by label*\n"
+	msgLabelStatsLine = "\n• *%s*: `%s` in %d sessions"
+	msgLabelQuality   = ", avg quality %.1f"
+	msgNoLabelStats   = "No labeled sessions yet. Pick a label before your next session!"
+)
+
+var (
+	ErrMsgLabelMenu  = "failed to send focus label menu"
+	ErrMsgLabelStats = "failed to send focus label stats"
+)
+
+func (a *API) registerLabelCallbacks() {
+	a.bot.Handle("/labels", func(c tb.Context) error {
+		err := a.sendLabelStats(c)
+		if err != nil {
+			a.logger.Error(context.Background(), "Error handling /labels command", err, "user", c.Sender().ID)
+		}
+		return err
+	})
+
+	a.bot.Handle(&tb.InlineButton{Unique: keyLabelPick}, func(c tb.Context) error {
+		_ = c.Respond()
+		return a.pickFocusLabel(c, a.focusLabelName(c, c.Data()))
+	})
+
+	a.bot.Handle(&tb.InlineButton{Unique: keyLabelNone}, func(c tb.Context) error {
+		_ = c.Respond()
+		return a.pickFocusLabel(c, "")
+	})
+
+	a.bot.Handle(&tb.InlineButton{Unique: keyLabelNew}, func(c tb.Context) error {
+		vendorID := strconv.FormatInt(c.Sender().ID, 10)
+		a.cache.Set(prefixNewLabel+vendorID, true)
+
+		_, err := a.bot.Send(c.Sender(), msgNewLabelPrompt, &tb.SendOptions{ParseMode: tb.ModeMarkdown})
+		if err != nil {
+			a.logger.Error(context.Background(), "Failed to send new label prompt", err, "user", c.Sender().ID)
+		}
+		return c.Respond()
+	})
+}
+
+func (a *API) sendLabelMenu(c tb.Context) error {
+	return a.sendLabelMenuTo(c.Sender(), strconv.FormatInt(c.Sender().ID, 10))
+}
+
+func (a *API) sendLabelMenuTo(to tb.Recipient, vendorID string) error {
+	a.cache.Delete(prefixFocusNote + vendorID)
+
+	labels, err := a.services.FocusLabelService.Recent(context.Background(), vendorID, recentLabelsLimit)
+	if err != nil && !apperrors.IsCode(err, apperrors.NotFound) {
+		a.logger.Error(context.Background(), "Failed to list recent focus labels", err, "user", vendorID)
+	}
+
+	var rows [][]tb.InlineButton
+	for i, label := range labels {
+		// Names may not fit into the callback data, so the button carries the ID.
+		btn := tb.InlineButton{Unique: keyLabelPick, Text: label.Name, Data: label.ID}
+		if i%2 == 0 {
+			rows = append(rows, []tb.InlineButton{btn})
+		} else {
+			rows[len(rows)-1] = append(rows[len(rows)-1], btn)
+		}
+	}
+	rows = append(rows, []tb.InlineButton{
+		{Unique: keyLabelNew, Text: "New label ✏️"},
+		{Unique: keyLabelNone, Text: "No label"},
+	})
+
+	opts := &tb.SendOptions{
+		ParseMode:   tb.ModeMarkdown,
+		ReplyMarkup: &tb.ReplyMarkup{InlineKeyboard: rows},
+	}
+	if _, err := a.bot.Send(to, msgLabelPrompt, opts); err != nil {
+		return apperrors.NewInternal().WithDescriptionAndCause(ErrMsgLabelMenu, err)
+	}
+
+	return nil
+}
+
+func (a *API) handleNewLabelInput(c tb.Context) error {
+	vendorID := strconv.FormatInt(c.Sender().ID, 10)
+	name := strings.Join(strings.Fields(c.Message().Text), " ")
+
+	if name == "" || utf8.RuneCountInString(name) > models.FocusLabelMaxLength {
+		_, _ = a.bot.Send(c.Sender(), msgInvalidLabel+msgNewLabelPrompt, &tb.SendOptions{ParseMode: tb.ModeMarkdown})
+		return nil
+	}
+
+	a.cache.Delete(prefixNewLabel + vendorID)
+
+	return a.pickFocusLabel(c, name)
+}
+
+func (a *API) pickFocusLabel(c tb.Context, name string) error {
+	vendorID := strconv.FormatInt(c.Sender().ID, 10)
+	if name == "" {
+		a.cache.Delete(prefixFocusLabel + vendorID)
+	} else {
+		a.cache.SetWithTTL(prefixFocusLabel+vendorID, name, focusLabelTTL)
+	}
+
+	if err := a.SendFocusSessionMenu(c, ""); err != nil {
+		a.logger.Error(context.Background(), "Failed to send focus session menu", err, "user", c.Sender().ID)
+		return apperrors.NewInternal().WithDescriptionAndCause(ErrMsgFocusSessionMenu, err)
+	}
+
+	return nil
+}
+
+func (a *API) focusLabelName(c tb.Context, labelID string) string {
+	vendorID := strconv.FormatInt(c.Sender().ID, 10)
+
+	label, err := a.services.FocusLabelService.Get(context.Background(), vendorID, labelID)
+	if err != nil && !apperrors.IsCode(err, apperrors.NotFound) {
+		a.logger.Error(context.Background(), "Failed to get focus label", err, "user", c.Sender().ID)
+	}
+
+	return label.Name
+}
+
+func (a *API) takeFocusLabel(vendorID string) string {
+	v, ok := a.cache.Get(prefixFocusLabel + vendorID)
+	if !ok {
+		return ""
+	}
+	a.cache.Delete(prefixFocusLabel + vendorID)
+
+	name, _ := v.(string)
+	return name
+}
+
+func (a *API) sendLabelStats(c tb.Context) error {
+	vendorID := strconv.FormatInt(c.Sender().ID, 10)
+
+	stats, err := a.services.FocusLabelService.Stats(context.Background(), vendorID)
+	if err != nil && !apperrors.IsCode(err, apperrors.NotFound) {
+		return err
+	}
+
+	msg := msgNoLabelStats
+	if len(stats) > 0 {
+		var sb strings.Builder
+		sb.WriteString(msgLabelStats)
+		for _, stat := range stats {
+			sb.WriteString(fmt.Sprintf(msgLabelStatsLine, markdownEscaper.Replace(stat.Name), formatDuration(stat.FocusedDuration), stat.Sessions))
+			if stat.AverageQuality > 0 {
+				sb.WriteString(fmt.Sprintf(msgLabelQuality, stat.AverageQuality))
+			}
+		}
+		msg = sb.String()
+	}
+
+	if _, err := a.bot.Send(c.Sender(), msg, &tb.SendOptions{ParseMode: tb.ModeMarkdown}); err != nil {
+		return apperrors.NewInternal().WithDescriptionAndCause(ErrMsgLabelStats, err)
+	}
+
+	return nil
+}
@@ -0,0 +1,233 @@
+package telegram
+
+import (
+	"attune/internal/models"
+	"attune/internal/service"
+	"attune/pkg/apperrors"
+	"context"
+	"fmt"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	tb "gopkg.in/telebot.v4"
+)
+
+const (
+	prefixLiveMessage = "live_message_"
+
+	// Keeps edits of a single message well below the Telegram limits.
+	liveEditMinInterval = 5 * time.Second
+	liveProgressCells   = 12
+
+	msgLiveRunning    = "⏱️ *Focus session in progress*"
+	msgLivePaused     = "⏸️ *Focus session paused*"
+	msgLiveWork       = "🍅 *Work %d/%d*"
+	msgLiveWorkPaused = "⏸️ *Work %d/%d paused*"
+	msgLiveBreak      = "☕ *%s*"
+	msgLiveRest       = "☕ *Break in progress*"
+	msgLiveFinished   = "🏁 *Focus session over*"
+	msgLiveRemaining  = "Remaining: `%s`"
+)
+
+type liveMessage struct {
+	mu        sync.Mutex
+	sessionID string
+	message   tb.StoredMessage
+	text      string
+	paused    bool
+	editedAt  time.Time
+}
+
+func (a *API) trackLiveMessage(vendorID, sessionID string, msg *tb.Message) {
+	if msg == nil {
+		return
+	}
+
+	a.closeLiveMessage(vendorID, "")
+	a.cache.Set(prefixLiveMessage+vendorID, &liveMessage{
+		sessionID: sessionID,
+		message: tb.StoredMessage{
+			MessageID: strconv.Itoa(msg.ID),
+			ChatID:    msg.Chat.ID,
+		},
+		text:     msg.Text,
+		editedAt: time.Now(),
+	})
+}
+
+func (a *API) getLiveMessage(vendorID string) (*liveMessage, bool) {
+	v, ok := a.cache.Get(prefixLiveMessage + vendorID)
+	if !ok {
+		return nil, false
+	}
+	live, ok := v.(*liveMessage)
+	return live, ok
+}
+
+func (a *API) closeLiveMessage(vendorID string, text string) {
+	live, ok := a.getLiveMessage(vendorID)
+	if !ok {
+		return
+	}
+	a.cache.Delete(prefixLiveMessage + vendorID)
+
+	live.mu.Lock()
+	defer live.mu.Unlock()
+
+	var err error
+	if text != "" && text != live.text {
+		_, err = a.bot.Edit(live.message, text, &tb.SendOptions{ParseMode: tb.ModeMarkdown})
+	} else {
+		_, err = a.bot.EditReplyMarkup(live.message, nil)
+	}
+	if err != nil {
+		a.logger.Error(context.Background(), "Failed to close live message", err, "vendorID", vendorID)
+	}
+}
+
+func (a *API) finishLiveMessage(vendorID, sessionID string) {
+	live, ok := a.getLiveMessage(vendorID)
+	if !ok || live.sessionID != sessionID {
+		return
+	}
+
+	a.closeLiveMessage(vendorID, msgLiveFinished)
+}
+
+func (a *API) ListenLiveMessages(ctx context.Context) {
+	ticker := time.NewTicker(a.liveUpdateInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			for _, key := range a.cache.Keys() {
+				vendorID, ok := strings.CutPrefix(key, prefixLiveMessage)
+				if !ok {
+					continue
+				}
+				a.refreshLiveMessage(ctx, vendorID, false)
+			}
+		}
+	}
+}
+
+// The edit is skipped when nothing changed or, unless forced, the message was edited too
+// recently.
+func (a *API) refreshLiveMessage(ctx context.Context, vendorID string, force bool) {
+	live, ok := a.getLiveMessage(vendorID)
+	if !ok {
+		return
+	}
+
+	state, err := a.services.FocusSessionService.State(ctx, vendorID)
+	if err != nil {
+		if apperrors.IsCode(err, apperrors.NotFound) {
+			a.closeLiveMessage(vendorID, msgLiveFinished)
+			return
+		}
+
+		a.logger.Error(ctx, "Failed to get focus session state", err, "vendorID", vendorID)
+		return
+	}
+	if state.SessionID != live.sessionID {
+		a.closeLiveMessage(vendorID, msgLiveFinished)
+		return
+	}
+
+	live.mu.Lock()
+	defer live.mu.Unlock()
+
+	text := renderLiveMessage(state)
+	if text == live.text && state.Paused == live.paused {
+		return
+	}
+	if !force && time.Since(live.editedAt) < liveEditMinInterval {
+		return
+	}
+
+	opts := &tb.SendOptions{
+		ParseMode:   tb.ModeMarkdown,
+		ReplyMarkup: liveMessageMarkup(state),
+	}
+	if _, err := a.bot.Edit(live.message, text, opts); err != nil {
+		a.logger.Error(ctx, "Failed to edit live message", err, "vendorID", vendorID)
+		return
+	}
+
+	live.text = text
+	live.paused = state.Paused
+	live.editedAt = time.Now()
+}
+
+func renderLiveMessage(state service.FocusSessionState) string {
+	var title string
+	progress := state.Pomodoro
+	switch {
+	case state.Break:
+		title = msgLiveRest
+	case progress == nil && state.Paused:
+		title = msgLivePaused
+	case progress == nil:
+		title = msgLiveRunning
+	case progress.IsBreak():
+		breakName := "Short break"
+		if progress.Phase == models.PomodoroPhaseLongBreak {
+			breakName = "Long break"
+		}
+		title = fmt.Sprintf(msgLiveBreak, breakName)
+	case state.Paused:
+		title = fmt.Sprintf(msgLiveWorkPaused, progress.Cycle, progress.Config.Cycles)
+	default:
+		title = fmt.Sprintf(msgLiveWork, progress.Cycle, progress.Config.Cycles)
+	}
+
+	return title + "\n" +
+		renderProgressBar(state.Length-state.Remaining, state.Length) + "\n" +
+		fmt.Sprintf(msgLiveRemaining, formatCountdown(state.Remaining))
+}
+
+func renderProgressBar(elapsed, length time.Duration) string {
+	percent := 100
+	if length > 0 {
+		percent = int(elapsed * 100 / length)
+	}
+	percent = max(0, min(percent, 100))
+
+	filled := percent * liveProgressCells / 100
+	bar := strings.Repeat("▓", filled) + strings.Repeat("░", liveProgressCells-filled)
+
+	return fmt.Sprintf("`%s` %d%%", bar, percent)
+}
+
+func formatCountdown(d time.Duration) string {
+	d = d.Round(time.Second)
+	hours := int(d / time.Hour)
+	minutes := int(d % time.Hour / time.Minute)
+	seconds := int(d % time.Minute / time.Second)
+
+	if hours > 0 {
+		return fmt.Sprintf("%d:%02d:%02d", hours, minutes, seconds)
+	}
+
+	return fmt.Sprintf("%02d:%02d", minutes, seconds)
+}
+
+func liveMessageMarkup(state service.FocusSessionState) *tb.ReplyMarkup {
+	switch {
+	case state.RoomID != "":
+		return roomControlMarkup()
+	case state.Break:
+		return breakControlMarkup()
+	case state.Pomodoro == nil:
+		return focusControlMarkup(state.Paused)
+	case state.Pomodoro.IsBreak():
+		return pomodoroBreakMarkup()
+	default:
+		return pomodoroWorkMarkup(state.Paused)
+	}
+}
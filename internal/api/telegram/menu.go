@@ -10,6 +10,9 @@ const (
 	keyFocus30     = "focus_30"
 	keyFocus60     = "focus_60"
 	keyFocusCustom = "focus_custom"
+
+	keyFocusPomodoro       = "focus_pomodoro"
+	keyFocusPomodoroCustom = "focus_pomodoro_custom"
 )
 
 var (
@@ -22,10 +25,13 @@ func (a *API) SendFocusSessionMenu(c tb.Context, customPrompt string) error {
 	btn30 := tb.InlineButton{Unique: keyFocus30, Text: "30 min"}
 	btn60 := tb.InlineButton{Unique: keyFocus60, Text: "60 min"}
 	btnCustom := tb.InlineButton{Unique: keyFocusCustom, Text: "Custom 📝"}
+	btnPomodoro := tb.InlineButton{Unique: keyFocusPomodoro, Text: "Pomodoro 🍅"}
+	btnPomodoroCustom := tb.InlineButton{Unique: keyFocusPomodoroCustom, Text: "Custom pomodoro 🍅"}
 
 	inlineKeys := [][]tb.InlineButton{
 		{btn15, btn30},
 		{btn60, btnCustom},
+		{btnPomodoro, btnPomodoroCustom},
 	}
 
 	markup := &tb.ReplyMarkup{InlineKeyboard: inlineKeys}
@@ -0,0 +1,220 @@
+package telegram
+
+import (
+	"attune/internal/models"
+	"attune/pkg/apperrors"
+	"context"
+	"fmt"
+	"strconv"
+	"strings"
+
+	tb "gopkg.in/telebot.v4"
+)
+
+const (
+	prefixDayEmotions = "day_emotions_"
+
+	keyDayEmotion      = "day_emotion"
+	keyDayEmotionsDone = "day_emotions_done"
+
+	msgEmotionPicker = "💭 *How did today feel?*\n" +
+		"Tap an emotion to pick it, tap again to make it stronger. Up to 5."
+	msgDayMood       = "💭 Mood: %s"
+	msgDayNoMood     = "_not set_"
+	msgMoodsTitle    = "💭 *Most frequent emotions this month*"
+	msgMoodsEntry    = "%s %s ×%d · %s on average"
+	msgMoodsNone     = "💭 No emotions recorded this month. Use /today to check in."
+	msgEmotionPicked = "%s: %s"
+	msgEmotionDrop   = "%s removed"
+)
+
+var (
+	ErrMsgEmotionPicker = "failed to send emotion picker"
+	ErrMsgMoods         = "failed to send emotion stats"
+)
+
+var intensityLabels = map[int]string{
+	models.EmotionIntensityMild:     "mild",
+	models.EmotionIntensityModerate: "moderate",
+	models.EmotionIntensityStrong:   "strong",
+}
+
+func (a *API) registerMoodCommands() {
+	a.bot.Handle(&tb.InlineButton{Unique: keyDayEmotion}, func(c tb.Context) error {
+		return a.toggleEmotion(c)
+	})
+
+	a.bot.Handle(&tb.InlineButton{Unique: keyDayEmotionsDone}, func(c tb.Context) error {
+		err := a.saveEmotions(c)
+		if err != nil {
+			a.logger.Error(context.Background(), "Error saving day emotions", err, "user", c.Sender().ID)
+		}
+		return err
+	})
+
+	a.bot.Handle("/moods", func(c tb.Context) error {
+		err := a.sendMoodStats(c)
+		if err != nil {
+			a.logger.Error(context.Background(), "Error handling /moods command", err, "user", c.Sender().ID)
+		}
+		return err
+	})
+}
+
+func (a *API) sendEmotionPicker(to tb.Recipient, emotions []models.DayEmotion) error {
+	selected := append([]models.DayEmotion(nil), emotions...)
+
+	opts := &tb.SendOptions{ParseMode: tb.ModeMarkdown, ReplyMarkup: emotionPickerMarkup(selected)}
+	if _, err := a.bot.Send(to, msgEmotionPicker, opts); err != nil {
+		return apperrors.NewInternal().WithDescriptionAndCause(ErrMsgEmotionPicker, err)
+	}
+	a.cache.Set(prefixDayEmotions+to.Recipient(), selected)
+
+	return nil
+}
+
+// A tap picks an emotion, raises its intensity, or drops it once at its strongest.
+func (a *API) toggleEmotion(c tb.Context) error {
+	userID := strconv.FormatInt(c.Sender().ID, 10)
+	definition, ok := models.LookupEmotion(models.Emotion(c.Data()))
+	if !ok {
+		return c.Respond(&tb.CallbackResponse{Text: models.ErrUnknownEmotion})
+	}
+
+	selected := a.pickedEmotions(userID)
+	var response string
+	index := -1
+	for i, emotion := range selected {
+		if emotion.Emotion == definition.Emotion {
+			index = i
+		}
+	}
+
+	switch {
+	case index < 0 && len(selected) >= models.DayEmotionsMax:
+		return c.Respond(&tb.CallbackResponse{Text: models.ErrTooManyEmotions})
+	case index < 0:
+		selected = append(selected, models.DayEmotion{Emotion: definition.Emotion, Intensity: models.EmotionIntensityMild})
+		response = fmt.Sprintf(msgEmotionPicked, definition.Emotion, intensityLabels[models.EmotionIntensityMild])
+	case selected[index].Intensity < models.EmotionIntensityStrong:
+		selected[index].Intensity++
+		response = fmt.Sprintf(msgEmotionPicked, definition.Emotion, intensityLabels[selected[index].Intensity])
+	default:
+		selected = append(selected[:index], selected[index+1:]...)
+		response = fmt.Sprintf(msgEmotionDrop, definition.Emotion)
+	}
+	a.cache.Set(prefixDayEmotions+userID, selected)
+
+	if _, err := a.bot.EditReplyMarkup(c.Message(), emotionPickerMarkup(selected)); err != nil {
+		a.logger.Error(context.Background(), "Failed to update emotion picker", err, "user", c.Sender().ID)
+	}
+
+	return c.Respond(&tb.CallbackResponse{Text: response})
+}
+
+func (a *API) saveEmotions(c tb.Context) error {
+	userID := strconv.FormatInt(c.Sender().ID, 10)
+
+	record, err := a.services.DayService.SetTodayEmotions(context.Background(), userID, a.pickedEmotions(userID))
+	if err != nil {
+		return c.Respond(&tb.CallbackResponse{Text: apperrors.GetMessage(err)})
+	}
+	a.cache.Delete(prefixDayEmotions + userID)
+
+	msg := fmt.Sprintf(msgDayMood, formatMood(models.Mood{Emotions: record.Mood.Emotions}))
+	if _, err := a.bot.Edit(c.Message(), msg, &tb.SendOptions{ParseMode: tb.ModeMarkdown}); err != nil {
+		a.logger.Error(context.Background(), "Failed to close emotion picker", err, "user", c.Sender().ID)
+	}
+	_ = c.Respond()
+
+	if record.Mood.Note != "" {
+		return nil
+	}
+
+	return a.askDayMoodNote(c.Sender())
+}
+
+func (a *API) pickedEmotions(userID string) []models.DayEmotion {
+	cached, ok := a.cache.Get(prefixDayEmotions + userID)
+	if !ok {
+		return nil
+	}
+	selected, ok := cached.([]models.DayEmotion)
+	if !ok {
+		a.logger.Error(context.Background(), "Failed to type cast picked emotions", nil, "user", userID)
+		return nil
+	}
+
+	return selected
+}
+
+func (a *API) sendMoodStats(c tb.Context) error {
+	vendorID := strconv.FormatInt(c.Sender().ID, 10)
+
+	frequencies, err := a.services.DayService.EmotionStats(context.Background(), vendorID)
+	if err != nil && !apperrors.IsCode(err, apperrors.NotFound) {
+		return err
+	}
+
+	msg := msgMoodsNone
+	if len(frequencies) > 0 {
+		lines := []string{msgMoodsTitle, ""}
+		for _, frequency := range frequencies {
+			definition, _ := models.LookupEmotion(frequency.Emotion)
+			intensity := intensityLabels[int(frequency.AverageIntensity+0.5)]
+			lines = append(lines, fmt.Sprintf(msgMoodsEntry, definition.Emoji, frequency.Emotion, frequency.Count, intensity))
+		}
+		msg = strings.Join(lines, "\n")
+	}
+
+	if _, err := a.bot.Send(c.Sender(), msg, &tb.SendOptions{ParseMode: tb.ModeMarkdown}); err != nil {
+		return apperrors.NewInternal().WithDescriptionAndCause(ErrMsgMoods, err)
+	}
+
+	return nil
+}
+
+func formatMood(mood models.Mood) string {
+	if len(mood.Emotions) == 0 && mood.Note == "" {
+		return msgDayNoMood
+	}
+
+	emotions := make([]string, 0, len(mood.Emotions))
+	for _, emotion := range mood.Emotions {
+		definition, _ := models.LookupEmotion(emotion.Emotion)
+		emotions = append(emotions, fmt.Sprintf("%s %s (%s)", definition.Emoji, emotion.Emotion, intensityLabels[emotion.Intensity]))
+	}
+
+	msg := strings.Join(emotions, ", ")
+	if mood.Note != "" {
+		msg += "\n📝 " + markdownEscaper.Replace(mood.Note)
+	}
+
+	return msg
+}
+
+func emotionPickerMarkup(selected []models.DayEmotion) *tb.ReplyMarkup {
+	intensities := make(map[models.Emotion]int, len(selected))
+	for _, emotion := range selected {
+		intensities[emotion.Emotion] = emotion.Intensity
+	}
+
+	var rows [][]tb.InlineButton
+	var category models.EmotionCategory
+	for _, definition := range models.Emotions {
+		if len(rows) == 0 || definition.Category != category {
+			rows = append(rows, nil)
+			category = definition.Category
+		}
+
+		text := definition.Emoji + " " + string(definition.Emotion)
+		if intensity := intensities[definition.Emotion]; intensity > 0 {
+			text += " " + strings.Repeat("●", intensity)
+		}
+		button := tb.InlineButton{Unique: keyDayEmotion, Text: text, Data: string(definition.Emotion)}
+		rows[len(rows)-1] = append(rows[len(rows)-1], button)
+	}
+	rows = append(rows, []tb.InlineButton{{Unique: keyDayEmotionsDone, Text: "✅ Done"}})
+
+	return &tb.ReplyMarkup{InlineKeyboard: rows}
+}
@@ -0,0 +1,163 @@
+package telegram
+
+import (
+	"attune/internal/dto"
+	"attune/internal/models"
+	"attune/pkg/apperrors"
+	"context"
+	"fmt"
+	"strconv"
+	"strings"
+
+	tb "gopkg.in/telebot.v4"
+)
+
+const (
+	prefixFocusNote = "focus_note_"
+
+	keyFocusNoteSkip = "focus_note_skip"
+
+	historyLimit      = 10
+	historyNoteLength = 200
+
+	msgFocusNotePrompt  = "📝 *What did you get done?*\nReply with a short note, or skip."
+	msgFocusNoteSaved   = "📝 Note saved."
+	msgFocusNoteSkipped = "📝 No note this time."
+	msgFocusNoteInvalid = "❌ *Invalid note.*\n"
+
+	msgHistoryTitle   = "📜 *Recent sessions*"
+	msgHistorySearch  = "🔎 *Notes matching* \"%s\""
+	msgHistoryNone    = "You have no finished sessions yet."
+	msgHistoryNoMatch = "No notes match \"%s\"."
+	msgHistoryUsage   = "Search your notes with `/history <text>`."
+)
+
+var (
+	ErrMsgFocusNotePrompt = "failed to send focus note prompt"
+	ErrMsgHistory         = "failed to send focus history"
+)
+
+func (a *API) registerNoteCallbacks() {
+	a.bot.Handle(&tb.InlineButton{Unique: keyFocusNoteSkip}, func(c tb.Context) error {
+		userID := strconv.FormatInt(c.Sender().ID, 10)
+		a.cache.Delete(prefixFocusNote + userID)
+
+		if _, err := a.bot.Edit(c.Message(), msgFocusNoteSkipped); err != nil {
+			a.logger.Error(context.Background(), "Failed to close focus note prompt", err, "user", c.Sender().ID)
+		}
+		_ = c.Respond()
+
+		return a.sendLabelMenu(c)
+	})
+
+	a.bot.Handle("/history", func(c tb.Context) error {
+		err := a.sendHistory(c)
+		if err != nil {
+			a.logger.Error(context.Background(), "Error handling /history command", err, "user", c.Sender().ID)
+		}
+		return err
+	})
+}
+
+func (a *API) askFocusNote(c tb.Context, sessionID string) error {
+	userID := strconv.FormatInt(c.Sender().ID, 10)
+
+	opts := &tb.SendOptions{
+		ParseMode: tb.ModeMarkdown,
+		ReplyMarkup: &tb.ReplyMarkup{
+			InlineKeyboard: [][]tb.InlineButton{{{Unique: keyFocusNoteSkip, Text: "Skip"}}},
+		},
+	}
+	if _, err := a.bot.Send(c.Sender(), msgFocusNotePrompt, opts); err != nil {
+		return apperrors.NewInternal().WithDescriptionAndCause(ErrMsgFocusNotePrompt, err)
+	}
+	a.cache.Set(prefixFocusNote+userID, sessionID)
+
+	return nil
+}
+
+func (a *API) handleFocusNoteInput(c tb.Context, sessionID string) error {
+	userID := strconv.FormatInt(c.Sender().ID, 10)
+
+	updateDTO := dto.UpdateFocusRequest{
+		VendorID:  userID,
+		SessionID: sessionID,
+		Type:      dto.UpdateFocusRequestTypeNote,
+		Note:      c.Message().Text,
+	}
+	if err := a.services.FocusSessionService.Update(context.Background(), updateDTO); err != nil {
+		if apperrors.IsCode(err, apperrors.BadRequest) {
+			_, _ = a.bot.Send(c.Sender(), msgFocusNoteInvalid+apperrors.GetMessage(err), &tb.SendOptions{ParseMode: tb.ModeMarkdown})
+			return nil
+		}
+		return err
+	}
+
+	a.cache.Delete(prefixFocusNote + userID)
+	_, _ = a.bot.Send(c.Sender(), msgFocusNoteSaved)
+
+	return a.sendLabelMenu(c)
+}
+
+func (a *API) sendHistory(c tb.Context) error {
+	vendorID := strconv.FormatInt(c.Sender().ID, 10)
+	query := strings.TrimSpace(c.Message().Payload)
+
+	sessions, err := a.services.FocusSessionService.History(context.Background(), vendorID, query, historyLimit)
+	if err != nil && !apperrors.IsCode(err, apperrors.NotFound) {
+		return err
+	}
+
+	var msg string
+	switch {
+	case len(sessions) == 0 && query != "":
+		msg = fmt.Sprintf(msgHistoryNoMatch, markdownEscaper.Replace(query))
+	case len(sessions) == 0:
+		msg = msgHistoryNone
+	case query != "":
+		msg = fmt.Sprintf(msgHistorySearch, markdownEscaper.Replace(query)) + "\n\n" + renderHistory(sessions)
+	default:
+		msg = msgHistoryTitle + "\n\n" + renderHistory(sessions)
+	}
+	msg += "\n\n" + msgHistoryUsage
+
+	if _, err := a.bot.Send(c.Sender(), msg, &tb.SendOptions{ParseMode: tb.ModeMarkdown}); err != nil {
+		return apperrors.NewInternal().WithDescriptionAndCause(ErrMsgHistory, err)
+	}
+
+	return nil
+}
+
+func renderHistory(sessions []models.FocusSession) string {
+	entries := make([]string, 0, len(sessions))
+	for _, session := range sessions {
+		startedAt := "*" + session.StartedAt.Format("Mon 02 Jan 15:04") + "*"
+		if session.IsBreak() {
+			entries = append(entries, startedAt+" · ☕ `"+formatDuration(session.RestDuration)+"` break")
+			continue
+		}
+
+		entry := startedAt + " · `" + formatDuration(session.FocusedDuration) + "`"
+		if session.Quality > 0 {
+			entry += fmt.Sprintf(" · %d/10", session.Quality)
+		}
+		if session.Status != models.FocusSessionStatusCompleted {
+			entry += " · " + string(session.Status)
+		}
+		if session.Note != "" {
+			entry += "\n📝 " + markdownEscaper.Replace(truncate(session.Note, historyNoteLength))
+		}
+		entries = append(entries, entry)
+	}
+
+	return strings.Join(entries, "\n\n")
+}
+
+func truncate(s string, n int) string {
+	runes := []rune(s)
+	if len(runes) <= n {
+		return s
+	}
+
+	return string(runes[:n-1]) + "…"
+}
@@ -0,0 +1,225 @@
+package telegram
+
+import (
+	"attune/internal/api"
+	"attune/internal/dto"
+	"attune/internal/models"
+	"attune/pkg/apperrors"
+	"context"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	tb "gopkg.in/telebot.v4"
+)
+
+const (
+	prefixCustomPomodoro = "custom_pomodoro_"
+
+	keyFocusSkipBreak = "focus_skip_break"
+	keyFocusEndCycle  = "focus_end_cycle"
+
+	msgPomodoroCustomPrompt = "⌨️ _Please enter your pomodoro settings_\n" +
+		"Work, short break, long break, cycles and long break interval,\n" +
+		"e.g. `25m 5m 15m 4 4`. Omitted values fall back to the defaults."
+	msgInvalidPomodoro  = "❌ *Invalid pomodoro settings.*\nPlease try again (e.g., `25m 5m 15m 4 4`)."
+	msgPomodoroStarted  = "🍅 *Your pomodoro has started!*\n"
+	msgPomodoroWork     = "🎯 *Back to work!*\n"
+	msgPomodoroBreak    = "☕ *Time for a %s!*\nDuration: `%s`\nCycle %d/%d done."
+	msgPomodoroComplete = "🍅 *Pomodoro complete!*\nAll %d work intervals are done."
+	msgBreakSkipped     = "⏭️ *Break skipped.*"
+	msgCycleEnded       = "🛑 *Your pomodoro has been ended.*"
+)
+
+var (
+	ErrMsgPomodoroBreak    = "failed to send pomodoro break message"
+	ErrMsgPomodoroWork     = "failed to send pomodoro work message"
+	ErrMsgPomodoroComplete = "failed to send pomodoro completion message"
+)
+
+func (a *API) registerPomodoroCallbacks() {
+	a.bot.Handle(&tb.InlineButton{Unique: keyFocusPomodoro}, func(c tb.Context) error {
+		err := a.startPomodoroSession(c, models.DefaultPomodoroConfig())
+		if err != nil {
+			a.logger.Error(context.Background(), "Error starting pomodoro session", err, "user", c.Sender().ID)
+		}
+		return err
+	})
+
+	a.bot.Handle(&tb.InlineButton{Unique: keyFocusPomodoroCustom}, func(c tb.Context) error {
+		userID := strconv.FormatInt(c.Sender().ID, 10)
+		a.cache.Set(prefixCustomPomodoro+userID, true)
+
+		_, err := a.bot.Send(c.Sender(), msgPomodoroCustomPrompt, &tb.SendOptions{ParseMode: tb.ModeMarkdown})
+		if err != nil {
+			a.logger.Error(context.Background(), "Failed to send custom pomodoro prompt", err, "user", c.Sender().ID)
+		}
+		return nil
+	})
+}
+
+func (a *API) handleCustomPomodoroInput(c tb.Context) error {
+	userID := strconv.FormatInt(c.Sender().ID, 10)
+	input := c.Message().Text
+
+	config, err := parsePomodoroConfig(input)
+	if err != nil {
+		a.logger.Error(context.Background(), "Invalid pomodoro settings", err, "input", input, "user", c.Sender().ID)
+		_, _ = a.bot.Send(c.Sender(), msgInvalidPomodoro, &tb.SendOptions{ParseMode: tb.ModeMarkdown})
+		return nil
+	}
+
+	a.cache.Delete(prefixCustomPomodoro + userID)
+
+	return a.startPomodoroSession(c, config)
+}
+
+func parsePomodoroConfig(input string) (models.PomodoroConfig, error) {
+	config := models.DefaultPomodoroConfig()
+	fields := strings.Fields(input)
+	if len(fields) == 0 || len(fields) > 5 {
+		return models.PomodoroConfig{}, fmt.Errorf("expected 1 to 5 values, got %d", len(fields))
+	}
+
+	durations := []*time.Duration{&config.WorkDuration, &config.ShortBreakDuration, &config.LongBreakDuration}
+	counts := []*int{&config.Cycles, &config.LongBreakEvery}
+	for i, field := range fields {
+		var err error
+		if i < len(durations) {
+			*durations[i], err = time.ParseDuration(field)
+		} else {
+			*counts[i-len(durations)], err = strconv.Atoi(field)
+		}
+		if err != nil {
+			return models.PomodoroConfig{}, err
+		}
+	}
+
+	if len(fields) < 5 && config.LongBreakEvery > config.Cycles {
+		config.LongBreakEvery = config.Cycles
+	}
+
+	return config, nil
+}
+
+func (a *API) startPomodoroSession(c tb.Context, config models.PomodoroConfig) error {
+	vendorID := strconv.FormatInt(c.Sender().ID, 10)
+
+	req := dto.CreateFocusSessionRequest{
+		VendorID: vendorID,
+		Pomodoro: &config,
+		Label:    a.takeFocusLabel(vendorID),
+		Source:   models.FocusSessionEventSourceTelegram,
+	}
+	confirmationMsg := msgPomodoroStarted + formatWorkPhase(1, config.Cycles, config.WorkDuration)
+
+	return a.launchFocusSession(c, req, confirmationMsg, pomodoroWorkMarkup(false))
+}
+
+func (a *API) skipPomodoroBreak(c tb.Context) error {
+	return a.updateFocusSession(c, dto.UpdateFocusRequestTypeSkipBreak, msgBreakSkipped)
+}
+
+func (a *API) endPomodoroCycle(c tb.Context) error {
+	return a.updateFocusSession(c, dto.UpdateFocusRequestTypeStop, msgCycleEnded)
+}
+
+func (a *API) startPomodoroBreak(_ context.Context, vendorID string, trigger api.Trigger) error {
+	progress := trigger.Pomodoro
+	if progress == nil {
+		return nil
+	}
+
+	vendorChat, err := chatFromVendorID(vendorID)
+	if err != nil {
+		return err
+	}
+
+	breakName := "short break"
+	if progress.Phase == models.PomodoroPhaseLongBreak {
+		breakName = "long break"
+	}
+
+	msg := fmt.Sprintf(msgPomodoroBreak, breakName, formatDuration(progress.PhaseDuration), progress.Cycle, progress.Config.Cycles)
+	opts := &tb.SendOptions{
+		ParseMode:   tb.ModeMarkdown,
+		ReplyMarkup: pomodoroBreakMarkup(),
+	}
+	sent, err := a.bot.Send(vendorChat, msg, opts)
+	if err != nil {
+		return apperrors.NewInternal().WithDescriptionAndCause(ErrMsgPomodoroBreak, err)
+	}
+	a.trackLiveMessage(vendorID, trigger.SessionID, sent)
+
+	return nil
+}
+
+func (a *API) endPomodoroBreak(_ context.Context, vendorID string, trigger api.Trigger) error {
+	progress := trigger.Pomodoro
+	if progress == nil {
+		return nil
+	}
+
+	vendorChat, err := chatFromVendorID(vendorID)
+	if err != nil {
+		return err
+	}
+
+	msg := msgPomodoroWork + formatWorkPhase(progress.Cycle, progress.Config.Cycles, progress.PhaseDuration)
+	opts := &tb.SendOptions{
+		ParseMode:   tb.ModeMarkdown,
+		ReplyMarkup: pomodoroWorkMarkup(false),
+	}
+	sent, err := a.bot.Send(vendorChat, msg, opts)
+	if err != nil {
+		return apperrors.NewInternal().WithDescriptionAndCause(ErrMsgPomodoroWork, err)
+	}
+	a.trackLiveMessage(vendorID, trigger.SessionID, sent)
+
+	return nil
+}
+
+func (a *API) completePomodoroCycle(ctx context.Context, vendorID string, trigger api.Trigger) error {
+	vendorChat, err := chatFromVendorID(vendorID)
+	if err != nil {
+		return err
+	}
+
+	if trigger.Pomodoro != nil {
+		msg := fmt.Sprintf(msgPomodoroComplete, trigger.Pomodoro.Config.Cycles)
+		if _, err := a.bot.Send(vendorChat, msg, &tb.SendOptions{ParseMode: tb.ModeMarkdown}); err != nil {
+			return apperrors.NewInternal().WithDescriptionAndCause(ErrMsgPomodoroComplete, err)
+		}
+	}
+
+	return a.finishFocusSession(ctx, vendorID, trigger)
+}
+
+func formatWorkPhase(cycle, cycles int, duration time.Duration) string {
+	return fmt.Sprintf("Work %d/%d: `%s`", cycle, cycles, formatDuration(duration))
+}
+
+func pomodoroWorkMarkup(paused bool) *tb.ReplyMarkup {
+	return &tb.ReplyMarkup{
+		InlineKeyboard: [][]tb.InlineButton{
+			{
+				pauseOrResumeButton(paused),
+				{Unique: keyFocusEndCycle, Text: "End cycle"},
+			},
+			extendButtons(),
+			{distractedButton()},
+		},
+	}
+}
+
+func pomodoroBreakMarkup() *tb.ReplyMarkup {
+	return &tb.ReplyMarkup{
+		InlineKeyboard: [][]tb.InlineButton{
+			{
+				{Unique: keyFocusSkipBreak, Text: "Skip break"},
+				{Unique: keyFocusEndCycle, Text: "End cycle"},
+			},
+		},
+	}
+}
@@ -0,0 +1,130 @@
+package telegram
+
+import (
+	"attune/internal/api"
+	"attune/internal/models"
+	"attune/pkg/apperrors"
+	"context"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	tb "gopkg.in/telebot.v4"
+)
+
+const (
+	msgProfileTitle     = "👤 *Profile*"
+	msgProfileCheckIn   = "📅 Check-in streak: %s"
+	msgProfileFocusGoal = "🎯 Goal streak: %s"
+	msgProfileStreak    = "%d %s (best %d)"
+	msgProfileFreezes   = " · 🧊 %d %s left"
+	msgProfileFocused   = "⏱ Focused %dh %02dm over %d completed %s"
+	msgProfileBadges    = "🏅 *Badges* %d/%d"
+	msgProfileBadge     = "%s %s · %s"
+	msgProfileLocked    = "🔒 %s"
+	msgProfileFreezeTip = "_Freeze days keep a streak alive over missed days, e.g. a weekend. A week of streak earns a spent one back._"
+
+	msgBadgeEarned     = "🏅 *New badge:* %s %s"
+	msgBadgesEarnedTip = "See all of them with /profile."
+)
+
+var (
+	ErrMsgProfile      = "failed to send profile"
+	ErrMsgBadgesEarned = "failed to announce badges"
+)
+
+func (a *API) registerProfileCommand() {
+	a.bot.Handle("/profile", func(c tb.Context) error {
+		err := a.sendProfile(c)
+		if err != nil {
+			a.logger.Error(context.Background(), "Error handling /profile command", err, "user", c.Sender().ID)
+		}
+		return err
+	})
+}
+
+func (a *API) sendProfile(c tb.Context) error {
+	vendorID := strconv.FormatInt(c.Sender().ID, 10)
+
+	profile, err := a.services.AchievementService.Profile(context.Background(), vendorID)
+	if err != nil {
+		return err
+	}
+
+	if _, err := a.bot.Send(c.Sender(), renderProfile(profile), &tb.SendOptions{ParseMode: tb.ModeMarkdown}); err != nil {
+		return apperrors.NewInternal().WithDescriptionAndCause(ErrMsgProfile, err)
+	}
+
+	return nil
+}
+
+func (a *API) announceBadges(_ context.Context, vendorID string, trigger api.Trigger) error {
+	if len(trigger.Badges) == 0 {
+		return nil
+	}
+
+	vendorChat, err := chatFromVendorID(vendorID)
+	if err != nil {
+		return err
+	}
+
+	lines := make([]string, 0, len(trigger.Badges)+1)
+	for _, badge := range trigger.Badges {
+		lines = append(lines, fmt.Sprintf(msgBadgeEarned, badge.Emoji, badge.Title))
+	}
+	lines = append(lines, msgBadgesEarnedTip)
+
+	if _, err := a.bot.Send(vendorChat, strings.Join(lines, "\n"), &tb.SendOptions{ParseMode: tb.ModeMarkdown}); err != nil {
+		return apperrors.NewInternal().WithDescriptionAndCause(ErrMsgBadgesEarned, err)
+	}
+
+	return nil
+}
+
+func renderProfile(profile models.Profile) string {
+	stats := profile.Stats
+	lines := []string{
+		msgProfileTitle,
+		"",
+		fmt.Sprintf(msgProfileCheckIn, formatStreak(profile.CheckInStreak, profile.Today)),
+		fmt.Sprintf(msgProfileFocusGoal, formatStreak(profile.FocusGoalStreak, profile.Today)),
+		fmt.Sprintf(
+			msgProfileFocused,
+			int(stats.Focused.Hours()),
+			int(stats.Focused.Minutes())%60,
+			stats.Sessions,
+			pick(stats.Sessions == 1, "session", "sessions"),
+		),
+		"",
+		fmt.Sprintf(msgProfileBadges, len(profile.Badges), len(models.Badges)),
+	}
+
+	earned := make(map[models.BadgeCode]bool, len(profile.Badges))
+	for _, owned := range profile.Badges {
+		badge, ok := models.LookupBadge(owned.Code)
+		if !ok {
+			continue
+		}
+		earned[owned.Code] = true
+		lines = append(lines, fmt.Sprintf(msgProfileBadge, badge.Emoji, badge.Title, owned.AwardedAt.Format("02 Jan 2006")))
+	}
+	for _, badge := range models.Badges {
+		if !earned[badge.Code] {
+			lines = append(lines, fmt.Sprintf(msgProfileLocked, badge.Title))
+		}
+	}
+
+	lines = append(lines, "", msgProfileFreezeTip)
+	return strings.Join(lines, "\n")
+}
+
+func formatStreak(streak models.Streak, today time.Time) string {
+	current := streak.CurrentOn(today)
+	text := fmt.Sprintf(msgProfileStreak, current, pick(current == 1, "day", "days"), streak.Longest)
+	if current > 0 {
+		text += fmt.Sprintf(msgProfileFreezes, streak.Freezes, pick(streak.Freezes == 1, "freeze", "freezes"))
+	}
+
+	return text
+}
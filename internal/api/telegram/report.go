@@ -0,0 +1,168 @@
+package telegram
+
+import (
+	"attune/internal/api"
+	"attune/internal/models"
+	"attune/pkg/apperrors"
+	"context"
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+	"time"
+
+	tb "gopkg.in/telebot.v4"
+)
+
+const (
+	msgReportUsage = "📈 *Reports*\n" +
+		"`/report week` sums up this week so far, `/report month` this month.\n" +
+		"Reports on the past week and month also arrive every Monday and on the 1st."
+	msgReportWeekTitle  = "📈 *Weekly report* · %s – %s"
+	msgReportMonthTitle = "📈 *Monthly report* · %s"
+	msgReportFocus      = "🎯 Focus: `%s` total · `%s` a day"
+	msgReportSessions   = "✅ Sessions: %d completed · %d stopped"
+	msgReportSession    = "⭐ Session quality: %.1f/10"
+	msgReportDay        = "🌙 Day quality: %.1f/10"
+	msgReportBestDay    = "🏆 Best day: %s (%d/10)"
+	msgReportWorstDay   = "🌧 Worst day: %s (%d/10)"
+	msgReportNoRatings  = "🌙 No rated days. Check in with /today."
+	msgReportVsPrevious = " (%s vs previous %s)"
+)
+
+var (
+	ErrMsgReport = "failed to send report"
+)
+
+func (a *API) registerReportCommand() {
+	a.bot.Handle("/report", func(c tb.Context) error {
+		err := a.handleReport(c)
+		if err != nil {
+			a.logger.Error(context.Background(), "Error handling /report command", err, "user", c.Sender().ID)
+		}
+		return err
+	})
+}
+
+func (a *API) handleReport(c tb.Context) error {
+	period := models.ReportPeriod(strings.ToLower(strings.TrimSpace(c.Message().Payload)))
+	if !period.IsValid() {
+		return c.Send(msgReportUsage, &tb.SendOptions{ParseMode: tb.ModeMarkdown})
+	}
+
+	vendorID := strconv.FormatInt(c.Sender().ID, 10)
+	report, err := a.services.ReportService.Current(context.Background(), vendorID, period)
+	if err != nil {
+		return err
+	}
+
+	if _, err := a.bot.Send(c.Sender(), renderReport(report), &tb.SendOptions{ParseMode: tb.ModeMarkdown}); err != nil {
+		return apperrors.NewInternal().WithDescriptionAndCause(ErrMsgReport, err)
+	}
+
+	return nil
+}
+
+func (a *API) deliverReport(_ context.Context, vendorID string, trigger api.Trigger) error {
+	if trigger.Report == nil {
+		return nil
+	}
+
+	vendorChat, err := chatFromVendorID(vendorID)
+	if err != nil {
+		return err
+	}
+
+	if _, err := a.bot.Send(vendorChat, renderReport(*trigger.Report), &tb.SendOptions{ParseMode: tb.ModeMarkdown}); err != nil {
+		return apperrors.NewInternal().WithDescriptionAndCause(ErrMsgReport, err)
+	}
+
+	return nil
+}
+
+func renderReport(report models.Report) string {
+	current, previous := report.Current, report.Previous
+
+	title := fmt.Sprintf(msgReportWeekTitle, current.From.Format("02 Jan"), current.To.Format("02 Jan"))
+	if report.Period == models.ReportPeriodMonth {
+		title = fmt.Sprintf(msgReportMonthTitle, current.From.Format("January 2006"))
+	}
+	lines := []string{title, ""}
+
+	focus := fmt.Sprintf(msgReportFocus, formatDuration(current.Focused), formatDuration(current.AverageDailyFocus().Round(time.Minute)))
+	lines = append(lines, focus+formatReportTrend(
+		current.AverageDailyFocus().Minutes(),
+		previous.AverageDailyFocus().Minutes(),
+		previous.Sessions() > 0,
+		report.Period,
+		formatPercentTrend,
+	))
+	lines = append(lines, fmt.Sprintf(msgReportSessions, current.Completed, current.Stopped))
+
+	if current.RatedSessions > 0 {
+		lines = append(lines, fmt.Sprintf(msgReportSession, current.AverageSessionQuality)+formatReportTrend(
+			current.AverageSessionQuality,
+			previous.AverageSessionQuality,
+			previous.RatedSessions > 0,
+			report.Period,
+			formatPointTrend,
+		))
+	}
+
+	if current.RatedDays == 0 {
+		lines = append(lines, msgReportNoRatings)
+		return strings.Join(lines, "\n")
+	}
+
+	lines = append(lines, fmt.Sprintf(msgReportDay, current.AverageDayQuality)+formatReportTrend(
+		current.AverageDayQuality,
+		previous.AverageDayQuality,
+		previous.RatedDays > 0,
+		report.Period,
+		formatPointTrend,
+	))
+	lines = append(lines, fmt.Sprintf(msgReportBestDay, current.BestDay.Date.Format("Mon 02 Jan"), current.BestDay.Quality))
+	if current.RatedDays > 1 {
+		lines = append(lines, fmt.Sprintf(msgReportWorstDay, current.WorstDay.Date.Format("Mon 02 Jan"), current.WorstDay.Quality))
+	}
+
+	return strings.Join(lines, "\n")
+}
+
+func formatReportTrend(
+	current, previous float64,
+	comparable bool,
+	period models.ReportPeriod,
+	format func(current, previous float64) string,
+) string {
+	if !comparable {
+		return ""
+	}
+
+	return fmt.Sprintf(msgReportVsPrevious, format(current, previous), period)
+}
+
+func formatPercentTrend(current, previous float64) string {
+	if previous == 0 {
+		return "▲ new"
+	}
+
+	change := (current - previous) / previous * 100
+	return trendArrow(change) + " " + strconv.Itoa(int(math.Abs(math.Round(change)))) + "%"
+}
+
+func formatPointTrend(current, previous float64) string {
+	change := current - previous
+	return trendArrow(change) + " " + strconv.FormatFloat(math.Abs(change), 'f', 1, 64)
+}
+
+func trendArrow(change float64) string {
+	switch {
+	case change > 0.05:
+		return "▲"
+	case change < -0.05:
+		return "▼"
+	}
+
+	return "="
+}
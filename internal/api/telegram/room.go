@@ -0,0 +1,313 @@
+package telegram
+
+import (
+	"attune/internal/dto"
+	"attune/internal/models"
+	"attune/pkg/apperrors"
+	"context"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	tb "gopkg.in/telebot.v4"
+)
+
+const (
+	keyRoomJoin   = "room_join"
+	keyRoomStart  = "room_start"
+	keyRoomCancel = "room_cancel"
+
+	msgRoomUsage = "🤝 *Co-focus room*\n" +
+		"`/room <duration>` opens a room in this group, e.g. `/room 25m`.\n" +
+		"Everyone who joins starts and ends together."
+	msgRoomGroupOnly = "🤝 Rooms live in group chats. Add me to a group and send `/room 25m` there."
+	msgRoomLobby     = "🤝 *Co-focus room* · `%s`\nHosted by %s\n\nJoined (%d):\n%s"
+	msgRoomStarted   = "🤝 *Co-focus room is on!* · `%s`\nFocusing: %s"
+	msgRoomBusy      = "\nAlready focusing: %s"
+	msgRoomCancelled = "🤝 Co-focus room cancelled."
+	msgRoomSummary   = "🏁 *Co-focus room finished* · `%s`\n\n%s"
+	msgRoomJoined    = "You're in!"
+	msgRoomRejoined  = "You've already joined."
+)
+
+var (
+	ErrMsgRoomLobby     = "failed to send focus room lobby"
+	ErrMsgRoomCountdown = "failed to send focus room countdown"
+	ErrMsgRoomSummary   = "failed to send focus room summary"
+)
+
+var markdownEscaper = strings.NewReplacer("_", "\\_", "*", "\\*", "`", "\\`", "[", "\\[")
+
+func (a *API) registerRoomCommands() {
+	a.bot.Handle("/room", func(c tb.Context) error {
+		err := a.handleRoom(c)
+		if err != nil {
+			a.logger.Error(context.Background(), "Error handling /room command", err, "user", c.Sender().ID)
+		}
+		return err
+	})
+
+	a.bot.Handle(&tb.InlineButton{Unique: keyRoomJoin}, func(c tb.Context) error {
+		err := a.joinRoom(c)
+		if err != nil {
+			a.logger.Error(context.Background(), "Error joining focus room", err, "user", c.Sender().ID)
+		}
+		return err
+	})
+
+	a.bot.Handle(&tb.InlineButton{Unique: keyRoomStart}, func(c tb.Context) error {
+		err := a.startRoom(c)
+		if err != nil {
+			a.logger.Error(context.Background(), "Error starting focus room", err, "user", c.Sender().ID)
+		}
+		return err
+	})
+
+	a.bot.Handle(&tb.InlineButton{Unique: keyRoomCancel}, func(c tb.Context) error {
+		vendorID := strconv.FormatInt(c.Sender().ID, 10)
+
+		if _, err := a.services.FocusRoomService.Cancel(context.Background(), c.Data(), vendorID); err != nil {
+			return c.Respond(&tb.CallbackResponse{Text: apperrors.GetMessage(err)})
+		}
+
+		if _, err := a.bot.Edit(c.Message(), msgRoomCancelled); err != nil {
+			a.logger.Error(context.Background(), "Failed to close focus room lobby", err, "user", c.Sender().ID)
+		}
+		return c.Respond()
+	})
+}
+
+func (a *API) handleRoom(c tb.Context) error {
+	ctx := context.Background()
+
+	chat := c.Chat()
+	if chat.Type != tb.ChatGroup && chat.Type != tb.ChatSuperGroup {
+		return c.Send(msgRoomGroupOnly, &tb.SendOptions{ParseMode: tb.ModeMarkdown})
+	}
+
+	args := c.Args()
+	if len(args) != 1 {
+		return c.Send(msgRoomUsage, &tb.SendOptions{ParseMode: tb.ModeMarkdown})
+	}
+	duration, err := time.ParseDuration(args[0])
+	if err != nil {
+		return c.Send(msgInvalidDuration+"\n\n"+msgRoomUsage, &tb.SendOptions{ParseMode: tb.ModeMarkdown})
+	}
+
+	if err := a.ensureUser(ctx, c.Sender()); err != nil {
+		return err
+	}
+
+	room, participants, err := a.services.FocusRoomService.Open(ctx, dto.OpenFocusRoomRequest{
+		ChatID:   strconv.FormatInt(chat.ID, 10),
+		VendorID: strconv.FormatInt(c.Sender().ID, 10),
+		Duration: duration,
+	})
+	if err != nil {
+		if apperrors.IsCode(err, apperrors.BadRequest) {
+			return c.Send("❌ "+apperrors.GetMessage(err), &tb.SendOptions{ParseMode: tb.ModeMarkdown})
+		}
+		return err
+	}
+
+	opts := &tb.SendOptions{ParseMode: tb.ModeMarkdown, ReplyMarkup: roomLobbyMarkup(room.ID)}
+	if err := c.Send(renderRoomLobby(room, participants), opts); err != nil {
+		return apperrors.NewInternal().WithDescriptionAndCause(ErrMsgRoomLobby, err)
+	}
+
+	return nil
+}
+
+func (a *API) joinRoom(c tb.Context) error {
+	ctx := context.Background()
+
+	if err := a.ensureUser(ctx, c.Sender()); err != nil {
+		_ = c.Respond(&tb.CallbackResponse{Text: apperrors.GetMessage(err)})
+		return err
+	}
+
+	vendorID := strconv.FormatInt(c.Sender().ID, 10)
+	room, participants, err := a.services.FocusRoomService.Join(ctx, c.Data(), vendorID)
+	if err != nil {
+		if apperrors.IsCode(err, apperrors.Conflict) {
+			return c.Respond(&tb.CallbackResponse{Text: msgRoomRejoined})
+		}
+		return c.Respond(&tb.CallbackResponse{Text: apperrors.GetMessage(err)})
+	}
+
+	opts := &tb.SendOptions{ParseMode: tb.ModeMarkdown, ReplyMarkup: roomLobbyMarkup(room.ID)}
+	if _, err := a.bot.Edit(c.Message(), renderRoomLobby(room, participants), opts); err != nil {
+		a.logger.Error(ctx, "Failed to update focus room lobby", err, "room", room.ID)
+	}
+
+	return c.Respond(&tb.CallbackResponse{Text: msgRoomJoined})
+}
+
+func (a *API) startRoom(c tb.Context) error {
+	ctx := context.Background()
+	vendorID := strconv.FormatInt(c.Sender().ID, 10)
+
+	room, participants, err := a.services.FocusRoomService.Start(ctx, c.Data(), vendorID, models.FocusSessionEventSourceTelegram)
+	if err != nil {
+		return c.Respond(&tb.CallbackResponse{Text: apperrors.GetMessage(err)})
+	}
+
+	var focusing, busy []string
+	for _, participant := range participants {
+		name := markdownEscaper.Replace(participant.Name)
+		if participant.Session == nil {
+			busy = append(busy, name)
+			continue
+		}
+		focusing = append(focusing, name)
+
+		if err := a.sendRoomCountdown(ctx, participant.VendorID); err != nil {
+			a.logger.Error(ctx, "Failed to send focus room countdown", err, "room", room.ID, "user", participant.VendorID)
+		}
+	}
+
+	msg := fmt.Sprintf(msgRoomStarted, formatDuration(room.Duration), strings.Join(focusing, ", "))
+	if len(busy) > 0 {
+		msg += fmt.Sprintf(msgRoomBusy, strings.Join(busy, ", "))
+	}
+	if _, err := a.bot.Edit(c.Message(), msg, &tb.SendOptions{ParseMode: tb.ModeMarkdown}); err != nil {
+		a.logger.Error(ctx, "Failed to update focus room lobby", err, "room", room.ID)
+	}
+
+	return c.Respond()
+}
+
+func (a *API) sendRoomCountdown(ctx context.Context, vendorID string) error {
+	vendorChat, err := chatFromVendorID(vendorID)
+	if err != nil {
+		return err
+	}
+
+	state, err := a.services.FocusSessionService.State(ctx, vendorID)
+	if err != nil {
+		return err
+	}
+
+	opts := &tb.SendOptions{
+		ParseMode:   tb.ModeMarkdown,
+		ReplyMarkup: liveMessageMarkup(state),
+	}
+	sent, err := a.bot.Send(vendorChat, renderLiveMessage(state), opts)
+	if err != nil {
+		return apperrors.NewInternal().WithDescriptionAndCause(ErrMsgRoomCountdown, err)
+	}
+	a.trackLiveMessage(vendorID, state.SessionID, sent)
+
+	return nil
+}
+
+// The room is claimed first so only one finish posts the summary.
+func (a *API) refreshRoomSummary(ctx context.Context, roomID string) error {
+	room, participants, err := a.services.FocusRoomService.Summary(ctx, roomID)
+	if err != nil {
+		return err
+	}
+
+	chatID, err := strconv.ParseInt(room.ChatID, 10, 64)
+	if err != nil {
+		return apperrors.NewInternal().WithDescriptionAndCause(ErrMsgInvalidVendorID, err)
+	}
+	msg := fmt.Sprintf(msgRoomSummary, formatDuration(room.Duration), renderRoomResults(participants))
+	opts := &tb.SendOptions{ParseMode: tb.ModeMarkdown}
+
+	switch room.Status {
+	case models.FocusRoomStatusRunning:
+		for _, participant := range participants {
+			if participant.Session != nil && participant.Session.Status == models.FocusSessionStatusActive {
+				return nil
+			}
+		}
+
+		claimed, err := a.services.FocusRoomService.Finish(ctx, room.ID)
+		if err != nil || !claimed {
+			return err
+		}
+
+		sent, err := a.bot.Send(&tb.Chat{ID: chatID}, msg, opts)
+		if err != nil {
+			return apperrors.NewInternal().WithDescriptionAndCause(ErrMsgRoomSummary, err)
+		}
+		return a.services.FocusRoomService.SetSummaryMessage(ctx, room.ID, strconv.Itoa(sent.ID))
+	case models.FocusRoomStatusFinished:
+		if room.SummaryMessageID == "" {
+			return nil
+		}
+
+		summary := tb.StoredMessage{MessageID: room.SummaryMessageID, ChatID: chatID}
+		if _, err := a.bot.Edit(summary, msg, opts); err != nil {
+			return apperrors.NewInternal().WithDescriptionAndCause(ErrMsgRoomSummary, err)
+		}
+	}
+
+	return nil
+}
+
+func renderRoomLobby(room models.FocusRoom, participants []models.FocusRoomParticipant) string {
+	var host string
+	names := make([]string, 0, len(participants))
+	for _, participant := range participants {
+		name := markdownEscaper.Replace(participant.Name)
+		if participant.UserID == room.HostUserID {
+			host = name
+		}
+		names = append(names, "• "+name)
+	}
+
+	return fmt.Sprintf(msgRoomLobby, formatDuration(room.Duration), host, len(participants), strings.Join(names, "\n"))
+}
+
+func renderRoomResults(participants []models.FocusRoomParticipant) string {
+	lines := make([]string, 0, len(participants))
+	for _, participant := range participants {
+		line := "• " + markdownEscaper.Replace(participant.Name) + ": "
+
+		session := participant.Session
+		switch {
+		case session == nil:
+			line += "sat this one out"
+		case session.Status == models.FocusSessionStatusActive:
+			line += "still focusing"
+		default:
+			line += "`" + formatDuration(session.FocusedDuration) + "` focused"
+			if session.Status != models.FocusSessionStatusCompleted {
+				line += " (left early)"
+			}
+			if session.Quality > 0 {
+				line += fmt.Sprintf(", rated %d/10", session.Quality)
+			} else {
+				line += ", not rated yet"
+			}
+		}
+		lines = append(lines, line)
+	}
+
+	return strings.Join(lines, "\n")
+}
+
+func roomLobbyMarkup(roomID string) *tb.ReplyMarkup {
+	return &tb.ReplyMarkup{
+		InlineKeyboard: [][]tb.InlineButton{
+			{
+				{Unique: keyRoomJoin, Text: "🙋 Join", Data: roomID},
+				{Unique: keyRoomStart, Text: "▶️ Start", Data: roomID},
+				{Unique: keyRoomCancel, Text: "✖️ Cancel", Data: roomID},
+			},
+		},
+	}
+}
+
+// Room sessions end together, so there are no pause or extend controls.
+func roomControlMarkup() *tb.ReplyMarkup {
+	return &tb.ReplyMarkup{
+		InlineKeyboard: [][]tb.InlineButton{
+			{{Unique: keyFocusStop, Text: "🚪 Leave room"}},
+			{distractedButton()},
+		},
+	}
+}
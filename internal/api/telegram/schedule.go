@@ -0,0 +1,249 @@
+package telegram
+
+import (
+	"attune/internal/api"
+	"attune/internal/dto"
+	"attune/internal/models"
+	"attune/pkg/apperrors"
+	"context"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	tb "gopkg.in/telebot.v4"
+)
+
+const (
+	keyScheduleStart  = "schedule_start"
+	keyScheduleSkip   = "schedule_skip"
+	keyScheduleSnooze = "schedule_snooze"
+	keyScheduleDelete = "schedule_delete"
+
+	scheduleSnoozeDelay = 10 * time.Minute
+	scheduleDateLayout  = "2006-01-02"
+
+	msgScheduleUsage = "🗓️ *Schedule a focus session*\n" +
+		"`/schedule <when> <HH:MM> <duration>`\n" +
+		"_when_ is once, daily, weekdays, weekends, weekly or a date (YYYY-MM-DD),\n" +
+		"e.g. `/schedule weekdays 09:00 50m`."
+	msgScheduleList    = "🗓️ *Your scheduled sessions*\nTap one to delete it."
+	msgScheduleNone    = "You have no scheduled sessions."
+	msgScheduleCreated = "✅ *Scheduled!* %s\nNext session: `%s`"
+	msgScheduleDeleted = "🗑️ Schedule deleted."
+	msgScheduleDue     = "⏰ *Time to focus!*\nYour scheduled `%s` session starts now."
+	msgScheduleSkipped = "⏭️ Scheduled session skipped."
+	msgScheduleSnoozed = "😴 Snoozed until `%s`."
+	msgScheduleStarted = "▶️ Starting your scheduled session."
+	msgScheduleInvalid = "❌ *Invalid schedule.*\n"
+)
+
+var (
+	ErrMsgScheduleDue  = "failed to send scheduled session prompt"
+	ErrMsgScheduleList = "failed to send focus schedules"
+)
+
+func (a *API) registerScheduleCommands() {
+	a.bot.Handle("/schedule", func(c tb.Context) error {
+		err := a.handleSchedule(c)
+		if err != nil {
+			a.logger.Error(context.Background(), "Error handling /schedule command", err, "user", c.Sender().ID)
+		}
+		return err
+	})
+
+	a.bot.Handle(&tb.InlineButton{Unique: keyScheduleStart}, func(c tb.Context) error {
+		err := a.startScheduledSession(c)
+		if err != nil {
+			a.logger.Error(context.Background(), "Error starting scheduled session", err, "user", c.Sender().ID)
+		}
+		return err
+	})
+
+	a.bot.Handle(&tb.InlineButton{Unique: keyScheduleSkip}, func(c tb.Context) error {
+		if _, err := a.bot.Edit(c.Message(), msgScheduleSkipped); err != nil {
+			a.logger.Error(context.Background(), "Failed to close scheduled session prompt", err, "user", c.Sender().ID)
+		}
+		return c.Respond()
+	})
+
+	a.bot.Handle(&tb.InlineButton{Unique: keyScheduleSnooze}, func(c tb.Context) error {
+		vendorID := strconv.FormatInt(c.Sender().ID, 10)
+
+		snoozed, err := a.services.FocusScheduleService.Snooze(context.Background(), vendorID, c.Data(), scheduleSnoozeDelay)
+		if err != nil {
+			a.logger.Error(context.Background(), "Failed to snooze focus schedule", err, "user", c.Sender().ID)
+			return c.Respond(&tb.CallbackResponse{Text: apperrors.GetMessage(err)})
+		}
+
+		msg := fmt.Sprintf(msgScheduleSnoozed, formatScheduleTime(snoozed))
+		if _, err := a.bot.Edit(c.Message(), msg, &tb.SendOptions{ParseMode: tb.ModeMarkdown}); err != nil {
+			a.logger.Error(context.Background(), "Failed to close scheduled session prompt", err, "user", c.Sender().ID)
+		}
+		return c.Respond()
+	})
+
+	a.bot.Handle(&tb.InlineButton{Unique: keyScheduleDelete}, func(c tb.Context) error {
+		vendorID := strconv.FormatInt(c.Sender().ID, 10)
+
+		if err := a.services.FocusScheduleService.Delete(context.Background(), vendorID, c.Data()); err != nil {
+			a.logger.Error(context.Background(), "Failed to delete focus schedule", err, "user", c.Sender().ID)
+			return c.Respond(&tb.CallbackResponse{Text: apperrors.GetMessage(err)})
+		}
+
+		if err := a.sendScheduleList(c, true); err != nil {
+			a.logger.Error(context.Background(), "Failed to refresh focus schedules", err, "user", c.Sender().ID)
+		}
+		return c.Respond(&tb.CallbackResponse{Text: msgScheduleDeleted})
+	})
+}
+
+func (a *API) handleSchedule(c tb.Context) error {
+	args := c.Args()
+	if len(args) == 0 {
+		return a.sendScheduleList(c, false)
+	}
+
+	req, err := parseScheduleArgs(args)
+	if err != nil {
+		_, _ = a.bot.Send(c.Sender(), msgScheduleInvalid+msgScheduleUsage, &tb.SendOptions{ParseMode: tb.ModeMarkdown})
+		return nil
+	}
+	req.VendorID = strconv.FormatInt(c.Sender().ID, 10)
+
+	schedule, err := a.services.FocusScheduleService.Create(context.Background(), req)
+	if err != nil {
+		if apperrors.IsCode(err, apperrors.BadRequest) {
+			_, _ = a.bot.Send(c.Sender(), msgScheduleInvalid+apperrors.GetMessage(err), &tb.SendOptions{ParseMode: tb.ModeMarkdown})
+			return nil
+		}
+		return err
+	}
+
+	msg := fmt.Sprintf(msgScheduleCreated, describeSchedule(schedule), formatScheduleTime(schedule))
+	_, err = a.bot.Send(c.Sender(), msg, &tb.SendOptions{ParseMode: tb.ModeMarkdown})
+	return err
+}
+
+func parseScheduleArgs(args []string) (dto.CreateFocusScheduleRequest, error) {
+	if len(args) != 3 {
+		return dto.CreateFocusScheduleRequest{}, fmt.Errorf("expected 3 arguments, got %d", len(args))
+	}
+
+	duration, err := time.ParseDuration(args[2])
+	if err != nil {
+		return dto.CreateFocusScheduleRequest{}, err
+	}
+
+	req := dto.CreateFocusScheduleRequest{
+		Rule:      models.FocusScheduleRule(strings.ToLower(args[0])),
+		StartTime: args[1],
+		Duration:  duration,
+	}
+	if date, err := time.Parse(scheduleDateLayout, args[0]); err == nil {
+		req.Rule = models.FocusScheduleRuleOnce
+		req.Date = date
+	}
+
+	return req, nil
+}
+
+func (a *API) sendScheduleList(c tb.Context, edit bool) error {
+	vendorID := strconv.FormatInt(c.Sender().ID, 10)
+
+	schedules, err := a.services.FocusScheduleService.List(context.Background(), vendorID)
+	if err != nil && !apperrors.IsCode(err, apperrors.NotFound) {
+		return err
+	}
+
+	msg := msgScheduleNone + "\n\n" + msgScheduleUsage
+	opts := &tb.SendOptions{ParseMode: tb.ModeMarkdown}
+	if len(schedules) > 0 {
+		msg = msgScheduleList + "\n\n" + msgScheduleUsage
+		markup := &tb.ReplyMarkup{}
+		for _, schedule := range schedules {
+			text := fmt.Sprintf("🗑️ %s · next %s", describeSchedule(schedule), formatScheduleTime(schedule))
+			markup.InlineKeyboard = append(markup.InlineKeyboard, []tb.InlineButton{
+				{Unique: keyScheduleDelete, Text: text, Data: schedule.ID},
+			})
+		}
+		opts.ReplyMarkup = markup
+	}
+
+	if edit {
+		_, err = a.bot.Edit(c.Message(), msg, opts)
+	} else {
+		_, err = a.bot.Send(c.Sender(), msg, opts)
+	}
+	if err != nil {
+		return apperrors.NewInternal().WithDescriptionAndCause(ErrMsgScheduleList, err)
+	}
+
+	return nil
+}
+
+func (a *API) promptScheduledSession(_ context.Context, vendorID string, trigger api.Trigger) error {
+	schedule := trigger.Schedule
+	if schedule == nil {
+		return nil
+	}
+
+	vendorChat, err := chatFromVendorID(vendorID)
+	if err != nil {
+		return err
+	}
+
+	opts := &tb.SendOptions{
+		ParseMode: tb.ModeMarkdown,
+		ReplyMarkup: &tb.ReplyMarkup{
+			InlineKeyboard: [][]tb.InlineButton{
+				{
+					{Unique: keyScheduleStart, Text: "Start", Data: schedule.ID},
+					{Unique: keyScheduleSkip, Text: "Skip", Data: schedule.ID},
+					{Unique: keyScheduleSnooze, Text: "Snooze 10m", Data: schedule.ID},
+				},
+			},
+		},
+	}
+	msg := fmt.Sprintf(msgScheduleDue, formatDuration(schedule.Duration))
+	if _, err := a.bot.Send(vendorChat, msg, opts); err != nil {
+		return apperrors.NewInternal().WithDescriptionAndCause(ErrMsgScheduleDue, err)
+	}
+
+	return nil
+}
+
+func (a *API) startScheduledSession(c tb.Context) error {
+	vendorID := strconv.FormatInt(c.Sender().ID, 10)
+
+	schedule, err := a.services.FocusScheduleService.Get(context.Background(), vendorID, c.Data())
+	if err != nil {
+		_ = c.Respond(&tb.CallbackResponse{Text: apperrors.GetMessage(err)})
+		return err
+	}
+
+	if _, err := a.bot.Edit(c.Message(), msgScheduleStarted); err != nil {
+		a.logger.Error(context.Background(), "Failed to close scheduled session prompt", err, "user", c.Sender().ID)
+	}
+	_ = c.Respond()
+
+	return a.startFocusSession(c, schedule.Duration)
+}
+
+func describeSchedule(schedule models.FocusSchedule) string {
+	when := string(schedule.Rule)
+	if schedule.Rule == models.FocusScheduleRuleWeekly {
+		when = "every " + schedule.Weekday.String()
+	}
+
+	return fmt.Sprintf("%s at %s for %s", when, schedule.StartTime, formatDuration(schedule.Duration))
+}
+
+func formatScheduleTime(schedule models.FocusSchedule) string {
+	runAt := schedule.NextRunAt
+	if loc, err := time.LoadLocation(schedule.Timezone); err == nil {
+		runAt = runAt.In(loc)
+	}
+
+	return runAt.Format("Mon 02 Jan 15:04 MST")
+}
@@ -42,9 +42,27 @@ func registerStartCommand(api *API) {
 }
 
 func (a *API) handleStart(c tb.Context) error {
-	ctx := context.Background()
+	if err := a.ensureUser(context.Background(), c.Sender()); err != nil {
+		return err
+	}
+
+	sendOpts := &tb.SendOptions{
+		ParseMode: tb.ModeMarkdown,
+	}
+	if _, err := a.bot.Send(c.Sender(), msgWelcome, sendOpts); err != nil {
+		return apperrors.NewInternal().WithDescriptionAndCause(ErrMsgSendWelcome, err)
+	}
+
+	if _, err := a.bot.Send(c.Sender(), msgRoadmap, sendOpts); err != nil {
+		return apperrors.NewInternal().WithDescriptionAndCause(ErrMsgSendRoadmap, err)
+	}
+
+	return a.createFocusSession(c)
+}
 
-	vendorID := strconv.FormatInt(c.Sender().ID, 10)
+// Users may join a room in a group before they have started the bot.
+func (a *API) ensureUser(ctx context.Context, sender *tb.User) error {
+	vendorID := strconv.FormatInt(sender.ID, 10)
 
 	users, _, err := a.services.UserService.List(ctx, storage.ListUserFilter{
 		VendorID: vendorID,
@@ -57,23 +75,12 @@ func (a *API) handleStart(c tb.Context) error {
 		createReq := dto.CreateUserRequest{
 			VendorID:   vendorID,
 			VendorType: "telegram",
-			Name:       c.Sender().FirstName,
+			Name:       sender.FirstName,
 		}
 		if err := a.services.UserService.Create(ctx, createReq); err != nil {
 			return apperrors.NewInternal().WithDescriptionAndCause(ErrMsgCreateUser, err)
 		}
 	}
 
-	sendOpts := &tb.SendOptions{
-		ParseMode: tb.ModeMarkdown,
-	}
-	if _, err := a.bot.Send(c.Sender(), msgWelcome, sendOpts); err != nil {
-		return apperrors.NewInternal().WithDescriptionAndCause(ErrMsgSendWelcome, err)
-	}
-
-	if _, err := a.bot.Send(c.Sender(), msgRoadmap, sendOpts); err != nil {
-		return apperrors.NewInternal().WithDescriptionAndCause(ErrMsgSendRoadmap, err)
-	}
-
-	return a.createFocusSession(c)
+	return nil
 }
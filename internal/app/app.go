@@ -3,6 +3,7 @@ package app
 import (
 	"context"
 	"database/sql"
+	"fmt"
 	"log"
 	"os"
 	"os/signal"
@@ -12,9 +13,11 @@ import (
 	"attune/internal/api"
 	"attune/internal/api/telegram"
 	"attune/internal/config"
+	"attune/internal/models"
 	"attune/internal/service"
 	"attune/internal/storage"
 	"attune/pkg/cache"
+	"attune/pkg/clock"
 	"attune/pkg/db"
 	"attune/pkg/logger"
 	"attune/pkg/transactor"
@@ -32,13 +35,15 @@ func MustRun() {
 	pgConn, dsn := db.MustConnect(ctx, cfg.Postgres)
 
 	if cfg.APP.Migrate {
-		migrate(dsn)
+		migrate(dsn, cfg.APP.Timezone)
 	}
 
 	slog := logger.NewSLogger()
 	storages := storage.NewStorages(pgConn)
 
-	focusSessionManagerCache := cache.NewCache()
+	clk := clock.NewRealClock()
+
+	focusSessionManagerCache := cache.NewCache(cache.WithClock(clk))
 	servicesCache := cache.NewCache()
 	telegramCache := cache.NewCache()
 
@@ -47,10 +52,46 @@ func MustRun() {
 	apiCh := make(chan api.Trigger)
 	stopCh := make(chan struct{})
 
-	focusSessionManager := service.NewFocusSessionManager(storages, focusSessionManagerCache, apiCh)
-	services := service.NewServices(storages, focusSessionManager, pgxTx, slog, servicesCache)
+	milestones, err := models.ParseFocusMilestones(cfg.Focus.Milestones)
+	if err != nil {
+		log.Fatalf("invalid focus milestones: %v", err)
+	}
+	location, err := time.LoadLocation(cfg.APP.Timezone)
+	if err != nil {
+		log.Fatalf("invalid app timezone: %v", err)
+	}
+	services := service.NewServices(
+		storages,
+		focusSessionManagerCache,
+		pgxTx,
+		slog,
+		servicesCache,
+		clk,
+		apiCh,
+		service.ServicesConfig{
+			Timezone: cfg.APP.Timezone,
+			Location: location,
+			FocusSessionManager: service.FocusSessionManagerConfig{
+				Milestones: milestones,
+				Location:   location,
+				MaxPause:   cfg.Focus.MaxPause,
+			},
+		},
+	)
+	if err := services.FocusSessionManager.Restore(ctx); err != nil {
+		slog.Error(ctx, "failed to restore focus sessions", err)
+	}
 
-	telegramAPI := telegram.NewTelegramAPI(cfg.Telegram.Token, "base_url", cfg.Telegram.PollTimeout, *services, slog, telegramCache, apiCh)
+	telegramAPI := telegram.NewTelegramAPI(
+		cfg.Telegram.Token,
+		"base_url",
+		cfg.Telegram.PollTimeout,
+		cfg.Telegram.LiveUpdateInterval,
+		*services,
+		slog,
+		telegramCache,
+		apiCh,
+	)
 
 	go func() {
 		caches := []cache.Cache{
@@ -68,6 +109,43 @@ func MustRun() {
 		cache.StartCacheWorker(context.Background(), cacheCfg)
 	}()
 
+	focusScheduler := service.NewFocusScheduler(
+		storages,
+		telegramAPI,
+		slog,
+		clk,
+		service.FocusSchedulerConfig{
+			Interval:    cfg.Focus.ScheduleInterval,
+			GraceWindow: cfg.Focus.ScheduleGraceWindow,
+		},
+	)
+	go focusScheduler.Start(ctx)
+
+	dayReminder := service.NewDayReminder(
+		storages,
+		telegramAPI,
+		slog,
+		clk,
+		service.DayReminderConfig{
+			At:       cfg.Day.CheckInAt,
+			Location: location,
+		},
+	)
+	go dayReminder.Start(ctx)
+
+	reportScheduler := service.NewReportScheduler(
+		storages,
+		services.ReportService,
+		telegramAPI,
+		slog,
+		clk,
+		service.ReportSchedulerConfig{
+			At:       cfg.Report.At,
+			Location: location,
+		},
+	)
+	go reportScheduler.Start(ctx)
+
 	go func() {
 		if err := telegramAPI.Start(ctx); err != nil {
 			log.Fatalf("failed to start telegram API: %v", err)
@@ -83,7 +161,7 @@ func MustRun() {
 	_, shutdownCancel := context.WithTimeout(ctx, 10*time.Second)
 	defer shutdownCancel()
 
-	focusSessionManager.GracefulShutdown()
+	services.FocusSessionManager.GracefulShutdown()
 
 	pgConn.Close()
 	log.Print("Postgres connection closed")
@@ -91,8 +169,10 @@ func MustRun() {
 	log.Print("shutting down services")
 }
 
-func migrate(dsn string) {
-	sqlDB, err := sql.Open("pgx", dsn)
+// Backfilled local dates must match the ones the app computes, so the migrations run in
+// the app's timezone.
+func migrate(dsn, timezone string) {
+	sqlDB, err := sql.Open("pgx", fmt.Sprintf("%s timezone='%s'", dsn, timezone))
 	if err != nil {
 		log.Fatalf("Failed to open SQL connection: %v", err)
 	}
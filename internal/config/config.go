@@ -13,6 +13,9 @@ type Config struct {
 	Postgres PostgresConfig
 	HTTP     HTTPConfig
 	Telegram TelegramConfig
+	Focus    FocusConfig
+	Day      DayConfig
+	Report   ReportConfig
 }
 
 type PostgresConfig struct {
@@ -26,7 +29,8 @@ type PostgresConfig struct {
 }
 
 type APPConfig struct {
-	Migrate bool `env:"APP_MIGRATE" envDefault:"false"`
+	Migrate  bool   `env:"APP_MIGRATE" envDefault:"false"`
+	Timezone string `env:"APP_TIMEZONE" env-default:"UTC"`
 }
 
 type HTTPConfig struct {
@@ -34,8 +38,24 @@ type HTTPConfig struct {
 }
 
 type TelegramConfig struct {
-	Token       string        `env:"TELEGRAM_TOKEN"`
-	PollTimeout time.Duration `env:"TELEGRAM_POLL_TIMEOUT" envDefault:"10s"`
+	Token              string        `env:"TELEGRAM_TOKEN"`
+	PollTimeout        time.Duration `env:"TELEGRAM_POLL_TIMEOUT" envDefault:"10s"`
+	LiveUpdateInterval time.Duration `env:"TELEGRAM_LIVE_UPDATE_INTERVAL" env-default:"30s"`
+}
+
+type FocusConfig struct {
+	Milestones          []string      `env:"FOCUS_MILESTONES" env-default:"50%,5m"`
+	ScheduleInterval    time.Duration `env:"FOCUS_SCHEDULE_INTERVAL" env-default:"30s"`
+	ScheduleGraceWindow time.Duration `env:"FOCUS_SCHEDULE_GRACE_WINDOW" env-default:"15m"`
+	MaxPause            time.Duration `env:"FOCUS_MAX_PAUSE" env-default:"1h"`
+}
+
+type DayConfig struct {
+	CheckInAt string `env:"DAY_CHECKIN_AT" env-default:"21:00"`
+}
+
+type ReportConfig struct {
+	At string `env:"REPORT_AT" env-default:"09:00"`
 }
 
 var (
@@ -1,7 +1,13 @@
 package dto
 
+import (
+	"attune/internal/models"
+	"time"
+)
+
 type CreateDayRecordRequest struct {
-	UserID  string `json:"userId"`
-	Quality int    `json:"quality"`
-	Mood    string `json:"mood"`
+	UserID    string      `json:"userId"`
+	LocalDate time.Time   `json:"localDate"`
+	Quality   int         `json:"quality"`
+	Mood      models.Mood `json:"mood"`
 }
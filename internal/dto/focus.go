@@ -8,20 +8,37 @@ import (
 type UpdateFocusRequestType string
 
 const (
-	UpdateFocusRequestTypePause   UpdateFocusRequestType = "Pause"
-	UpdateFocusRequestTypeResume  UpdateFocusRequestType = "Resume"
-	UpdateFocusRequestTypeStop    UpdateFocusRequestType = "Stop"
-	UpdateFocusRequestTypeQuality UpdateFocusRequestType = "Quality"
+	UpdateFocusRequestTypePause     UpdateFocusRequestType = "Pause"
+	UpdateFocusRequestTypeResume    UpdateFocusRequestType = "Resume"
+	UpdateFocusRequestTypeStop      UpdateFocusRequestType = "Stop"
+	UpdateFocusRequestTypeQuality   UpdateFocusRequestType = "Quality"
+	UpdateFocusRequestTypeSkipBreak UpdateFocusRequestType = "SkipBreak"
+	UpdateFocusRequestTypeExtend    UpdateFocusRequestType = "Extend"
+	UpdateFocusRequestTypeNote      UpdateFocusRequestType = "Note"
 )
 
 type CreateFocusSessionRequest struct {
-	VendorID string        `json:"vendorId"`
-	Duration time.Duration `json:"duration"`
+	VendorID string                         `json:"vendorId"`
+	Duration time.Duration                  `json:"duration"`
+	Pomodoro *models.PomodoroConfig         `json:"pomodoro,omitempty"`
+	Label    string                         `json:"label,omitempty"`
+	Break    bool                           `json:"break,omitempty"`
+	Replace  bool                           `json:"replace"`
+	Source   models.FocusSessionEventSource `json:"source"`
 }
 
 type UpdateFocusRequest struct {
-	VendorID string                    `json:"id"`
-	Type     UpdateFocusRequestType    `json:"type"`
-	Status   models.FocusSessionStatus `json:"status"`
-	Quality  int                       `json:"quality"`
+	VendorID  string                         `json:"id"`
+	SessionID string                         `json:"sessionId"`
+	Type      UpdateFocusRequestType         `json:"type"`
+	Status    models.FocusSessionStatus      `json:"status"`
+	Quality   int                            `json:"quality"`
+	Delta     time.Duration                  `json:"delta"`
+	Note      string                         `json:"note"`
+	Source    models.FocusSessionEventSource `json:"source"`
+}
+
+type CreateFocusDistractionRequest struct {
+	VendorID string `json:"vendorId"`
+	Reason   string `json:"reason"`
 }
@@ -0,0 +1,14 @@
+package dto
+
+import (
+	"attune/internal/models"
+	"time"
+)
+
+type CreateFocusSessionEventRequest struct {
+	SessionID  string                         `json:"sessionId"`
+	UserID     string                         `json:"userId"`
+	Type       models.FocusSessionEventType   `json:"type"`
+	Source     models.FocusSessionEventSource `json:"source"`
+	OccurredAt time.Time                      `json:"occurredAt"`
+}
@@ -0,0 +1,9 @@
+package dto
+
+import "time"
+
+type OpenFocusRoomRequest struct {
+	ChatID   string        `json:"chatId"`
+	VendorID string        `json:"vendorId"`
+	Duration time.Duration `json:"duration"`
+}
@@ -0,0 +1,15 @@
+package dto
+
+import (
+	"attune/internal/models"
+	"time"
+)
+
+type CreateFocusScheduleRequest struct {
+	VendorID string                   `json:"vendorId"`
+	Rule     models.FocusScheduleRule `json:"rule"`
+	// Date pins a one-off schedule to a day; the next occurrence is used when it is zero.
+	Date      time.Time     `json:"date"`
+	StartTime string        `json:"startTime"`
+	Duration  time.Duration `json:"duration"`
+}
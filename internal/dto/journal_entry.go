@@ -0,0 +1,12 @@
+package dto
+
+type CreateJournalEntryRequest struct {
+	VendorID string `json:"vendorId"`
+	Text     string `json:"text"`
+}
+
+type UpdateJournalEntryRequest struct {
+	VendorID string `json:"vendorId"`
+	EntryID  string `json:"entryId"`
+	Text     string `json:"text"`
+}
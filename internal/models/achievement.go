@@ -0,0 +1,167 @@
+package models
+
+import (
+	"time"
+)
+
+type StreakKind string
+
+const (
+	StreakKindCheckIn   StreakKind = "check_in"
+	StreakKindFocusGoal StreakKind = "focus_goal"
+)
+
+const (
+	// A new streak starts with all freezes, so a missed weekend does not reset it.
+	StreakFreezesMax  = 2
+	StreakFreezeEvery = 7
+)
+
+type Streak struct {
+	UserID    string     `json:"userId"`
+	Kind      StreakKind `json:"kind"`
+	Current   int        `json:"current"`
+	Longest   int        `json:"longest"`
+	LastDate  time.Time  `json:"lastDate"`
+	Freezes   int        `json:"freezes"`
+	UpdatedAt time.Time  `json:"updatedAt"`
+}
+
+func NewStreak(userID string, kind StreakKind) Streak {
+	return Streak{
+		UserID:  userID,
+		Kind:    kind,
+		Freezes: StreakFreezesMax,
+	}
+}
+
+// Days missed since LastDate are covered by freezes; without enough of them the streak
+// starts over.
+func (s *Streak) Record(date, now time.Time) bool {
+	if s.Current > 0 && !date.After(s.LastDate) {
+		return false
+	}
+
+	missed := s.missed(date)
+	if s.Current == 0 || missed > s.Freezes {
+		s.Current = 1
+		s.Freezes = StreakFreezesMax
+	} else {
+		s.Freezes -= missed
+		s.Current++
+		if s.Current%StreakFreezeEvery == 0 && s.Freezes < StreakFreezesMax {
+			s.Freezes++
+		}
+	}
+	s.Longest = max(s.Longest, s.Current)
+	s.LastDate = date
+	s.UpdatedAt = now
+
+	return true
+}
+
+// CurrentOn is zero once more days were missed than the freezes cover, even before the
+// next date is recorded.
+func (s Streak) CurrentOn(date time.Time) int {
+	if s.Current == 0 || s.missed(date) > s.Freezes {
+		return 0
+	}
+
+	return s.Current
+}
+
+// missed counts the days between LastDate and date, both excluded.
+func (s Streak) missed(date time.Time) int {
+	return max(0, int(date.Sub(s.LastDate)/(24*time.Hour))-1)
+}
+
+type BadgeCode string
+
+const (
+	BadgeFirstSession      BadgeCode = "first_session"
+	BadgeSessions100       BadgeCode = "sessions_100"
+	BadgeFocus10Hours      BadgeCode = "focus_10h"
+	BadgeFocus100Hours     BadgeCode = "focus_100h"
+	BadgeFirstCheckIn      BadgeCode = "first_check_in"
+	BadgeCheckInStreak7    BadgeCode = "check_in_streak_7"
+	BadgeCheckInStreak30   BadgeCode = "check_in_streak_30"
+	BadgeFocusGoalStreak7  BadgeCode = "focus_goal_streak_7"
+	BadgeFocusGoalStreak30 BadgeCode = "focus_goal_streak_30"
+)
+
+type BadgeMetric string
+
+const (
+	BadgeMetricSessions        BadgeMetric = "sessions"
+	BadgeMetricFocusHours      BadgeMetric = "focus_hours"
+	BadgeMetricCheckInStreak   BadgeMetric = "check_in_streak"
+	BadgeMetricFocusGoalStreak BadgeMetric = "focus_goal_streak"
+)
+
+type Badge struct {
+	Code      BadgeCode   `json:"code"`
+	Emoji     string      `json:"emoji"`
+	Title     string      `json:"title"`
+	Metric    BadgeMetric `json:"metric"`
+	Threshold int         `json:"threshold"`
+}
+
+var Badges = []Badge{
+	{Code: BadgeFirstSession, Emoji: "🌱", Title: "First session", Metric: BadgeMetricSessions, Threshold: 1},
+	{Code: BadgeSessions100, Emoji: "💯", Title: "100 sessions", Metric: BadgeMetricSessions, Threshold: 100},
+	{Code: BadgeFocus10Hours, Emoji: "⏱", Title: "10 hours focused", Metric: BadgeMetricFocusHours, Threshold: 10},
+	{Code: BadgeFocus100Hours, Emoji: "🏔", Title: "100 hours focused", Metric: BadgeMetricFocusHours, Threshold: 100},
+	{Code: BadgeFirstCheckIn, Emoji: "📝", Title: "First check-in", Metric: BadgeMetricCheckInStreak, Threshold: 1},
+	{Code: BadgeCheckInStreak7, Emoji: "🔥", Title: "7-day check-in streak", Metric: BadgeMetricCheckInStreak, Threshold: 7},
+	{Code: BadgeCheckInStreak30, Emoji: "🏆", Title: "30-day check-in streak", Metric: BadgeMetricCheckInStreak, Threshold: 30},
+	{Code: BadgeFocusGoalStreak7, Emoji: "🎯", Title: "7-day goal streak", Metric: BadgeMetricFocusGoalStreak, Threshold: 7},
+	{Code: BadgeFocusGoalStreak30, Emoji: "🥇", Title: "30-day goal streak", Metric: BadgeMetricFocusGoalStreak, Threshold: 30},
+}
+
+func LookupBadge(code BadgeCode) (Badge, bool) {
+	for _, badge := range Badges {
+		if badge.Code == code {
+			return badge, true
+		}
+	}
+
+	return Badge{}, false
+}
+
+type AchievementStats struct {
+	Sessions int           `json:"sessions"`
+	Focused  time.Duration `json:"focused"`
+	// The streaks are the longest ones, so a badge stays earned after a streak ends.
+	CheckInStreak   int `json:"checkInStreak"`
+	FocusGoalStreak int `json:"focusGoalStreak"`
+}
+
+func (b Badge) EarnedBy(stats AchievementStats) bool {
+	var value int
+	switch b.Metric {
+	case BadgeMetricSessions:
+		value = stats.Sessions
+	case BadgeMetricFocusHours:
+		value = int(stats.Focused / time.Hour)
+	case BadgeMetricCheckInStreak:
+		value = stats.CheckInStreak
+	case BadgeMetricFocusGoalStreak:
+		value = stats.FocusGoalStreak
+	}
+
+	return value >= b.Threshold
+}
+
+type UserBadge struct {
+	UserID    string    `json:"userId"`
+	Code      BadgeCode `json:"code"`
+	AwardedAt time.Time `json:"awardedAt"`
+}
+
+type Profile struct {
+	Today           time.Time        `json:"today"`
+	CheckInStreak   Streak           `json:"checkInStreak"`
+	FocusGoalStreak Streak           `json:"focusGoalStreak"`
+	Stats           AchievementStats `json:"stats"`
+	Badges          []UserBadge      `json:"badges"`
+}
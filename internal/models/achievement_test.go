@@ -0,0 +1,151 @@
+package models
+
+import (
+	"testing"
+	"time"
+)
+
+// streakDay is a local date in October 2026, which starts on a Thursday.
+func streakDay(day int) time.Time {
+	return time.Date(2026, time.October, day, 0, 0, 0, 0, time.UTC)
+}
+
+func TestStreak_Record(t *testing.T) {
+	tests := []struct {
+		name        string
+		days        []int
+		wantCurrent int
+		wantLongest int
+		wantFreezes int
+	}{
+		{
+			name:        "first day",
+			days:        []int{1},
+			wantCurrent: 1,
+			wantLongest: 1,
+			wantFreezes: StreakFreezesMax,
+		},
+		{
+			name:        "consecutive days",
+			days:        []int{1, 2, 3},
+			wantCurrent: 3,
+			wantLongest: 3,
+			wantFreezes: StreakFreezesMax,
+		},
+		{
+			// Friday to Monday spends both freezes on the weekend.
+			name:        "missed weekend",
+			days:        []int{1, 2, 5},
+			wantCurrent: 3,
+			wantLongest: 3,
+			wantFreezes: 0,
+		},
+		{
+			name:        "three missed days",
+			days:        []int{1, 2, 3, 7},
+			wantCurrent: 1,
+			wantLongest: 3,
+			wantFreezes: StreakFreezesMax,
+		},
+		{
+			name:        "missed day after the freezes ran out",
+			days:        []int{1, 2, 5, 7},
+			wantCurrent: 1,
+			wantLongest: 3,
+			wantFreezes: StreakFreezesMax,
+		},
+		{
+			// The seventh day of the streak earns the freeze spent on the 2nd back.
+			name:        "freeze earned back",
+			days:        []int{1, 3, 4, 5, 6, 7, 8},
+			wantCurrent: 7,
+			wantLongest: 7,
+			wantFreezes: StreakFreezesMax,
+		},
+		{
+			name:        "freeze not earned back before a week",
+			days:        []int{1, 3, 4, 5, 6, 7},
+			wantCurrent: 6,
+			wantLongest: 6,
+			wantFreezes: StreakFreezesMax - 1,
+		},
+		{
+			name:        "freezes never exceed the maximum",
+			days:        []int{1, 2, 3, 4, 5, 6, 7, 8, 9, 10, 11, 12, 13, 14},
+			wantCurrent: 14,
+			wantLongest: 14,
+			wantFreezes: StreakFreezesMax,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			streak := NewStreak("user-1", StreakKindCheckIn)
+			for _, day := range tt.days {
+				if !streak.Record(streakDay(day), streakDay(day)) {
+					t.Fatalf("record day %d: got unchanged", day)
+				}
+			}
+
+			if streak.Current != tt.wantCurrent {
+				t.Fatalf("current: got %d, want %d", streak.Current, tt.wantCurrent)
+			}
+			if streak.Longest != tt.wantLongest {
+				t.Fatalf("longest: got %d, want %d", streak.Longest, tt.wantLongest)
+			}
+			if streak.Freezes != tt.wantFreezes {
+				t.Fatalf("freezes: got %d, want %d", streak.Freezes, tt.wantFreezes)
+			}
+			if !streak.LastDate.Equal(streakDay(tt.days[len(tt.days)-1])) {
+				t.Fatalf("last date: got %s", streak.LastDate)
+			}
+		})
+	}
+}
+
+func TestStreak_RecordIgnoresPastDates(t *testing.T) {
+	streak := NewStreak("user-1", StreakKindCheckIn)
+	for _, day := range []int{1, 2, 4} {
+		streak.Record(streakDay(day), streakDay(day))
+	}
+	want := streak
+
+	for _, day := range []int{4, 3, 1} {
+		if streak.Record(streakDay(day), streakDay(5)) {
+			t.Fatalf("record day %d: got changed", day)
+		}
+	}
+	if streak != want {
+		t.Fatalf("streak: got %+v, want %+v", streak, want)
+	}
+}
+
+func TestStreak_CurrentOn(t *testing.T) {
+	streak := NewStreak("user-1", StreakKindCheckIn)
+	for _, day := range []int{1, 2, 3} {
+		streak.Record(streakDay(day), streakDay(day))
+	}
+
+	tests := []struct {
+		name string
+		day  int
+		want int
+	}{
+		{name: "last day", day: 3, want: 3},
+		{name: "next day", day: 4, want: 3},
+		{name: "missed days the freezes cover", day: 6, want: 3},
+		{name: "missed days beyond the freezes", day: 7, want: 0},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := streak.CurrentOn(streakDay(tt.day)); got != tt.want {
+				t.Fatalf("current on day %d: got %d, want %d", tt.day, got, tt.want)
+			}
+		})
+	}
+
+	if got := NewStreak("user-1", StreakKindCheckIn).CurrentOn(streakDay(1)); got != 0 {
+		t.Fatalf("new streak: got %d, want 0", got)
+	}
+}
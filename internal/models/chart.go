@@ -0,0 +1,48 @@
+package models
+
+import "time"
+
+type ChartPeriod string
+
+const (
+	ChartPeriodWeek  ChartPeriod = "week"
+	ChartPeriodMonth ChartPeriod = "month"
+	ChartPeriodYear  ChartPeriod = "year"
+)
+
+var (
+	ErrInvalidChartPeriod = "Period must be week, month or year"
+)
+
+func (p ChartPeriod) IsValid() bool {
+	switch p {
+	case ChartPeriodWeek, ChartPeriodMonth, ChartPeriodYear:
+		return true
+	}
+
+	return false
+}
+
+type ChartBucket struct {
+	Start           time.Time     `json:"start"`
+	DayQuality      float64       `json:"dayQuality"`
+	RatedDays       int           `json:"ratedDays"`
+	FocusedDuration time.Duration `json:"focusedDuration"`
+}
+
+type ChartData struct {
+	Period  ChartPeriod   `json:"period"`
+	Buckets []ChartBucket `json:"buckets"`
+	// Index 0 holds the sessions rated 1.
+	SessionQuality [10]int `json:"sessionQuality"`
+}
+
+func (d ChartData) IsEmpty() bool {
+	for _, bucket := range d.Buckets {
+		if bucket.RatedDays > 0 || bucket.FocusedDuration > 0 {
+			return false
+		}
+	}
+
+	return true
+}
@@ -2,34 +2,40 @@ package models
 
 import (
 	"attune/pkg/apperrors"
+	"attune/pkg/clock"
+	"github.com/google/uuid"
 	"time"
 )
 
 type DayRecord struct {
 	ID        string    `json:"id"`
 	UserID    string    `json:"userId"`
+	LocalDate time.Time `json:"localDate"`
 	Quality   int       `json:"quality"`
-	Mood      string    `json:"mood"`
+	Mood      Mood      `json:"mood"`
 	CreatedAt time.Time `json:"createdAt"`
 	UpdatedAt time.Time `json:"updatedAt"`
 }
 
 func NewDayRecord(
 	userID string,
+	localDate time.Time,
 	quality int,
-	mood string,
+	mood Mood,
+	clk clock.Clock,
 ) (DayRecord, error) {
 	if quality < 0 || quality > 10 {
 		return DayRecord{}, apperrors.NewBadRequest().WithDescription("quality must be between 0 and 10")
 	}
-	if len(mood) > 255 {
-		return DayRecord{}, apperrors.NewBadRequest().WithDescription("mood is too long")
+	if err := mood.Validate(); err != nil {
+		return DayRecord{}, err
 	}
 
-	now := time.Now()
+	now := clk.Now()
 	return DayRecord{
-		ID:        userID,
+		ID:        uuid.NewString(),
 		UserID:    userID,
+		LocalDate: localDate,
 		Quality:   quality,
 		Mood:      mood,
 		CreatedAt: now,
@@ -47,12 +53,20 @@ func (dr *DayRecord) UpdateQuality(quality int) error {
 	return nil
 }
 
-func (dr *DayRecord) UpdateMood(mood string) error {
-	if len(mood) > 255 {
-		return apperrors.NewBadRequest().WithDescription("mood is too long")
+func (dr *DayRecord) UpdateMood(mood Mood) error {
+	if err := mood.Validate(); err != nil {
+		return err
 	}
 
 	dr.Mood = mood
 	dr.UpdatedAt = time.Now()
 	return nil
 }
+
+// LocalDate is the calendar day of t in loc, as midnight UTC so it compares and stores
+// as a plain date.
+func LocalDate(t time.Time, loc *time.Location) time.Time {
+	year, month, day := t.In(loc).Date()
+
+	return time.Date(year, month, day, 0, 0, 0, 0, time.UTC)
+}
@@ -2,8 +2,11 @@ package models
 
 import (
 	"attune/pkg/apperrors"
+	"attune/pkg/clock"
 	"github.com/google/uuid"
+	"strings"
 	"time"
+	"unicode/utf8"
 )
 
 type FocusSessionStatus string
@@ -12,44 +15,92 @@ const (
 	FocusSessionStatusActive    FocusSessionStatus = "active"
 	FocusSessionStatusCompleted FocusSessionStatus = "completed"
 	FocusSessionStatusStopped   FocusSessionStatus = "stopped"
+	// Abandoned sessions were stopped by the system, e.g. after a too long pause.
+	FocusSessionStatusAbandoned FocusSessionStatus = "abandoned"
 )
 
 var (
 	ErrInvalidDuration = "Duration must be between 1 minute and 24 hours"
 	ErrInvalidQuality  = "Invalid quality value, must be between 0 and 10"
+	ErrInvalidExtend   = "Extension must be between 1 minute and 24 hours"
+	ErrNoteTooLong     = "Note is too long"
 )
 
+const FocusSessionNoteMaxLength = 1000
+
 type FocusSession struct {
-	ID        string             `json:"id"`
-	UserID    string             `json:"userId"`
-	VendorID  string             `json:"vendorId"`
-	Status    FocusSessionStatus `json:"status"`
-	Quality   int                `json:"quality"`
-	StartedAt time.Time          `json:"startedAt"`
-	EndedAt   time.Time          `json:"endedAt"`
-	CreatedAt time.Time          `json:"createdAt"`
-	UpdatedAt time.Time          `json:"updatedAt"`
+	ID              string             `json:"id"`
+	UserID          string             `json:"userId"`
+	VendorID        string             `json:"vendorId"`
+	LabelID         string             `json:"labelId,omitempty"`
+	RoomID          string             `json:"roomId,omitempty"`
+	Status          FocusSessionStatus `json:"status"`
+	Quality         int                `json:"quality"`
+	Note            string             `json:"note,omitempty"`
+	Mode            FocusSessionMode   `json:"mode"`
+	Pomodoro        *PomodoroProgress  `json:"pomodoro,omitempty"`
+	PlannedDuration time.Duration      `json:"plannedDuration"`
+	Remaining       time.Duration      `json:"remaining"`
+	Paused          bool               `json:"paused"`
+	PausedAt        time.Time          `json:"pausedAt"`
+	PausedDuration  time.Duration      `json:"pausedDuration"`
+	FocusedDuration time.Duration      `json:"focusedDuration"`
+	RestDuration    time.Duration      `json:"restDuration"`
+	LastStartedAt   time.Time          `json:"lastStartedAt"`
+	StartedAt       time.Time          `json:"startedAt"`
+	EndedAt         time.Time          `json:"endedAt"`
+	CreatedAt       time.Time          `json:"createdAt"`
+	UpdatedAt       time.Time          `json:"updatedAt"`
 }
 
 func NewFocusSession(
 	userID string,
-	Duration time.Duration,
+	duration time.Duration,
+	clk clock.Clock,
 ) (FocusSession, error) {
-	if Duration <= 0 || Duration > time.Hour*24 || Duration < time.Minute {
+	if duration <= 0 || duration > time.Hour*24 || duration < time.Minute {
 		return FocusSession{}, apperrors.NewBadRequest().WithDescription(ErrInvalidDuration)
 	}
 
-	now := time.Now()
+	now := clk.Now()
 	return FocusSession{
-		ID:        uuid.NewString(),
-		UserID:    userID,
-		Status:    FocusSessionStatusActive,
-		StartedAt: now,
-		CreatedAt: now,
-		UpdatedAt: now,
+		ID:              uuid.NewString(),
+		UserID:          userID,
+		Status:          FocusSessionStatusActive,
+		Mode:            FocusSessionModeSingle,
+		PlannedDuration: duration,
+		Remaining:       duration,
+		LastStartedAt:   now,
+		StartedAt:       now,
+		CreatedAt:       now,
+		UpdatedAt:       now,
 	}, nil
 }
 
+func NewBreakSession(userID string, duration time.Duration, clk clock.Clock) (FocusSession, error) {
+	session, err := NewFocusSession(userID, duration, clk)
+	if err != nil {
+		return FocusSession{}, err
+	}
+	session.Mode = FocusSessionModeBreak
+
+	return session, nil
+}
+
+func (fs *FocusSession) IsBreak() bool {
+	return fs.Mode == FocusSessionModeBreak
+}
+
+func (fs *FocusSession) UpdateNote(note string) error {
+	note = strings.TrimSpace(note)
+	if utf8.RuneCountInString(note) > FocusSessionNoteMaxLength {
+		return apperrors.NewBadRequest().WithDescription(ErrNoteTooLong)
+	}
+
+	fs.Note = note
+	return nil
+}
+
 func (fs *FocusSession) UpdateQuality(quality int) error {
 	if quality < 0 || quality > 10 {
 		return apperrors.NewBadRequest().WithDescription(ErrInvalidQuality)
@@ -60,3 +111,82 @@ func (fs *FocusSession) UpdateQuality(quality int) error {
 
 	return nil
 }
+
+func (fs *FocusSession) Pause(at time.Time) {
+	fs.Paused = true
+	fs.PausedAt = at
+}
+
+func (fs *FocusSession) Resume(at time.Time) {
+	if fs.Paused && at.After(fs.PausedAt) {
+		fs.PausedDuration += at.Sub(fs.PausedAt)
+	}
+
+	fs.Paused = false
+	fs.PausedAt = time.Time{}
+	fs.LastStartedAt = at
+}
+
+// Extending a break does not change the planned focus time.
+func (fs *FocusSession) Extend(delta time.Duration) error {
+	if delta < time.Minute || delta > time.Hour*24 {
+		return apperrors.NewBadRequest().WithDescription(ErrInvalidExtend)
+	}
+
+	if fs.Pomodoro != nil {
+		fs.Pomodoro.PhaseDuration += delta
+		if fs.Pomodoro.IsBreak() {
+			return nil
+		}
+	}
+	fs.PlannedDuration += delta
+
+	return nil
+}
+
+func (fs *FocusSession) Reopen(remaining time.Duration, at time.Time) {
+	fs.Status = FocusSessionStatusActive
+	fs.Remaining = remaining
+	fs.Paused = false
+	fs.PausedAt = time.Time{}
+	fs.FocusedDuration = 0
+	fs.LastStartedAt = at
+	fs.EndedAt = time.Time{}
+	fs.UpdatedAt = at
+}
+
+// Finish derives the focused time from the planned duration and the time left on the
+// timer. A break records that time as rest instead.
+func (fs *FocusSession) Finish(status FocusSessionStatus, remaining time.Duration, at time.Time) {
+	if fs.Paused {
+		fs.Resume(at)
+	}
+
+	fs.Status = status
+	fs.Remaining = remaining
+	if fs.Pomodoro != nil {
+		fs.FocusedDuration = fs.Pomodoro.FocusedDuration(remaining)
+	} else {
+		fs.FocusedDuration = fs.PlannedDuration - remaining
+	}
+	if fs.FocusedDuration < 0 {
+		fs.FocusedDuration = 0
+	}
+	if fs.IsBreak() {
+		fs.RestDuration, fs.FocusedDuration = fs.FocusedDuration, 0
+	}
+	fs.EndedAt = at
+}
+
+type WorkRestBalance struct {
+	Focused time.Duration `json:"focused"`
+	Rest    time.Duration `json:"rest"`
+}
+
+func (b WorkRestBalance) Ratio() float64 {
+	if b.Rest <= 0 {
+		return 0
+	}
+
+	return float64(b.Focused) / float64(b.Rest)
+}
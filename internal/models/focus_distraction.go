@@ -0,0 +1,59 @@
+package models
+
+import (
+	"attune/pkg/apperrors"
+	"attune/pkg/clock"
+	"github.com/google/uuid"
+	"strings"
+	"time"
+	"unicode/utf8"
+)
+
+const FocusDistractionReasonMaxLength = 64
+
+var (
+	ErrInvalidDistractionReason = "Reason must be at most 64 characters"
+)
+
+type FocusDistraction struct {
+	ID        string `json:"id"`
+	SessionID string `json:"sessionId"`
+	UserID    string `json:"userId"`
+	// Stored lowercased so the same reason is counted together.
+	Reason    string    `json:"reason"`
+	CreatedAt time.Time `json:"createdAt"`
+}
+
+func NewFocusDistraction(sessionID, userID, reason string, clk clock.Clock) (FocusDistraction, error) {
+	reason = strings.ToLower(strings.Join(strings.Fields(reason), " "))
+	if utf8.RuneCountInString(reason) > FocusDistractionReasonMaxLength {
+		return FocusDistraction{}, apperrors.NewBadRequest().WithDescription(ErrInvalidDistractionReason)
+	}
+
+	return FocusDistraction{
+		ID:        uuid.NewString(),
+		SessionID: sessionID,
+		UserID:    userID,
+		Reason:    reason,
+		CreatedAt: clk.Now(),
+	}, nil
+}
+
+type FocusDistractionReason struct {
+	Reason string `json:"reason"`
+	Count  int    `json:"count"`
+}
+
+type FocusDistractionQuality struct {
+	Distractions   int     `json:"distractions"`
+	Sessions       int     `json:"sessions"`
+	RatedSessions  int     `json:"ratedSessions"`
+	AverageQuality float64 `json:"averageQuality"`
+}
+
+type FocusDistractionStats struct {
+	Sessions     int                       `json:"sessions"`
+	Distractions int                       `json:"distractions"`
+	TopReasons   []FocusDistractionReason  `json:"topReasons"`
+	Quality      []FocusDistractionQuality `json:"quality"`
+}
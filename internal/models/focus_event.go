@@ -0,0 +1,87 @@
+package models
+
+import (
+	"attune/pkg/apperrors"
+	"github.com/google/uuid"
+	"time"
+)
+
+type FocusSessionEventType string
+
+const (
+	FocusSessionEventTypeStart    FocusSessionEventType = "start"
+	FocusSessionEventTypePause    FocusSessionEventType = "pause"
+	FocusSessionEventTypeResume   FocusSessionEventType = "resume"
+	FocusSessionEventTypeStop     FocusSessionEventType = "stop"
+	FocusSessionEventTypeComplete FocusSessionEventType = "complete"
+	FocusSessionEventTypeExtend   FocusSessionEventType = "extend"
+	FocusSessionEventTypeAbandon  FocusSessionEventType = "abandon"
+
+	FocusSessionEventTypeBreakStart FocusSessionEventType = "break_start"
+	FocusSessionEventTypeBreakEnd   FocusSessionEventType = "break_end"
+	FocusSessionEventTypeSkipBreak  FocusSessionEventType = "skip_break"
+)
+
+type FocusSessionEventSource string
+
+const (
+	FocusSessionEventSourceTelegram FocusSessionEventSource = "telegram"
+	FocusSessionEventSourceHTTP     FocusSessionEventSource = "http"
+	FocusSessionEventSourceSystem   FocusSessionEventSource = "system"
+)
+
+var (
+	ErrInvalidEventType   = "Invalid focus session event type"
+	ErrInvalidEventSource = "Invalid focus session event source"
+)
+
+type FocusSessionEvent struct {
+	ID         string                  `json:"id"`
+	SessionID  string                  `json:"sessionId"`
+	UserID     string                  `json:"userId"`
+	Type       FocusSessionEventType   `json:"type"`
+	Source     FocusSessionEventSource `json:"source"`
+	OccurredAt time.Time               `json:"occurredAt"`
+	CreatedAt  time.Time               `json:"createdAt"`
+}
+
+func NewFocusSessionEvent(
+	sessionID string,
+	userID string,
+	eventType FocusSessionEventType,
+	source FocusSessionEventSource,
+	occurredAt time.Time,
+) (FocusSessionEvent, error) {
+	switch eventType {
+	case FocusSessionEventTypeStart,
+		FocusSessionEventTypePause,
+		FocusSessionEventTypeResume,
+		FocusSessionEventTypeStop,
+		FocusSessionEventTypeComplete,
+		FocusSessionEventTypeExtend,
+		FocusSessionEventTypeAbandon,
+		FocusSessionEventTypeBreakStart,
+		FocusSessionEventTypeBreakEnd,
+		FocusSessionEventTypeSkipBreak:
+	default:
+		return FocusSessionEvent{}, apperrors.NewBadRequest().WithDescription(ErrInvalidEventType)
+	}
+
+	switch source {
+	case FocusSessionEventSourceTelegram,
+		FocusSessionEventSourceHTTP,
+		FocusSessionEventSourceSystem:
+	default:
+		return FocusSessionEvent{}, apperrors.NewBadRequest().WithDescription(ErrInvalidEventSource)
+	}
+
+	return FocusSessionEvent{
+		ID:         uuid.NewString(),
+		SessionID:  sessionID,
+		UserID:     userID,
+		Type:       eventType,
+		Source:     source,
+		OccurredAt: occurredAt,
+		CreatedAt:  time.Now(),
+	}, nil
+}
@@ -0,0 +1,50 @@
+package models
+
+import (
+	"attune/pkg/apperrors"
+	"attune/pkg/clock"
+	"github.com/google/uuid"
+	"strings"
+	"time"
+	"unicode/utf8"
+)
+
+const FocusLabelMaxLength = 32
+
+var (
+	ErrInvalidLabel = "Label must be between 1 and 32 characters"
+)
+
+type FocusLabel struct {
+	ID         string    `json:"id"`
+	UserID     string    `json:"userId"`
+	Name       string    `json:"name"`
+	LastUsedAt time.Time `json:"lastUsedAt"`
+	CreatedAt  time.Time `json:"createdAt"`
+	UpdatedAt  time.Time `json:"updatedAt"`
+}
+
+func NewFocusLabel(userID, name string, clk clock.Clock) (FocusLabel, error) {
+	name = strings.Join(strings.Fields(name), " ")
+	if name == "" || utf8.RuneCountInString(name) > FocusLabelMaxLength {
+		return FocusLabel{}, apperrors.NewBadRequest().WithDescription(ErrInvalidLabel)
+	}
+
+	now := clk.Now()
+	return FocusLabel{
+		ID:         uuid.NewString(),
+		UserID:     userID,
+		Name:       name,
+		LastUsedAt: now,
+		CreatedAt:  now,
+		UpdatedAt:  now,
+	}, nil
+}
+
+type FocusLabelStats struct {
+	LabelID         string        `json:"labelId"`
+	Name            string        `json:"name"`
+	Sessions        int64         `json:"sessions"`
+	FocusedDuration time.Duration `json:"focusedDuration"`
+	AverageQuality  float64       `json:"averageQuality"`
+}
@@ -0,0 +1,64 @@
+package models
+
+import (
+	"attune/pkg/apperrors"
+	"attune/pkg/clock"
+	"github.com/google/uuid"
+	"time"
+)
+
+type FocusRoomStatus string
+
+const (
+	FocusRoomStatusOpen      FocusRoomStatus = "open"
+	FocusRoomStatusRunning   FocusRoomStatus = "running"
+	FocusRoomStatusFinished  FocusRoomStatus = "finished"
+	FocusRoomStatusCancelled FocusRoomStatus = "cancelled"
+)
+
+var (
+	ErrRoomNotOpen   = "This room has already started or was cancelled"
+	ErrRoomNotHost   = "Only the member who opened the room can do this"
+	ErrRoomNotJoined = "Join the room first"
+	ErrRoomFull      = "This room is full"
+)
+
+const FocusRoomMaxParticipants = 20
+
+type FocusRoom struct {
+	ID               string          `json:"id"`
+	ChatID           string          `json:"chatId"`
+	HostUserID       string          `json:"hostUserId"`
+	Duration         time.Duration   `json:"duration"`
+	Status           FocusRoomStatus `json:"status"`
+	SummaryMessageID string          `json:"summaryMessageId"`
+	StartedAt        time.Time       `json:"startedAt"`
+	CreatedAt        time.Time       `json:"createdAt"`
+	UpdatedAt        time.Time       `json:"updatedAt"`
+}
+
+func NewFocusRoom(chatID, hostUserID string, duration time.Duration, clk clock.Clock) (FocusRoom, error) {
+	if duration < time.Minute || duration > time.Hour*24 {
+		return FocusRoom{}, apperrors.NewBadRequest().WithDescription(ErrInvalidDuration)
+	}
+
+	now := clk.Now()
+	return FocusRoom{
+		ID:         uuid.NewString(),
+		ChatID:     chatID,
+		HostUserID: hostUserID,
+		Duration:   duration,
+		Status:     FocusRoomStatusOpen,
+		CreatedAt:  now,
+		UpdatedAt:  now,
+	}, nil
+}
+
+type FocusRoomParticipant struct {
+	RoomID   string        `json:"roomId"`
+	UserID   string        `json:"userId"`
+	VendorID string        `json:"vendorId"`
+	Name     string        `json:"name"`
+	JoinedAt time.Time     `json:"joinedAt"`
+	Session  *FocusSession `json:"session,omitempty"`
+}
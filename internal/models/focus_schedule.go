@@ -0,0 +1,173 @@
+package models
+
+import (
+	"attune/pkg/apperrors"
+	"attune/pkg/clock"
+	"github.com/google/uuid"
+	"time"
+)
+
+type FocusScheduleRule string
+
+const (
+	FocusScheduleRuleOnce     FocusScheduleRule = "once"
+	FocusScheduleRuleDaily    FocusScheduleRule = "daily"
+	FocusScheduleRuleWeekdays FocusScheduleRule = "weekdays"
+	FocusScheduleRuleWeekends FocusScheduleRule = "weekends"
+	FocusScheduleRuleWeekly   FocusScheduleRule = "weekly"
+)
+
+const FocusScheduleTimeLayout = "15:04"
+
+var (
+	ErrInvalidScheduleRule     = "Invalid schedule rule, use once, daily, weekdays, weekends or weekly"
+	ErrInvalidScheduleTime     = "Invalid start time, use HH:MM (e.g. 09:00)"
+	ErrInvalidScheduleTimezone = "Invalid schedule timezone"
+	ErrScheduleInPast          = "The scheduled time is already in the past"
+)
+
+type FocusSchedule struct {
+	ID        string            `json:"id"`
+	UserID    string            `json:"userId"`
+	VendorID  string            `json:"vendorId"`
+	Rule      FocusScheduleRule `json:"rule"`
+	StartTime string            `json:"startTime"`
+	Weekday   time.Weekday      `json:"weekday"`
+	Duration  time.Duration     `json:"duration"`
+	Timezone  string            `json:"timezone"`
+	// NextRunAt keeps the last run once the schedule is inactive.
+	NextRunAt time.Time `json:"nextRunAt"`
+	Active    bool      `json:"active"`
+	CreatedAt time.Time `json:"createdAt"`
+	UpdatedAt time.Time `json:"updatedAt"`
+}
+
+func NewFocusSchedule(
+	userID string,
+	rule FocusScheduleRule,
+	date time.Time,
+	startTime string,
+	duration time.Duration,
+	timezone string,
+	clk clock.Clock,
+) (FocusSchedule, error) {
+	switch rule {
+	case FocusScheduleRuleOnce,
+		FocusScheduleRuleDaily,
+		FocusScheduleRuleWeekdays,
+		FocusScheduleRuleWeekends,
+		FocusScheduleRuleWeekly:
+	default:
+		return FocusSchedule{}, apperrors.NewBadRequest().WithDescription(ErrInvalidScheduleRule)
+	}
+	if duration < time.Minute || duration > time.Hour*24 {
+		return FocusSchedule{}, apperrors.NewBadRequest().WithDescription(ErrInvalidDuration)
+	}
+	at, err := time.Parse(FocusScheduleTimeLayout, startTime)
+	if err != nil {
+		return FocusSchedule{}, apperrors.NewBadRequest().WithDescription(ErrInvalidScheduleTime)
+	}
+	loc, err := time.LoadLocation(timezone)
+	if err != nil {
+		return FocusSchedule{}, apperrors.NewBadRequest().WithDescription(ErrInvalidScheduleTimezone)
+	}
+
+	now := clk.Now()
+	schedule := FocusSchedule{
+		ID:        uuid.NewString(),
+		UserID:    userID,
+		Rule:      rule,
+		StartTime: at.Format(FocusScheduleTimeLayout),
+		Duration:  duration,
+		Timezone:  loc.String(),
+		Active:    true,
+		CreatedAt: now,
+		UpdatedAt: now,
+	}
+
+	switch {
+	case !date.IsZero():
+		schedule.NextRunAt = time.Date(date.Year(), date.Month(), date.Day(), at.Hour(), at.Minute(), 0, 0, loc)
+		if !schedule.NextRunAt.After(now) {
+			return FocusSchedule{}, apperrors.NewBadRequest().WithDescription(ErrScheduleInPast)
+		}
+		schedule.Weekday = schedule.NextRunAt.Weekday()
+	case rule == FocusScheduleRuleOnce || rule == FocusScheduleRuleWeekly:
+		schedule.NextRunAt = schedule.nextOccurrence(now, func(time.Weekday) bool { return true })
+		schedule.Weekday = schedule.NextRunAt.Weekday()
+	default:
+		schedule.NextRunAt, _ = schedule.Next(now)
+	}
+
+	return schedule, nil
+}
+
+func (fs *FocusSchedule) Next(after time.Time) (time.Time, bool) {
+	var runsOn func(time.Weekday) bool
+	switch fs.Rule {
+	case FocusScheduleRuleDaily:
+		runsOn = func(time.Weekday) bool { return true }
+	case FocusScheduleRuleWeekdays:
+		runsOn = func(d time.Weekday) bool { return d != time.Saturday && d != time.Sunday }
+	case FocusScheduleRuleWeekends:
+		runsOn = func(d time.Weekday) bool { return d == time.Saturday || d == time.Sunday }
+	case FocusScheduleRuleWeekly:
+		runsOn = func(d time.Weekday) bool { return d == fs.Weekday }
+	default:
+		return time.Time{}, false
+	}
+
+	return fs.nextOccurrence(after, runsOn), true
+}
+
+func (fs *FocusSchedule) Advance(now time.Time) {
+	next, ok := fs.Next(now)
+	if ok {
+		fs.NextRunAt = next
+	} else {
+		fs.Active = false
+	}
+	fs.UpdatedAt = now
+}
+
+func (fs *FocusSchedule) nextOccurrence(after time.Time, runsOn func(time.Weekday) bool) time.Time {
+	loc, err := time.LoadLocation(fs.Timezone)
+	if err != nil {
+		loc = time.UTC
+	}
+	at, _ := time.Parse(FocusScheduleTimeLayout, fs.StartTime)
+
+	local := after.In(loc)
+	for i := 0; i <= 7; i++ {
+		day := local.AddDate(0, 0, i)
+		candidate := time.Date(day.Year(), day.Month(), day.Day(), at.Hour(), at.Minute(), 0, 0, loc)
+		if candidate.After(after) && runsOn(candidate.Weekday()) {
+			return candidate
+		}
+	}
+
+	return time.Time{}
+}
+
+func NewSnoozedFocusSchedule(schedule FocusSchedule, delay time.Duration, clk clock.Clock) FocusSchedule {
+	now := clk.Now()
+	loc, err := time.LoadLocation(schedule.Timezone)
+	if err != nil {
+		loc = time.UTC
+	}
+	runAt := now.Add(delay).Truncate(time.Second).In(loc)
+
+	return FocusSchedule{
+		ID:        uuid.NewString(),
+		UserID:    schedule.UserID,
+		Rule:      FocusScheduleRuleOnce,
+		StartTime: runAt.Format(FocusScheduleTimeLayout),
+		Weekday:   runAt.Weekday(),
+		Duration:  schedule.Duration,
+		Timezone:  schedule.Timezone,
+		NextRunAt: runAt,
+		Active:    true,
+		CreatedAt: now,
+		UpdatedAt: now,
+	}
+}
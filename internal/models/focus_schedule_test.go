@@ -0,0 +1,209 @@
+package models
+
+import (
+	"testing"
+	"time"
+
+	"attune/pkg/apperrors"
+	"attune/pkg/clock"
+)
+
+const scheduleTimezone = "America/New_York"
+
+func scheduleLocation(t *testing.T) *time.Location {
+	t.Helper()
+
+	loc, err := time.LoadLocation(scheduleTimezone)
+	if err != nil {
+		t.Skipf("timezone %s unavailable: %v", scheduleTimezone, err)
+	}
+	return loc
+}
+
+func TestFocusSchedule_Next(t *testing.T) {
+	loc := scheduleLocation(t)
+	at := func(month time.Month, day, hour, minute int) time.Time {
+		return time.Date(2026, month, day, hour, minute, 0, 0, loc)
+	}
+
+	tests := []struct {
+		name    string
+		rule    FocusScheduleRule
+		weekday time.Weekday
+		after   time.Time
+		want    time.Time
+	}{
+		{
+			name:  "daily later today",
+			rule:  FocusScheduleRuleDaily,
+			after: at(time.October, 14, 8, 0),
+			want:  at(time.October, 14, 9, 0),
+		},
+		{
+			// A run that is due right now is not the next one.
+			name:  "daily at the start time",
+			rule:  FocusScheduleRuleDaily,
+			after: at(time.October, 14, 9, 0),
+			want:  at(time.October, 15, 9, 0),
+		},
+		{
+			// Clocks spring forward on Sunday 8 March; the run stays at 09:00 local time.
+			name:  "daily into summer time",
+			rule:  FocusScheduleRuleDaily,
+			after: at(time.March, 7, 10, 0),
+			want:  time.Date(2026, time.March, 8, 13, 0, 0, 0, time.UTC),
+		},
+		{
+			// Clocks fall back on Sunday 1 November.
+			name:  "daily into winter time",
+			rule:  FocusScheduleRuleDaily,
+			after: at(time.October, 31, 10, 0),
+			want:  time.Date(2026, time.November, 1, 14, 0, 0, 0, time.UTC),
+		},
+		{
+			name:  "weekdays on a weekday",
+			rule:  FocusScheduleRuleWeekdays,
+			after: at(time.October, 16, 8, 0),
+			want:  at(time.October, 16, 9, 0),
+		},
+		{
+			name:  "weekdays skip the weekend",
+			rule:  FocusScheduleRuleWeekdays,
+			after: at(time.October, 16, 10, 0),
+			want:  at(time.October, 19, 9, 0),
+		},
+		{
+			name:  "weekends skip the week",
+			rule:  FocusScheduleRuleWeekends,
+			after: at(time.October, 12, 10, 0),
+			want:  at(time.October, 17, 9, 0),
+		},
+		{
+			name:  "weekends from Saturday to Sunday",
+			rule:  FocusScheduleRuleWeekends,
+			after: at(time.October, 17, 10, 0),
+			want:  at(time.October, 18, 9, 0),
+		},
+		{
+			name:    "weekly later this week",
+			rule:    FocusScheduleRuleWeekly,
+			weekday: time.Friday,
+			after:   at(time.October, 12, 10, 0),
+			want:    at(time.October, 16, 9, 0),
+		},
+		{
+			name:    "weekly a week after the run",
+			rule:    FocusScheduleRuleWeekly,
+			weekday: time.Wednesday,
+			after:   at(time.October, 14, 9, 0),
+			want:    at(time.October, 21, 9, 0),
+		},
+		{
+			// The week from Wednesday 28 October crosses the switch to winter time.
+			name:    "weekly across the time change",
+			rule:    FocusScheduleRuleWeekly,
+			weekday: time.Wednesday,
+			after:   at(time.October, 28, 9, 30),
+			want:    time.Date(2026, time.November, 4, 14, 0, 0, 0, time.UTC),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			schedule := FocusSchedule{
+				Rule:      tt.rule,
+				StartTime: "09:00",
+				Weekday:   tt.weekday,
+				Timezone:  scheduleTimezone,
+			}
+
+			got, ok := schedule.Next(tt.after)
+			if !ok {
+				t.Fatal("next: got none")
+			}
+			if !got.Equal(tt.want) {
+				t.Fatalf("next: got %s, want %s", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestFocusSchedule_AdvanceOnce(t *testing.T) {
+	loc := scheduleLocation(t)
+	runAt := time.Date(2026, time.October, 14, 9, 0, 0, 0, loc)
+	schedule := FocusSchedule{
+		Rule:      FocusScheduleRuleOnce,
+		StartTime: "09:00",
+		Timezone:  scheduleTimezone,
+		NextRunAt: runAt,
+		Active:    true,
+	}
+
+	if _, ok := schedule.Next(runAt); ok {
+		t.Fatal("next: got a run for a one-off schedule")
+	}
+	schedule.Advance(runAt)
+	if schedule.Active || !schedule.NextRunAt.Equal(runAt) {
+		t.Fatalf("advanced schedule: active %v, next run %s", schedule.Active, schedule.NextRunAt)
+	}
+}
+
+func TestNewFocusSchedule(t *testing.T) {
+	loc := scheduleLocation(t)
+	// Wednesday 14 October, 10:00 in New York.
+	clk := clock.NewFakeClock(time.Date(2026, time.October, 14, 10, 0, 0, 0, loc))
+
+	tests := []struct {
+		name        string
+		rule        FocusScheduleRule
+		date        time.Time
+		want        time.Time
+		wantWeekday time.Weekday
+	}{
+		{
+			name:        "once rolls over to tomorrow",
+			rule:        FocusScheduleRuleOnce,
+			want:        time.Date(2026, time.October, 15, 9, 0, 0, 0, loc),
+			wantWeekday: time.Thursday,
+		},
+		{
+			name:        "once on a date",
+			rule:        FocusScheduleRuleOnce,
+			date:        time.Date(2026, time.November, 2, 0, 0, 0, 0, time.UTC),
+			want:        time.Date(2026, time.November, 2, 9, 0, 0, 0, loc),
+			wantWeekday: time.Monday,
+		},
+		{
+			name:        "weekly runs on the weekday of its first run",
+			rule:        FocusScheduleRuleWeekly,
+			want:        time.Date(2026, time.October, 15, 9, 0, 0, 0, loc),
+			wantWeekday: time.Thursday,
+		},
+		{
+			name: "weekdays",
+			rule: FocusScheduleRuleWeekdays,
+			want: time.Date(2026, time.October, 15, 9, 0, 0, 0, loc),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			schedule, err := NewFocusSchedule("user-1", tt.rule, tt.date, "09:00", 25*time.Minute, scheduleTimezone, clk)
+			if err != nil {
+				t.Fatalf("new schedule: %v", err)
+			}
+			if !schedule.NextRunAt.Equal(tt.want) {
+				t.Fatalf("next run: got %s, want %s", schedule.NextRunAt, tt.want)
+			}
+			if schedule.Weekday != tt.wantWeekday {
+				t.Fatalf("weekday: got %s, want %s", schedule.Weekday, tt.wantWeekday)
+			}
+		})
+	}
+
+	_, err := NewFocusSchedule("user-1", FocusScheduleRuleOnce, time.Date(2026, time.October, 14, 0, 0, 0, 0, time.UTC),
+		"09:00", 25*time.Minute, scheduleTimezone, clk)
+	if !apperrors.IsCode(err, apperrors.BadRequest) {
+		t.Fatalf("date in the past: got %v, want bad request", err)
+	}
+}
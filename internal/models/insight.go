@@ -0,0 +1,76 @@
+package models
+
+import "time"
+
+type InsightKind string
+
+const (
+	InsightKindFocusDayQuality  InsightKind = "focus_day_quality"
+	InsightKindTimeOfDay        InsightKind = "time_of_day"
+	InsightKindSessionLength    InsightKind = "session_length"
+	InsightKindMoodDistractions InsightKind = "mood_distractions"
+)
+
+type PartOfDay string
+
+const (
+	PartOfDayMorning   PartOfDay = "morning"
+	PartOfDayAfternoon PartOfDay = "afternoon"
+	PartOfDayEvening   PartOfDay = "evening"
+	PartOfDayNight     PartOfDay = "night"
+)
+
+func PartOfDayOf(t time.Time) PartOfDay {
+	switch hour := t.Hour(); {
+	case hour >= 5 && hour < 12:
+		return PartOfDayMorning
+	case hour >= 12 && hour < 17:
+		return PartOfDayAfternoon
+	case hour >= 17 && hour < 22:
+		return PartOfDayEvening
+	}
+
+	return PartOfDayNight
+}
+
+// Joyful and calm emotions add their intensity, sad, fearful and angry ones subtract it.
+func MoodScore(mood Mood) int {
+	var score int
+	for _, emotion := range mood.Emotions {
+		definition, ok := LookupEmotion(emotion.Emotion)
+		if !ok {
+			continue
+		}
+
+		switch definition.Category {
+		case EmotionCategoryJoy, EmotionCategoryCalm:
+			score += emotion.Intensity
+		default:
+			score -= emotion.Intensity
+		}
+	}
+
+	return score
+}
+
+type Insight struct {
+	Kind         InsightKind `json:"kind"`
+	Samples      int         `json:"samples"`
+	Correlation  float64     `json:"correlation"`
+	Best         PartOfDay   `json:"best"`
+	BestAverage  float64     `json:"bestAverage"`
+	Worst        PartOfDay   `json:"worst"`
+	WorstAverage float64     `json:"worstAverage"`
+}
+
+type InsightGap struct {
+	Kind    InsightKind `json:"kind"`
+	Samples int         `json:"samples"`
+	Needed  int         `json:"needed"`
+}
+
+type Insights struct {
+	Window   time.Duration `json:"window"`
+	Insights []Insight     `json:"insights"`
+	Gaps     []InsightGap  `json:"gaps"`
+}
@@ -0,0 +1,51 @@
+package models
+
+import (
+	"attune/pkg/apperrors"
+	"attune/pkg/clock"
+	"github.com/google/uuid"
+	"strings"
+	"time"
+)
+
+var (
+	ErrEmptyJournalEntry = "Journal entry can't be empty"
+)
+
+type JournalEntry struct {
+	ID          string    `json:"id"`
+	UserID      string    `json:"userId"`
+	LocalDate   time.Time `json:"localDate"`
+	Text        string    `json:"text"`
+	DayRecordID string    `json:"dayRecordId"`
+	CreatedAt   time.Time `json:"createdAt"`
+	UpdatedAt   time.Time `json:"updatedAt"`
+}
+
+func NewJournalEntry(userID string, localDate time.Time, text string, clk clock.Clock) (JournalEntry, error) {
+	text = strings.TrimSpace(text)
+	if text == "" {
+		return JournalEntry{}, apperrors.NewBadRequest().WithDescription(ErrEmptyJournalEntry)
+	}
+
+	now := clk.Now()
+	return JournalEntry{
+		ID:        uuid.NewString(),
+		UserID:    userID,
+		LocalDate: localDate,
+		Text:      text,
+		CreatedAt: now,
+		UpdatedAt: now,
+	}, nil
+}
+
+func (e *JournalEntry) UpdateText(text string, clk clock.Clock) error {
+	text = strings.TrimSpace(text)
+	if text == "" {
+		return apperrors.NewBadRequest().WithDescription(ErrEmptyJournalEntry)
+	}
+
+	e.Text = text
+	e.UpdatedAt = clk.Now()
+	return nil
+}
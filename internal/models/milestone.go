@@ -0,0 +1,70 @@
+package models
+
+import (
+	"attune/pkg/apperrors"
+	"strconv"
+	"strings"
+	"time"
+)
+
+type FocusMilestoneKind string
+
+const (
+	FocusMilestoneKindProgress FocusMilestoneKind = "progress"
+	FocusMilestoneKindTimeLeft FocusMilestoneKind = "time_left"
+)
+
+var (
+	ErrInvalidMilestone = "Milestone must be a percentage between 1% and 99% or a duration such as 5m"
+)
+
+type FocusMilestone struct {
+	Kind     FocusMilestoneKind `json:"kind"`
+	Percent  int                `json:"percent"`
+	TimeLeft time.Duration      `json:"timeLeft"`
+}
+
+func ParseFocusMilestone(value string) (FocusMilestone, error) {
+	value = strings.TrimSpace(value)
+
+	if percent, ok := strings.CutSuffix(value, "%"); ok {
+		p, err := strconv.Atoi(percent)
+		if err != nil || p < 1 || p > 99 {
+			return FocusMilestone{}, apperrors.NewBadRequest().WithDescription(ErrInvalidMilestone)
+		}
+
+		return FocusMilestone{Kind: FocusMilestoneKindProgress, Percent: p}, nil
+	}
+
+	timeLeft, err := time.ParseDuration(value)
+	if err != nil || timeLeft <= 0 {
+		return FocusMilestone{}, apperrors.NewBadRequest().WithDescription(ErrInvalidMilestone)
+	}
+
+	return FocusMilestone{Kind: FocusMilestoneKindTimeLeft, TimeLeft: timeLeft}, nil
+}
+
+func ParseFocusMilestones(values []string) ([]FocusMilestone, error) {
+	milestones := make([]FocusMilestone, 0, len(values))
+	for _, value := range values {
+		if strings.TrimSpace(value) == "" {
+			continue
+		}
+
+		milestone, err := ParseFocusMilestone(value)
+		if err != nil {
+			return nil, err
+		}
+		milestones = append(milestones, milestone)
+	}
+
+	return milestones, nil
+}
+
+func (m FocusMilestone) RemainingAt(length time.Duration) time.Duration {
+	if m.Kind == FocusMilestoneKindProgress {
+		return length * time.Duration(100-m.Percent) / 100
+	}
+
+	return m.TimeLeft
+}
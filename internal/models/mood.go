@@ -0,0 +1,145 @@
+package models
+
+import (
+	"attune/pkg/apperrors"
+	"sort"
+	"unicode/utf8"
+)
+
+type Emotion string
+
+type EmotionCategory string
+
+const (
+	EmotionCategoryJoy     EmotionCategory = "joy"
+	EmotionCategoryCalm    EmotionCategory = "calm"
+	EmotionCategorySadness EmotionCategory = "sadness"
+	EmotionCategoryFear    EmotionCategory = "fear"
+	EmotionCategoryAnger   EmotionCategory = "anger"
+)
+
+const (
+	EmotionHappy        Emotion = "happy"
+	EmotionExcited      Emotion = "excited"
+	EmotionGrateful     Emotion = "grateful"
+	EmotionProud        Emotion = "proud"
+	EmotionCalm         Emotion = "calm"
+	EmotionContent      Emotion = "content"
+	EmotionHopeful      Emotion = "hopeful"
+	EmotionSad          Emotion = "sad"
+	EmotionLonely       Emotion = "lonely"
+	EmotionDisappointed Emotion = "disappointed"
+	EmotionTired        Emotion = "tired"
+	EmotionAnxious      Emotion = "anxious"
+	EmotionStressed     Emotion = "stressed"
+	EmotionOverwhelmed  Emotion = "overwhelmed"
+	EmotionAngry        Emotion = "angry"
+	EmotionFrustrated   Emotion = "frustrated"
+	EmotionIrritated    Emotion = "irritated"
+)
+
+const (
+	EmotionIntensityMild     = 1
+	EmotionIntensityModerate = 2
+	EmotionIntensityStrong   = 3
+
+	DayEmotionsMax    = 5
+	MoodNoteMaxLength = 255
+)
+
+var (
+	ErrUnknownEmotion          = "Unknown emotion"
+	ErrInvalidEmotionIntensity = "Intensity must be between 1 and 3"
+	ErrDuplicateEmotion        = "Each emotion can be picked once"
+	ErrTooManyEmotions         = "Pick at most 5 emotions"
+	ErrMoodNoteTooLong         = "Note must be at most 255 characters"
+)
+
+type EmotionDefinition struct {
+	Emotion  Emotion         `json:"emotion"`
+	Category EmotionCategory `json:"category"`
+	Emoji    string          `json:"emoji"`
+}
+
+var Emotions = []EmotionDefinition{
+	{EmotionHappy, EmotionCategoryJoy, "😊"},
+	{EmotionExcited, EmotionCategoryJoy, "🤩"},
+	{EmotionGrateful, EmotionCategoryJoy, "🙏"},
+	{EmotionProud, EmotionCategoryJoy, "💪"},
+	{EmotionCalm, EmotionCategoryCalm, "😌"},
+	{EmotionContent, EmotionCategoryCalm, "🙂"},
+	{EmotionHopeful, EmotionCategoryCalm, "🌱"},
+	{EmotionSad, EmotionCategorySadness, "😢"},
+	{EmotionLonely, EmotionCategorySadness, "🥀"},
+	{EmotionDisappointed, EmotionCategorySadness, "😞"},
+	{EmotionTired, EmotionCategorySadness, "😴"},
+	{EmotionAnxious, EmotionCategoryFear, "😰"},
+	{EmotionStressed, EmotionCategoryFear, "😫"},
+	{EmotionOverwhelmed, EmotionCategoryFear, "🤯"},
+	{EmotionAngry, EmotionCategoryAnger, "😠"},
+	{EmotionFrustrated, EmotionCategoryAnger, "😤"},
+	{EmotionIrritated, EmotionCategoryAnger, "🙄"},
+}
+
+func LookupEmotion(emotion Emotion) (EmotionDefinition, bool) {
+	for _, definition := range Emotions {
+		if definition.Emotion == emotion {
+			return definition, true
+		}
+	}
+
+	return EmotionDefinition{}, false
+}
+
+type DayEmotion struct {
+	Emotion   Emotion `json:"emotion"`
+	Intensity int     `json:"intensity"`
+}
+
+type Mood struct {
+	Emotions []DayEmotion `json:"emotions"`
+	Note     string       `json:"note"`
+}
+
+func (m Mood) Validate() error {
+	if utf8.RuneCountInString(m.Note) > MoodNoteMaxLength {
+		return apperrors.NewBadRequest().WithDescription(ErrMoodNoteTooLong)
+	}
+	if len(m.Emotions) > DayEmotionsMax {
+		return apperrors.NewBadRequest().WithDescription(ErrTooManyEmotions)
+	}
+
+	seen := make(map[Emotion]bool, len(m.Emotions))
+	for _, emotion := range m.Emotions {
+		if _, ok := LookupEmotion(emotion.Emotion); !ok {
+			return apperrors.NewBadRequest().WithDescription(ErrUnknownEmotion)
+		}
+		if emotion.Intensity < EmotionIntensityMild || emotion.Intensity > EmotionIntensityStrong {
+			return apperrors.NewBadRequest().WithDescription(ErrInvalidEmotionIntensity)
+		}
+		if seen[emotion.Emotion] {
+			return apperrors.NewBadRequest().WithDescription(ErrDuplicateEmotion)
+		}
+		seen[emotion.Emotion] = true
+	}
+
+	return nil
+}
+
+type EmotionFrequency struct {
+	Emotion          Emotion         `json:"emotion"`
+	Category         EmotionCategory `json:"category"`
+	Count            int             `json:"count"`
+	AverageIntensity float64         `json:"averageIntensity"`
+}
+
+func SortDayEmotions(emotions []DayEmotion) {
+	order := make(map[Emotion]int, len(Emotions))
+	for i, definition := range Emotions {
+		order[definition.Emotion] = i
+	}
+
+	sort.Slice(emotions, func(i, j int) bool {
+		return order[emotions[i].Emotion] < order[emotions[j].Emotion]
+	})
+}
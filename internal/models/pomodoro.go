@@ -0,0 +1,167 @@
+package models
+
+import (
+	"attune/pkg/apperrors"
+	"attune/pkg/clock"
+	"github.com/google/uuid"
+	"time"
+)
+
+type FocusSessionMode string
+
+const (
+	FocusSessionModeSingle   FocusSessionMode = "single"
+	FocusSessionModePomodoro FocusSessionMode = "pomodoro"
+	// Breaks never count as focus time.
+	FocusSessionModeBreak FocusSessionMode = "break"
+)
+
+type PomodoroPhase string
+
+const (
+	PomodoroPhaseWork       PomodoroPhase = "work"
+	PomodoroPhaseShortBreak PomodoroPhase = "short_break"
+	PomodoroPhaseLongBreak  PomodoroPhase = "long_break"
+)
+
+const (
+	maxPomodoroCycles = 12
+	maxBreakDuration  = 2 * time.Hour
+)
+
+var (
+	ErrInvalidBreakDuration = "Break duration must be between 1 minute and 2 hours"
+	ErrInvalidCycles        = "Number of cycles must be between 1 and 12"
+	ErrInvalidLongBreak     = "Long break interval must be between 1 and the number of cycles"
+)
+
+type PomodoroConfig struct {
+	WorkDuration       time.Duration `json:"workDuration"`
+	ShortBreakDuration time.Duration `json:"shortBreakDuration"`
+	LongBreakDuration  time.Duration `json:"longBreakDuration"`
+	Cycles             int           `json:"cycles"`
+	LongBreakEvery     int           `json:"longBreakEvery"`
+}
+
+func DefaultPomodoroConfig() PomodoroConfig {
+	return PomodoroConfig{
+		WorkDuration:       25 * time.Minute,
+		ShortBreakDuration: 5 * time.Minute,
+		LongBreakDuration:  15 * time.Minute,
+		Cycles:             4,
+		LongBreakEvery:     4,
+	}
+}
+
+func NewPomodoroConfig(
+	work, shortBreak, longBreak time.Duration,
+	cycles, longBreakEvery int,
+) (PomodoroConfig, error) {
+	if work < time.Minute || work > time.Hour*24 {
+		return PomodoroConfig{}, apperrors.NewBadRequest().WithDescription(ErrInvalidDuration)
+	}
+	if shortBreak < time.Minute || shortBreak > maxBreakDuration ||
+		longBreak < time.Minute || longBreak > maxBreakDuration {
+		return PomodoroConfig{}, apperrors.NewBadRequest().WithDescription(ErrInvalidBreakDuration)
+	}
+	if cycles < 1 || cycles > maxPomodoroCycles {
+		return PomodoroConfig{}, apperrors.NewBadRequest().WithDescription(ErrInvalidCycles)
+	}
+	if longBreakEvery < 1 || longBreakEvery > cycles {
+		return PomodoroConfig{}, apperrors.NewBadRequest().WithDescription(ErrInvalidLongBreak)
+	}
+
+	return PomodoroConfig{
+		WorkDuration:       work,
+		ShortBreakDuration: shortBreak,
+		LongBreakDuration:  longBreak,
+		Cycles:             cycles,
+		LongBreakEvery:     longBreakEvery,
+	}, nil
+}
+
+func (c PomodoroConfig) PhaseDuration(phase PomodoroPhase) time.Duration {
+	switch phase {
+	case PomodoroPhaseShortBreak:
+		return c.ShortBreakDuration
+	case PomodoroPhaseLongBreak:
+		return c.LongBreakDuration
+	default:
+		return c.WorkDuration
+	}
+}
+
+type PomodoroProgress struct {
+	Config        PomodoroConfig `json:"config"`
+	Cycle         int            `json:"cycle"`
+	Phase         PomodoroPhase  `json:"phase"`
+	PhaseDuration time.Duration  `json:"phaseDuration"`
+	Focused       time.Duration  `json:"focused"`
+}
+
+func (p *PomodoroProgress) IsBreak() bool {
+	return p.Phase != PomodoroPhaseWork
+}
+
+func (p *PomodoroProgress) Advance() bool {
+	if p.Phase == PomodoroPhaseWork {
+		if p.Cycle >= p.Config.Cycles {
+			return false
+		}
+
+		p.Focused += p.PhaseDuration
+		if p.Cycle%p.Config.LongBreakEvery == 0 {
+			p.Phase = PomodoroPhaseLongBreak
+		} else {
+			p.Phase = PomodoroPhaseShortBreak
+		}
+	} else {
+		p.Cycle++
+		p.Phase = PomodoroPhaseWork
+	}
+
+	p.PhaseDuration = p.Config.PhaseDuration(p.Phase)
+
+	return true
+}
+
+func (p *PomodoroProgress) FocusedDuration(remaining time.Duration) time.Duration {
+	if p.IsBreak() {
+		return p.Focused
+	}
+
+	return p.Focused + p.PhaseDuration - remaining
+}
+
+func NewPomodoroSession(userID string, config PomodoroConfig, clk clock.Clock) (FocusSession, error) {
+	config, err := NewPomodoroConfig(
+		config.WorkDuration,
+		config.ShortBreakDuration,
+		config.LongBreakDuration,
+		config.Cycles,
+		config.LongBreakEvery,
+	)
+	if err != nil {
+		return FocusSession{}, err
+	}
+
+	now := clk.Now()
+	return FocusSession{
+		ID:              uuid.NewString(),
+		UserID:          userID,
+		Status:          FocusSessionStatusActive,
+		Mode:            FocusSessionModePomodoro,
+		PlannedDuration: config.WorkDuration * time.Duration(config.Cycles),
+		Remaining:       config.WorkDuration,
+		Pomodoro: &PomodoroProgress{
+			Config:        config,
+			Cycle:         1,
+			Phase:         PomodoroPhaseWork,
+			PhaseDuration: config.WorkDuration,
+		},
+		LastStartedAt: now,
+		StartedAt:     now,
+		CreatedAt:     now,
+		UpdatedAt:     now,
+	}, nil
+}
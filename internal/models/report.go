@@ -0,0 +1,75 @@
+package models
+
+import "time"
+
+type ReportPeriod string
+
+const (
+	ReportPeriodWeek  ReportPeriod = "week"
+	ReportPeriodMonth ReportPeriod = "month"
+)
+
+var (
+	ErrInvalidReportPeriod = "Period must be week or month"
+)
+
+func (p ReportPeriod) IsValid() bool {
+	return p == ReportPeriodWeek || p == ReportPeriodMonth
+}
+
+func (p ReportPeriod) Start(date time.Time) time.Time {
+	if p == ReportPeriodMonth {
+		return time.Date(date.Year(), date.Month(), 1, 0, 0, 0, 0, time.UTC)
+	}
+
+	offset := (int(date.Weekday()) + 6) % 7
+	return time.Date(date.Year(), date.Month(), date.Day()-offset, 0, 0, 0, 0, time.UTC)
+}
+
+func (p ReportPeriod) End(date time.Time) time.Time {
+	if p == ReportPeriodMonth {
+		return p.Start(date).AddDate(0, 1, -1)
+	}
+
+	return p.Start(date).AddDate(0, 0, 6)
+}
+
+type ReportDay struct {
+	Date    time.Time `json:"date"`
+	Quality int       `json:"quality"`
+}
+
+type ReportTotals struct {
+	From    time.Time     `json:"from"`
+	To      time.Time     `json:"to"`
+	Focused time.Duration `json:"focused"`
+	// Stopped also counts abandoned sessions.
+	Completed             int        `json:"completed"`
+	Stopped               int        `json:"stopped"`
+	RatedSessions         int        `json:"ratedSessions"`
+	AverageSessionQuality float64    `json:"averageSessionQuality"`
+	RatedDays             int        `json:"ratedDays"`
+	AverageDayQuality     float64    `json:"averageDayQuality"`
+	BestDay               *ReportDay `json:"bestDay"`
+	WorstDay              *ReportDay `json:"worstDay"`
+}
+
+func (t ReportTotals) Days() int {
+	return int(t.To.Sub(t.From).Hours()/24) + 1
+}
+
+func (t ReportTotals) Sessions() int {
+	return t.Completed + t.Stopped
+}
+
+func (t ReportTotals) AverageDailyFocus() time.Duration {
+	return t.Focused / time.Duration(t.Days())
+}
+
+// Current stops at today while the period is still running, so averages rather than
+// totals are meant for comparison.
+type Report struct {
+	Period   ReportPeriod `json:"period"`
+	Current  ReportTotals `json:"current"`
+	Previous ReportTotals `json:"previous"`
+}
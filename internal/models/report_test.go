@@ -0,0 +1,95 @@
+package models
+
+import (
+	"testing"
+	"time"
+)
+
+func TestReportPeriod_StartEnd(t *testing.T) {
+	date := func(year int, month time.Month, day int) time.Time {
+		return time.Date(year, month, day, 0, 0, 0, 0, time.UTC)
+	}
+
+	tests := []struct {
+		name      string
+		period    ReportPeriod
+		date      time.Time
+		wantStart time.Time
+		wantEnd   time.Time
+	}{
+		{
+			name:      "week from Monday",
+			period:    ReportPeriodWeek,
+			date:      date(2026, time.October, 12),
+			wantStart: date(2026, time.October, 12),
+			wantEnd:   date(2026, time.October, 18),
+		},
+		{
+			name:      "week from Sunday",
+			period:    ReportPeriodWeek,
+			date:      date(2026, time.October, 18),
+			wantStart: date(2026, time.October, 12),
+			wantEnd:   date(2026, time.October, 18),
+		},
+		{
+			name:      "week across months",
+			period:    ReportPeriodWeek,
+			date:      date(2026, time.October, 1),
+			wantStart: date(2026, time.September, 28),
+			wantEnd:   date(2026, time.October, 4),
+		},
+		{
+			name:      "week across years",
+			period:    ReportPeriodWeek,
+			date:      date(2027, time.January, 1),
+			wantStart: date(2026, time.December, 28),
+			wantEnd:   date(2027, time.January, 3),
+		},
+		{
+			name:      "month on the 31st",
+			period:    ReportPeriodMonth,
+			date:      date(2026, time.January, 31),
+			wantStart: date(2026, time.January, 1),
+			wantEnd:   date(2026, time.January, 31),
+		},
+		{
+			name:      "month of 30 days",
+			period:    ReportPeriodMonth,
+			date:      date(2026, time.April, 30),
+			wantStart: date(2026, time.April, 1),
+			wantEnd:   date(2026, time.April, 30),
+		},
+		{
+			name:      "February",
+			period:    ReportPeriodMonth,
+			date:      date(2026, time.February, 15),
+			wantStart: date(2026, time.February, 1),
+			wantEnd:   date(2026, time.February, 28),
+		},
+		{
+			name:      "February of a leap year",
+			period:    ReportPeriodMonth,
+			date:      date(2028, time.February, 29),
+			wantStart: date(2028, time.February, 1),
+			wantEnd:   date(2028, time.February, 29),
+		},
+		{
+			name:      "December",
+			period:    ReportPeriodMonth,
+			date:      date(2026, time.December, 1),
+			wantStart: date(2026, time.December, 1),
+			wantEnd:   date(2026, time.December, 31),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.period.Start(tt.date); !got.Equal(tt.wantStart) {
+				t.Fatalf("start: got %s, want %s", got.Format(time.DateOnly), tt.wantStart.Format(time.DateOnly))
+			}
+			if got := tt.period.End(tt.date); !got.Equal(tt.wantEnd) {
+				t.Fatalf("end: got %s, want %s", got.Format(time.DateOnly), tt.wantEnd.Format(time.DateOnly))
+			}
+		})
+	}
+}
@@ -1,13 +1,24 @@
 package models
 
-import "time"
+import (
+	"attune/pkg/apperrors"
+	"time"
+)
+
+var (
+	ErrInvalidFocusGoal = "Daily focus goal must be between 1 minute and 24 hours, or off"
+)
 
 type UserSettings struct {
-	ID               string    `json:"id"`
-	UserID           string    `json:"userId"`
-	SentDailyStatsAt time.Time `json:"sentDailyStatsAt"`
-	CreatedAt        time.Time `json:"createdAt"`
-	UpdatedAt        time.Time `json:"updatedAt"`
+	ID                string        `json:"id"`
+	UserID            string        `json:"userId"`
+	SentDailyStatsAt  time.Time     `json:"sentDailyStatsAt"`
+	DailyFocusGoal    time.Duration `json:"dailyFocusGoal"`
+	GoalReachedAt     time.Time     `json:"goalReachedAt"`
+	WeekReportSentAt  time.Time     `json:"weekReportSentAt"`
+	MonthReportSentAt time.Time     `json:"monthReportSentAt"`
+	CreatedAt         time.Time     `json:"createdAt"`
+	UpdatedAt         time.Time     `json:"updatedAt"`
 }
 
 func NewUserSettings(userID string, sentDailyStatsAt time.Time) (UserSettings, error) {
@@ -26,3 +37,23 @@ func (us *UserSettings) UpdateSentDailyStatsAt(sentDailyStatsAt time.Time) {
 	us.SentDailyStatsAt = sentDailyStatsAt
 	us.UpdatedAt = time.Now()
 }
+
+func (us *UserSettings) UpdateDailyFocusGoal(goal time.Duration) error {
+	if goal != 0 && (goal < time.Minute || goal > time.Hour*24) {
+		return apperrors.NewBadRequest().WithDescription(ErrInvalidFocusGoal)
+	}
+
+	us.DailyFocusGoal = goal
+	us.UpdatedAt = time.Now()
+
+	return nil
+}
+
+type DailyFocusGoalProgress struct {
+	Focused time.Duration `json:"focused"`
+	Goal    time.Duration `json:"goal"`
+}
+
+func (p DailyFocusGoalProgress) Reached() bool {
+	return p.Goal > 0 && p.Focused >= p.Goal
+}
@@ -0,0 +1,212 @@
+package service
+
+import (
+	"attune/internal/api"
+	"attune/internal/models"
+	"attune/internal/storage"
+	"attune/pkg/apperrors"
+	"attune/pkg/clock"
+	"attune/pkg/logger"
+	"context"
+	"fmt"
+	"time"
+)
+
+var (
+	errMsgProfile = "failed to get profile for VendorID %s"
+)
+
+type AchievementService interface {
+	RecordSession(ctx context.Context, session models.FocusSession, goalReached bool) ([]models.Badge, error)
+	RecordCheckIn(ctx context.Context, userID string, date time.Time) ([]models.Badge, error)
+	Profile(ctx context.Context, vendorID string) (models.Profile, error)
+}
+
+type achievementService struct {
+	storages storage.Storages
+	logger   logger.Logger
+	clock    clock.Clock
+	location *time.Location
+}
+
+func NewAchievementService(
+	storages storage.Storages,
+	logger logger.Logger,
+	clk clock.Clock,
+	location *time.Location,
+) AchievementService {
+	return &achievementService{
+		storages: storages,
+		logger:   logger,
+		clock:    clk,
+		location: location,
+	}
+}
+
+func (s *achievementService) RecordSession(
+	ctx context.Context,
+	session models.FocusSession,
+	goalReached bool,
+) ([]models.Badge, error) {
+	if session.IsBreak() {
+		return nil, nil
+	}
+	if goalReached {
+		if err := s.recordStreak(ctx, session.UserID, models.StreakKindFocusGoal, s.localDate()); err != nil {
+			return nil, err
+		}
+	}
+
+	return s.award(ctx, session.UserID)
+}
+
+func (s *achievementService) RecordCheckIn(ctx context.Context, userID string, date time.Time) ([]models.Badge, error) {
+	if err := s.recordStreak(ctx, userID, models.StreakKindCheckIn, date); err != nil {
+		return nil, err
+	}
+
+	return s.award(ctx, userID)
+}
+
+func (s *achievementService) Profile(ctx context.Context, vendorID string) (models.Profile, error) {
+	const op = "achievementService.Profile"
+	log := s.logger.With("operation", op)
+
+	users, _, err := s.storages.User.List(ctx, storage.ListUserFilter{
+		VendorID: vendorID,
+	})
+	if err != nil {
+		return models.Profile{}, apperrors.NewInternal().WithDescriptionAndCause(fmt.Sprintf(errMsgListUsers, vendorID), err)
+	}
+	userID := users[0].ID
+
+	stats, streaks, err := s.stats(ctx, userID)
+	if err != nil {
+		log.Error(ctx, fmt.Sprintf(errMsgProfile, vendorID), err)
+		return models.Profile{}, err
+	}
+	badges, err := s.storages.UserBadge.List(ctx, storage.ListUserBadgeFilter{UserID: userID})
+	if err != nil && !apperrors.IsCode(err, apperrors.NotFound) {
+		log.Error(ctx, fmt.Sprintf(errMsgProfile, vendorID), err)
+		return models.Profile{}, err
+	}
+
+	return models.Profile{
+		Today:           s.localDate(),
+		CheckInStreak:   streaks[models.StreakKindCheckIn],
+		FocusGoalStreak: streaks[models.StreakKindFocusGoal],
+		Stats:           stats,
+		Badges:          badges,
+	}, nil
+}
+
+func (s *achievementService) recordStreak(ctx context.Context, userID string, kind models.StreakKind, date time.Time) error {
+	const op = "achievementService.recordStreak"
+	log := s.logger.With("operation", op)
+
+	streak := models.NewStreak(userID, kind)
+	streaks, err := s.storages.Streak.List(ctx, storage.ListStreakFilter{
+		UserID: userID,
+		Kind:   kind,
+	})
+	switch {
+	case err == nil:
+		streak = streaks[0]
+	case !apperrors.IsCode(err, apperrors.NotFound):
+		log.Error(ctx, "failed to list streaks", err, "userID", userID)
+		return err
+	}
+
+	if !streak.Record(date, s.clock.Now()) {
+		return nil
+	}
+	if err := s.storages.Streak.Upsert(ctx, streak); err != nil {
+		log.Error(ctx, "failed to upsert streak", err, "userID", userID)
+		return err
+	}
+
+	return nil
+}
+
+func (s *achievementService) award(ctx context.Context, userID string) ([]models.Badge, error) {
+	const op = "achievementService.award"
+	log := s.logger.With("operation", op)
+
+	owned, err := s.storages.UserBadge.List(ctx, storage.ListUserBadgeFilter{UserID: userID})
+	if err != nil && !apperrors.IsCode(err, apperrors.NotFound) {
+		log.Error(ctx, "failed to list user badges", err, "userID", userID)
+		return nil, err
+	}
+	has := make(map[models.BadgeCode]bool, len(owned))
+	for _, badge := range owned {
+		has[badge.Code] = true
+	}
+
+	stats, _, err := s.stats(ctx, userID)
+	if err != nil {
+		log.Error(ctx, "failed to get achievement stats", err, "userID", userID)
+		return nil, err
+	}
+
+	var awarded []models.Badge
+	now := s.clock.Now()
+	for _, badge := range models.Badges {
+		if has[badge.Code] || !badge.EarnedBy(stats) {
+			continue
+		}
+
+		ok, err := s.storages.UserBadge.Award(ctx, models.UserBadge{
+			UserID:    userID,
+			Code:      badge.Code,
+			AwardedAt: now,
+		})
+		if err != nil {
+			log.Error(ctx, "failed to award user badge", err, "userID", userID, "badge", badge.Code)
+			return awarded, err
+		}
+		if ok {
+			awarded = append(awarded, badge)
+		}
+	}
+
+	return awarded, nil
+}
+
+func (s *achievementService) stats(
+	ctx context.Context,
+	userID string,
+) (models.AchievementStats, map[models.StreakKind]models.Streak, error) {
+	var stats models.AchievementStats
+
+	sessions, focused, err := s.storages.FocusSession.Totals(ctx, userID)
+	if err != nil {
+		return stats, nil, err
+	}
+	stats.Sessions = sessions
+	stats.Focused = focused
+
+	streaks := make(map[models.StreakKind]models.Streak)
+	list, err := s.storages.Streak.List(ctx, storage.ListStreakFilter{UserID: userID})
+	if err != nil && !apperrors.IsCode(err, apperrors.NotFound) {
+		return stats, nil, err
+	}
+	for _, streak := range list {
+		streaks[streak.Kind] = streak
+	}
+	stats.CheckInStreak = streaks[models.StreakKindCheckIn].Longest
+	stats.FocusGoalStreak = streaks[models.StreakKindFocusGoal].Longest
+
+	return stats, streaks, nil
+}
+
+func (s *achievementService) localDate() time.Time {
+	return models.LocalDate(s.clock.Now(), s.location)
+}
+
+func badgesTrigger(vendorID string, badges []models.Badge) api.Trigger {
+	return api.Trigger{
+		VendorID: vendorID,
+		Type:     api.TriggerTypeBadgesEarned,
+		Badges:   badges,
+	}
+}
@@ -0,0 +1,138 @@
+package service
+
+import (
+	"attune/internal/models"
+	"attune/internal/storage"
+	"attune/pkg/apperrors"
+	"attune/pkg/clock"
+	"attune/pkg/logger"
+	"context"
+	"fmt"
+	"time"
+)
+
+var (
+	errMsgChartData = "failed to get chart data for VendorID %s"
+)
+
+type ChartService interface {
+	Data(ctx context.Context, vendorID string, period models.ChartPeriod) (models.ChartData, error)
+}
+
+type chartService struct {
+	storages storage.Storages
+	logger   logger.Logger
+	clock    clock.Clock
+	location *time.Location
+}
+
+func NewChartService(
+	storages storage.Storages,
+	logger logger.Logger,
+	clk clock.Clock,
+	location *time.Location,
+) ChartService {
+	return &chartService{
+		storages: storages,
+		logger:   logger,
+		clock:    clk,
+		location: location,
+	}
+}
+
+func (s *chartService) Data(ctx context.Context, vendorID string, period models.ChartPeriod) (models.ChartData, error) {
+	const op = "chartService.Data"
+	log := s.logger.With("operation", op)
+
+	if !period.IsValid() {
+		return models.ChartData{}, apperrors.NewBadRequest().WithDescription(models.ErrInvalidChartPeriod)
+	}
+
+	users, _, err := s.storages.User.List(ctx, storage.ListUserFilter{
+		VendorID: vendorID,
+	})
+	if err != nil {
+		return models.ChartData{}, apperrors.NewInternal().WithDescriptionAndCause(fmt.Sprintf(errMsgListUsers, vendorID), err)
+	}
+	userID := users[0].ID
+
+	loc := s.location
+	today := models.LocalDate(s.clock.Now(), loc)
+	data := models.ChartData{Period: period, Buckets: chartBuckets(period, today)}
+	from := data.Buckets[0].Start
+
+	records, _, err := s.storages.DayRecord.List(ctx, storage.ListDayRecordFilter{
+		UserID:   userID,
+		DateFrom: from,
+		DateTo:   today,
+	})
+	if err != nil && !apperrors.IsCode(err, apperrors.NotFound) {
+		log.Error(ctx, fmt.Sprintf(errMsgChartData, vendorID), err)
+		return models.ChartData{}, err
+	}
+	for _, record := range records {
+		bucket := &data.Buckets[chartBucketIndex(period, from, record.LocalDate)]
+		bucket.DayQuality += float64(record.Quality)
+		bucket.RatedDays++
+	}
+	for i := range data.Buckets {
+		if bucket := &data.Buckets[i]; bucket.RatedDays > 0 {
+			bucket.DayQuality /= float64(bucket.RatedDays)
+		}
+	}
+
+	sessions, _, err := s.storages.FocusSession.List(ctx, storage.ListFocusSessionFilter{
+		UserID:       userID,
+		OnlyFinished: true,
+		StartedFrom:  time.Date(from.Year(), from.Month(), from.Day(), 0, 0, 0, 0, loc),
+	})
+	if err != nil && !apperrors.IsCode(err, apperrors.NotFound) {
+		log.Error(ctx, fmt.Sprintf(errMsgChartData, vendorID), err)
+		return models.ChartData{}, err
+	}
+	for _, session := range sessions {
+		if session.IsBreak() {
+			continue
+		}
+
+		index := chartBucketIndex(period, from, models.LocalDate(session.StartedAt, loc))
+		if index >= len(data.Buckets) {
+			continue
+		}
+		data.Buckets[index].FocusedDuration += session.FocusedDuration
+		if session.Quality >= 1 && session.Quality <= len(data.SessionQuality) {
+			data.SessionQuality[session.Quality-1]++
+		}
+	}
+
+	return data, nil
+}
+
+func chartBuckets(period models.ChartPeriod, today time.Time) []models.ChartBucket {
+	var buckets []models.ChartBucket
+	switch period {
+	case models.ChartPeriodYear:
+		month := time.Date(today.Year(), today.Month(), 1, 0, 0, 0, 0, time.UTC)
+		for i := 11; i >= 0; i-- {
+			buckets = append(buckets, models.ChartBucket{Start: month.AddDate(0, -i, 0)})
+		}
+	default:
+		days := 7
+		if period == models.ChartPeriodMonth {
+			days = 30
+		}
+		for i := days - 1; i >= 0; i-- {
+			buckets = append(buckets, models.ChartBucket{Start: today.AddDate(0, 0, -i)})
+		}
+	}
+
+	return buckets
+}
+
+func chartBucketIndex(period models.ChartPeriod, from, date time.Time) int {
+	if period == models.ChartPeriodYear {
+		return (date.Year()-from.Year())*12 + int(date.Month()-from.Month())
+	}
+
+	return int(date.Sub(from).Hours() / 24)
+}
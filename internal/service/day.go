@@ -1,29 +1,63 @@
 package service
 
 import (
+	"attune/internal/api"
 	"attune/internal/dto"
 	"attune/internal/models"
 	"attune/internal/storage"
+	"attune/pkg/apperrors"
+	"attune/pkg/clock"
 	"attune/pkg/logger"
 	"context"
+	"fmt"
+	"time"
 )
 
+var (
+	errMsgRateDay   = "failed to rate today for VendorID %s"
+	errMsgDayMood   = "failed to set today's mood for VendorID %s"
+	errMsgEmotions  = "failed to get emotion frequencies for VendorID %s"
+	errMsgRateFirst = "Rate your day first"
+)
+
+const topEmotionsLimit = 5
+
 type DayService interface {
 	Create(ctx context.Context, input dto.CreateDayRecordRequest) error
 	List(ctx context.Context, filter storage.ListDayRecordFilter) ([]models.DayRecord, int64, error)
 	Delete(ctx context.Context, id string) error
+	Today(ctx context.Context, vendorID string) (models.DayRecord, error)
+	RateToday(ctx context.Context, vendorID string, quality int) (models.DayRecord, error)
+	SetTodayEmotions(ctx context.Context, vendorID string, emotions []models.DayEmotion) (models.DayRecord, error)
+	SetTodayMoodNote(ctx context.Context, vendorID, note string) (models.DayRecord, error)
+	EmotionStats(ctx context.Context, vendorID string) ([]models.EmotionFrequency, error)
 	//Update(ctx context.Context, input dto.UpdateUserRequest) (models.User, error)
 }
 
 type dayService struct {
-	storages storage.Storages
-	logger   logger.Logger
+	storages     storage.Storages
+	achievements AchievementService
+	apiCh        chan<- api.Trigger
+	logger       logger.Logger
+	clock        clock.Clock
+	location     *time.Location
 }
 
-func NewDayService(storages storage.Storages, logger logger.Logger) DayService {
+func NewDayService(
+	storages storage.Storages,
+	achievements AchievementService,
+	apiCh chan<- api.Trigger,
+	logger logger.Logger,
+	clk clock.Clock,
+	location *time.Location,
+) DayService {
 	return &dayService{
-		storages: storages,
-		logger:   logger,
+		storages:     storages,
+		achievements: achievements,
+		apiCh:        apiCh,
+		logger:       logger,
+		clock:        clk,
+		location:     location,
 	}
 }
 
@@ -32,22 +66,36 @@ func (s *dayService) Create(ctx context.Context, input dto.CreateDayRecordReques
 
 	log := s.logger.With("operation", op)
 
+	localDate := input.LocalDate
+	if localDate.IsZero() {
+		localDate = s.localDate()
+	}
+
 	dayRecord, err := models.NewDayRecord(
 		input.UserID,
+		localDate,
 		input.Quality,
 		input.Mood,
+		s.clock,
 	)
 	if err != nil {
 		log.Error(ctx, "failed to create day record", err)
 		return err
 	}
 
-	err = s.storages.DayRecord.Create(ctx, dayRecord)
+	_, err = s.storages.DayRecord.Upsert(ctx, dayRecord)
 	if err != nil {
 		log.Error(ctx, "failed to create day record in storage", err)
 		return err
 	}
 
+	users, _, err := s.storages.User.List(ctx, storage.ListUserFilter{ID: input.UserID})
+	if err != nil {
+		log.Error(ctx, "failed to list users", err, "userID", input.UserID)
+		return nil
+	}
+	s.checkIn(ctx, users[0], localDate)
+
 	return nil
 }
 
@@ -78,3 +126,161 @@ func (s *dayService) Delete(ctx context.Context, id string) error {
 
 	return nil
 }
+
+func (s *dayService) Today(ctx context.Context, vendorID string) (models.DayRecord, error) {
+	user, err := s.user(ctx, vendorID)
+	if err != nil {
+		return models.DayRecord{}, err
+	}
+
+	return s.today(ctx, user.ID)
+}
+
+func (s *dayService) RateToday(ctx context.Context, vendorID string, quality int) (models.DayRecord, error) {
+	const op = "dayService.RateToday"
+	log := s.logger.With("operation", op)
+
+	user, err := s.user(ctx, vendorID)
+	if err != nil {
+		return models.DayRecord{}, err
+	}
+
+	record, err := models.NewDayRecord(user.ID, s.localDate(), quality, models.Mood{}, s.clock)
+	if err != nil {
+		return models.DayRecord{}, err
+	}
+	record, err = s.storages.DayRecord.Rate(ctx, record)
+	if err != nil {
+		log.Error(ctx, fmt.Sprintf(errMsgRateDay, vendorID), err)
+		return models.DayRecord{}, err
+	}
+	s.checkIn(ctx, user, record.LocalDate)
+
+	return record, nil
+}
+
+func (s *dayService) SetTodayEmotions(
+	ctx context.Context,
+	vendorID string,
+	emotions []models.DayEmotion,
+) (models.DayRecord, error) {
+	return s.updateTodayMood(ctx, vendorID, func(mood *models.Mood) {
+		mood.Emotions = emotions
+	})
+}
+
+func (s *dayService) SetTodayMoodNote(ctx context.Context, vendorID, note string) (models.DayRecord, error) {
+	return s.updateTodayMood(ctx, vendorID, func(mood *models.Mood) {
+		mood.Note = note
+	})
+}
+
+func (s *dayService) EmotionStats(ctx context.Context, vendorID string) ([]models.EmotionFrequency, error) {
+	const op = "dayService.EmotionStats"
+	log := s.logger.With("operation", op)
+
+	user, err := s.user(ctx, vendorID)
+	if err != nil {
+		return nil, err
+	}
+
+	today := s.localDate()
+	frequencies, err := s.storages.DayRecord.EmotionFrequencies(ctx, storage.ListDayRecordFilter{
+		UserID:   user.ID,
+		DateFrom: today.AddDate(0, 0, 1-today.Day()),
+		DateTo:   today,
+	}, topEmotionsLimit)
+	if err != nil {
+		if !apperrors.IsCode(err, apperrors.NotFound) {
+			log.Error(ctx, fmt.Sprintf(errMsgEmotions, vendorID), err)
+		}
+		return nil, err
+	}
+
+	return frequencies, nil
+}
+
+func (s *dayService) updateTodayMood(
+	ctx context.Context,
+	vendorID string,
+	change func(mood *models.Mood),
+) (models.DayRecord, error) {
+	const op = "dayService.updateTodayMood"
+	log := s.logger.With("operation", op)
+
+	user, err := s.user(ctx, vendorID)
+	if err != nil {
+		return models.DayRecord{}, err
+	}
+
+	record, err := s.today(ctx, user.ID)
+	if err != nil {
+		if apperrors.IsCode(err, apperrors.NotFound) {
+			return models.DayRecord{}, apperrors.NewBadRequest().WithDescription(errMsgRateFirst)
+		}
+		log.Error(ctx, fmt.Sprintf(errMsgDayMood, vendorID), err)
+		return models.DayRecord{}, err
+	}
+
+	mood := record.Mood
+	change(&mood)
+	if err := record.UpdateMood(mood); err != nil {
+		return models.DayRecord{}, err
+	}
+	if err := s.storages.DayRecord.Update(ctx, record); err != nil {
+		log.Error(ctx, fmt.Sprintf(errMsgDayMood, vendorID), err)
+		return models.DayRecord{}, err
+	}
+
+	return record, nil
+}
+
+func (s *dayService) checkIn(ctx context.Context, user models.User, localDate time.Time) {
+	badges, err := s.achievements.RecordCheckIn(ctx, user.ID, localDate)
+	if err != nil {
+		s.logger.Error(ctx, "failed to award badges", err, "userID", user.ID)
+	}
+	if len(badges) == 0 || user.VendorID == "" {
+		return
+	}
+
+	trigger := badgesTrigger(user.VendorID, badges)
+	go func() {
+		s.apiCh <- trigger
+	}()
+}
+
+func (s *dayService) today(ctx context.Context, userID string) (models.DayRecord, error) {
+	today := s.localDate()
+	records, _, err := s.storages.DayRecord.List(ctx, storage.ListDayRecordFilter{
+		UserID:   userID,
+		DateFrom: today,
+		DateTo:   today,
+	})
+	if err != nil {
+		return models.DayRecord{}, err
+	}
+
+	return records[0], nil
+}
+
+func (s *dayService) user(ctx context.Context, vendorID string) (models.User, error) {
+	users, _, err := s.storages.User.List(ctx, storage.ListUserFilter{
+		VendorID: vendorID,
+	})
+	if err != nil {
+		return models.User{}, apperrors.NewInternal().WithDescriptionAndCause(fmt.Sprintf(errMsgListUsers, vendorID), err)
+	}
+
+	return users[0], nil
+}
+
+func (s *dayService) localDate() time.Time {
+	return models.LocalDate(s.clock.Now(), s.location)
+}
+
+func localDayStart(t time.Time, loc *time.Location) time.Time {
+	year, month, day := t.In(loc).Date()
+
+	return time.Date(year, month, day, 0, 0, 0, 0, loc)
+}
@@ -0,0 +1,133 @@
+package service
+
+import (
+	"attune/internal/api"
+	"attune/internal/models"
+	"attune/internal/storage"
+	"attune/pkg/apperrors"
+	"attune/pkg/clock"
+	"attune/pkg/logger"
+	"context"
+	"time"
+)
+
+const dayReminderInterval = time.Minute
+
+type DayReminder interface {
+	Start(ctx context.Context)
+}
+
+type DayReminderConfig struct {
+	At       string
+	Location *time.Location
+}
+
+type dayReminder struct {
+	storages    storage.Storages
+	externalAPI api.ExternalAPI
+	logger      logger.Logger
+	clock       clock.Clock
+	config      DayReminderConfig
+}
+
+func NewDayReminder(
+	storages storage.Storages,
+	externalAPI api.ExternalAPI,
+	logger logger.Logger,
+	clk clock.Clock,
+	config DayReminderConfig,
+) DayReminder {
+	if config.Location == nil {
+		config.Location = time.UTC
+	}
+
+	return &dayReminder{
+		storages:    storages,
+		externalAPI: externalAPI,
+		logger:      logger,
+		clock:       clk,
+		config:      config,
+	}
+}
+
+// A check-in time that has already passed at startup waits for the next day, so a
+// restart never reminds twice.
+func (r *dayReminder) Start(ctx context.Context) {
+	if r.config.At == "" {
+		return
+	}
+	at, err := time.Parse("15:04", r.config.At)
+	if err != nil {
+		r.logger.Error(ctx, "invalid day check-in time", err, "at", r.config.At)
+		return
+	}
+
+	remindedOn := latestDueDay(r.clock.Now(), r.config.Location, at)
+	timer := r.clock.NewTimer(dayReminderInterval)
+	defer timer.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-timer.C():
+			if day := latestDueDay(r.clock.Now(), r.config.Location, at); day.After(remindedOn) {
+				remindedOn = day
+				r.remind(ctx, day)
+			}
+			timer.Reset(dayReminderInterval)
+		}
+	}
+}
+
+func latestDueDay(now time.Time, loc *time.Location, at time.Time) time.Time {
+	now = now.In(loc)
+	year, month, day := now.Date()
+	today := time.Date(year, month, day, 0, 0, 0, 0, loc)
+
+	if now.Before(today.Add(time.Duration(at.Hour())*time.Hour + time.Duration(at.Minute())*time.Minute)) {
+		return today.AddDate(0, 0, -1)
+	}
+
+	return today
+}
+
+func (r *dayReminder) remind(ctx context.Context, day time.Time) {
+	users, _, err := r.storages.User.List(ctx, storage.ListUserFilter{
+		VendorType: string(models.VendorTelegram),
+	})
+	if err != nil {
+		if !apperrors.IsCode(err, apperrors.NotFound) {
+			r.logger.Error(ctx, "failed to list users for day check-in", err)
+		}
+		return
+	}
+
+	checkedIn := make(map[string]bool)
+	date := models.LocalDate(day, r.config.Location)
+	records, _, err := r.storages.DayRecord.List(ctx, storage.ListDayRecordFilter{
+		DateFrom: date,
+		DateTo:   date,
+	})
+	if err != nil && !apperrors.IsCode(err, apperrors.NotFound) {
+		r.logger.Error(ctx, "failed to list day records for day check-in", err)
+		return
+	}
+	for _, record := range records {
+		checkedIn[record.UserID] = true
+	}
+
+	for _, user := range users {
+		if checkedIn[user.ID] {
+			continue
+		}
+
+		err := r.externalAPI.Trigger(ctx, user.VendorID, api.Trigger{
+			VendorID: user.VendorID,
+			Type:     api.TriggerTypeDayCheckIn,
+		})
+		if err != nil {
+			r.logger.Error(ctx, "failed to send day check-in", err, "user", user.VendorID)
+		}
+	}
+}
@@ -6,10 +6,12 @@ import (
 	"attune/internal/storage"
 	"attune/pkg/apperrors"
 	"attune/pkg/cache"
+	"attune/pkg/clock"
 	"attune/pkg/logger"
 	"attune/pkg/transactor"
 	"context"
 	"fmt"
+	"strings"
 	"time"
 )
 
@@ -22,12 +24,30 @@ var (
 	errMsgUpdateInvalidType = "invalid update request type"
 	errMsgUpdateFailure     = "failed to update focus session with type %s"
 	errMsgDeleteSession     = "failed to delete focus session with id %s"
+	errMsgSessionRunning    = "user already has an active focus session"
+	errMsgReplaceSession    = "failed to stop the active focus session"
+	errMsgRoomSessionLocked = "Room sessions end together, so they can't be paused or extended"
+	errMsgBreakExtend       = "You're on a break, so there is no focus session to extend"
+	errMsgListHistory       = "failed to list focus history for VendorID %s"
+	errMsgDistract          = "failed to record focus distraction"
+	errMsgDistractionStats  = "failed to get focus distraction stats"
+	errMsgNoRunningSession  = "You have no running focus session"
+	errMsgBreakDistraction  = "You're on a break, interruptions only count while you focus"
+	errMsgWorkRest          = "failed to get work-to-rest balance"
 )
 
+const distractionStatsWindow = 30 * 24 * time.Hour
+
 type FocusSessionService interface {
 	Create(ctx context.Context, input dto.CreateFocusSessionRequest) error
 	List(ctx context.Context, filter storage.ListFocusSessionFilter) ([]models.FocusSession, int64, error)
 	Update(ctx context.Context, input dto.UpdateFocusRequest) error
+	State(ctx context.Context, vendorID string) (FocusSessionState, error)
+	History(ctx context.Context, vendorID, query string, limit uint64) ([]models.FocusSession, error)
+	Distract(ctx context.Context, input dto.CreateFocusDistractionRequest) (int64, error)
+	Distractions(ctx context.Context, vendorID, sessionID string) ([]models.FocusDistraction, error)
+	DistractionStats(ctx context.Context, vendorID string) (models.FocusDistractionStats, error)
+	WorkRest(ctx context.Context, vendorID string) (models.WorkRestBalance, error)
 	Delete(ctx context.Context, id string) error
 }
 
@@ -37,6 +57,8 @@ type focusSessionService struct {
 	transactor          transactor.Transactor
 	logger              logger.Logger
 	cache               cache.Cache
+	clock               clock.Clock
+	location            *time.Location
 }
 
 func NewFocusSessionService(
@@ -45,6 +67,8 @@ func NewFocusSessionService(
 	transactor transactor.Transactor,
 	logger logger.Logger,
 	cache cache.Cache,
+	clk clock.Clock,
+	location *time.Location,
 ) FocusSessionService {
 	return &focusSessionService{
 		storages:            storages,
@@ -52,6 +76,8 @@ func NewFocusSessionService(
 		transactor:          transactor,
 		logger:              logger,
 		cache:               cache,
+		clock:               clk,
+		location:            location,
 	}
 }
 
@@ -69,7 +95,17 @@ func (s *focusSessionService) Create(ctx context.Context, input dto.CreateFocusS
 	}
 	user := users[0]
 
-	focusSession, err := models.NewFocusSession(user.ID, input.Duration)
+	duration := input.Duration
+	var focusSession models.FocusSession
+	switch {
+	case input.Pomodoro != nil:
+		focusSession, err = models.NewPomodoroSession(user.ID, *input.Pomodoro, s.clock)
+		duration = input.Pomodoro.WorkDuration
+	case input.Break:
+		focusSession, err = models.NewBreakSession(user.ID, input.Duration, s.clock)
+	default:
+		focusSession, err = models.NewFocusSession(user.ID, input.Duration, s.clock)
+	}
 	if err != nil {
 		log.Error(ctx, errMsgCreateSession, err)
 		return err
@@ -79,12 +115,85 @@ func (s *focusSessionService) Create(ctx context.Context, input dto.CreateFocusS
 		focusSession.VendorID = input.VendorID
 	}
 
+	active, _, err := s.storages.FocusSession.List(ctx, storage.ListFocusSessionFilter{
+		UserID: user.ID,
+		Status: models.FocusSessionStatusActive,
+	})
+	if err != nil && !apperrors.IsCode(err, apperrors.NotFound) {
+		log.Error(ctx, errMsgListSessions, err)
+		return apperrors.NewInternal().WithDescriptionAndCause(errMsgListSessions, err)
+	}
+	if len(active) > 0 {
+		onBreak := active[0].IsBreak()
+		if !onBreak && !input.Replace {
+			return apperrors.NewConflict().WithDescription(errMsgSessionRunning)
+		}
+
+		// A break simply ends when the next session starts.
+		source := input.Source
+		if onBreak {
+			source = models.FocusSessionEventSourceSystem
+		}
+		if err := stopActiveSessions(ctx, s.storages, s.focusSessionManager, s.clock, user.ID, active, source); err != nil {
+			log.Error(ctx, errMsgReplaceSession, err)
+			return err
+		}
+	}
+
+	if input.Label != "" && !input.Break {
+		label, err := useFocusLabel(ctx, s.storages, s.clock, user.ID, input.Label)
+		if err != nil {
+			log.Error(ctx, errMsgCreateSession, err)
+			return err
+		}
+		focusSession.LabelID = label.ID
+	}
+
 	if err := s.storages.FocusSession.Create(ctx, focusSession); err != nil {
 		log.Error(ctx, errMsgCreateSession, err)
+		if apperrors.IsCode(err, apperrors.Conflict) {
+			return err
+		}
+
 		return apperrors.NewInternal().WithDescriptionAndCause(errMsgCreateSession, err)
 	}
 
-	go s.focusSessionManager.Start(focusSession, input.Duration)
+	if err := s.focusSessionManager.Start(focusSession, duration, input.Source); err != nil {
+		log.Error(ctx, errMsgCreateSession, err)
+		if err := s.storages.FocusSession.Delete(ctx, focusSession.ID); err != nil {
+			log.Error(ctx, fmt.Sprintf(errMsgDeleteSession, focusSession.ID), err)
+		}
+
+		return err
+	}
+
+	return nil
+}
+
+// Active rows without a running timer are closed directly.
+func stopActiveSessions(
+	ctx context.Context,
+	storages storage.Storages,
+	manager FocusSessionManager,
+	clk clock.Clock,
+	userID string,
+	active []models.FocusSession,
+	source models.FocusSessionEventSource,
+) error {
+	err := manager.Stop(userID, source)
+	if err == nil {
+		return nil
+	}
+	if !apperrors.IsCode(err, apperrors.NotFound) {
+		return err
+	}
+
+	for _, session := range active {
+		session.Finish(models.FocusSessionStatusStopped, session.Remaining, clk.Now())
+		if err := storages.FocusSession.Update(ctx, session); err != nil {
+			return apperrors.NewInternal().WithDescriptionAndCause(errMsgReplaceSession, err)
+		}
+	}
 
 	return nil
 }
@@ -116,13 +225,18 @@ func (s *focusSessionService) Update(ctx context.Context, input dto.UpdateFocusR
 	return s.transactor.Transact(ctx, func(ctx context.Context) error {
 		switch input.Type {
 		case dto.UpdateFocusRequestTypePause:
-			return s.focusSessionManager.Pause(user.ID)
+			return s.focusSessionManager.Pause(user.ID, input.Source)
 		case dto.UpdateFocusRequestTypeResume:
-			return s.focusSessionManager.Resume(user.ID)
+			return s.focusSessionManager.Resume(user.ID, input.Source)
 		case dto.UpdateFocusRequestTypeStop:
-			return s.focusSessionManager.Stop(user.ID)
+			return s.focusSessionManager.Stop(user.ID, input.Source)
+		case dto.UpdateFocusRequestTypeSkipBreak:
+			return s.focusSessionManager.SkipBreak(user.ID, input.Source)
+		case dto.UpdateFocusRequestTypeExtend:
+			return s.focusSessionManager.Extend(user.ID, input.Delta, input.Source)
 		case dto.UpdateFocusRequestTypeQuality:
 			sessions, _, err := s.storages.FocusSession.List(ctx, storage.ListFocusSessionFilter{
+				ID:     input.SessionID,
 				UserID: user.ID,
 			})
 			if err != nil {
@@ -133,8 +247,28 @@ func (s *focusSessionService) Update(ctx context.Context, input dto.UpdateFocusR
 			session := sessions[0]
 			session.Quality = input.Quality
 			session.Status = input.Status
-			session.UpdatedAt = time.Now()
-			session.EndedAt = time.Now()
+			session.UpdatedAt = s.clock.Now()
+			if err := s.storages.FocusSession.Update(ctx, session); err != nil {
+				log.Error(ctx, errMsgUpdateFailure, err)
+				return apperrors.NewInternal().WithDescriptionAndCause(fmt.Sprintf(errMsgUpdateFailure, input.Type), err)
+			}
+
+			return nil
+		case dto.UpdateFocusRequestTypeNote:
+			sessions, _, err := s.storages.FocusSession.List(ctx, storage.ListFocusSessionFilter{
+				ID:     input.SessionID,
+				UserID: user.ID,
+			})
+			if err != nil {
+				log.Error(ctx, errMsgListSessions, err)
+				return apperrors.NewInternal().WithDescriptionAndCause(errMsgListSessions, err)
+			}
+
+			session := sessions[0]
+			if err := session.UpdateNote(input.Note); err != nil {
+				return err
+			}
+			session.UpdatedAt = s.clock.Now()
 			if err := s.storages.FocusSession.Update(ctx, session); err != nil {
 				log.Error(ctx, errMsgUpdateFailure, err)
 				return apperrors.NewInternal().WithDescriptionAndCause(fmt.Sprintf(errMsgUpdateFailure, input.Type), err)
@@ -148,6 +282,166 @@ func (s *focusSessionService) Update(ctx context.Context, input dto.UpdateFocusR
 	})
 }
 
+func (s *focusSessionService) State(ctx context.Context, vendorID string) (FocusSessionState, error) {
+	users, _, err := s.storages.User.List(ctx, storage.ListUserFilter{
+		VendorID: vendorID,
+	})
+	if err != nil {
+		return FocusSessionState{}, apperrors.NewInternal().WithDescriptionAndCause(fmt.Sprintf(errMsgListUsers, vendorID), err)
+	}
+
+	return s.focusSessionManager.State(users[0].ID)
+}
+
+func (s *focusSessionService) History(ctx context.Context, vendorID, query string, limit uint64) ([]models.FocusSession, error) {
+	const op = "focusSessionService.History"
+	log := s.logger.With("operation", op)
+
+	users, _, err := s.storages.User.List(ctx, storage.ListUserFilter{
+		VendorID: vendorID,
+	})
+	if err != nil {
+		return nil, apperrors.NewInternal().WithDescriptionAndCause(fmt.Sprintf(errMsgListUsers, vendorID), err)
+	}
+
+	sessions, _, err := s.storages.FocusSession.List(ctx, storage.ListFocusSessionFilter{
+		UserID:       users[0].ID,
+		OnlyFinished: true,
+		NoteQuery:    strings.TrimSpace(query),
+		Limit:        limit,
+	})
+	if err != nil {
+		if !apperrors.IsCode(err, apperrors.NotFound) {
+			log.Error(ctx, fmt.Sprintf(errMsgListHistory, vendorID), err)
+		}
+		return nil, err
+	}
+
+	for i := range sessions {
+		sessions[i].StartedAt = sessions[i].StartedAt.In(s.location)
+		sessions[i].EndedAt = sessions[i].EndedAt.In(s.location)
+	}
+
+	return sessions, nil
+}
+
+func (s *focusSessionService) Distract(ctx context.Context, input dto.CreateFocusDistractionRequest) (int64, error) {
+	const op = "focusSessionService.Distract"
+	log := s.logger.With("operation", op)
+
+	users, _, err := s.storages.User.List(ctx, storage.ListUserFilter{
+		VendorID: input.VendorID,
+	})
+	if err != nil {
+		return 0, apperrors.NewInternal().WithDescriptionAndCause(fmt.Sprintf(errMsgListUsers, input.VendorID), err)
+	}
+	user := users[0]
+
+	state, err := s.focusSessionManager.State(user.ID)
+	if err != nil {
+		if apperrors.IsCode(err, apperrors.NotFound) {
+			return 0, apperrors.NewBadRequest().WithDescription(errMsgNoRunningSession)
+		}
+		return 0, err
+	}
+	if state.Break || (state.Pomodoro != nil && state.Pomodoro.IsBreak()) {
+		return 0, apperrors.NewBadRequest().WithDescription(errMsgBreakDistraction)
+	}
+
+	distraction, err := models.NewFocusDistraction(state.SessionID, user.ID, input.Reason, s.clock)
+	if err != nil {
+		return 0, err
+	}
+	if err := s.storages.FocusDistraction.Create(ctx, distraction); err != nil {
+		log.Error(ctx, errMsgDistract, err)
+		return 0, err
+	}
+
+	_, count, err := s.storages.FocusDistraction.List(ctx, storage.ListFocusDistractionFilter{
+		SessionID: state.SessionID,
+	})
+	if err != nil {
+		log.Error(ctx, errMsgDistract, err)
+		return 0, err
+	}
+
+	return count, nil
+}
+
+func (s *focusSessionService) Distractions(ctx context.Context, vendorID, sessionID string) ([]models.FocusDistraction, error) {
+	users, _, err := s.storages.User.List(ctx, storage.ListUserFilter{
+		VendorID: vendorID,
+	})
+	if err != nil {
+		return nil, apperrors.NewInternal().WithDescriptionAndCause(fmt.Sprintf(errMsgListUsers, vendorID), err)
+	}
+
+	distractions, _, err := s.storages.FocusDistraction.List(ctx, storage.ListFocusDistractionFilter{
+		SessionID: sessionID,
+		UserID:    users[0].ID,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return distractions, nil
+}
+
+func (s *focusSessionService) DistractionStats(ctx context.Context, vendorID string) (models.FocusDistractionStats, error) {
+	const op = "focusSessionService.DistractionStats"
+	log := s.logger.With("operation", op)
+
+	users, _, err := s.storages.User.List(ctx, storage.ListUserFilter{
+		VendorID: vendorID,
+	})
+	if err != nil {
+		return models.FocusDistractionStats{}, apperrors.NewInternal().WithDescriptionAndCause(fmt.Sprintf(errMsgListUsers, vendorID), err)
+	}
+
+	stats, err := s.storages.FocusDistraction.Stats(ctx, storage.FocusDistractionStatsFilter{
+		UserID: users[0].ID,
+		From:   s.clock.Now().Add(-distractionStatsWindow),
+	})
+	if err != nil {
+		if !apperrors.IsCode(err, apperrors.NotFound) {
+			log.Error(ctx, errMsgDistractionStats, err)
+		}
+		return models.FocusDistractionStats{}, err
+	}
+
+	return stats, nil
+}
+
+func (s *focusSessionService) WorkRest(ctx context.Context, vendorID string) (models.WorkRestBalance, error) {
+	const op = "focusSessionService.WorkRest"
+	log := s.logger.With("operation", op)
+
+	users, _, err := s.storages.User.List(ctx, storage.ListUserFilter{
+		VendorID: vendorID,
+	})
+	if err != nil {
+		return models.WorkRestBalance{}, apperrors.NewInternal().WithDescriptionAndCause(fmt.Sprintf(errMsgListUsers, vendorID), err)
+	}
+
+	sessions, _, err := s.storages.FocusSession.List(ctx, storage.ListFocusSessionFilter{
+		UserID:       users[0].ID,
+		OnlyFinished: true,
+		StartedFrom:  localDayStart(s.clock.Now(), s.location),
+	})
+	if err != nil && !apperrors.IsCode(err, apperrors.NotFound) {
+		log.Error(ctx, errMsgWorkRest, err)
+		return models.WorkRestBalance{}, err
+	}
+
+	var balance models.WorkRestBalance
+	for _, session := range sessions {
+		balance.Focused += session.FocusedDuration
+		balance.Rest += session.RestDuration
+	}
+
+	return balance, nil
+}
+
 func (s *focusSessionService) Delete(ctx context.Context, id string) error {
 	const op = "focusSessionService.Delete"
 	log := s.logger.With("operation", op)
@@ -0,0 +1,68 @@
+package service
+
+import (
+	"attune/internal/dto"
+	"attune/internal/models"
+	"attune/internal/storage"
+	"attune/pkg/logger"
+	"context"
+)
+
+var (
+	errMsgCreateEvent = "failed to create focus session event"
+	errMsgListEvents  = "failed to list focus session events"
+)
+
+type FocusSessionEventService interface {
+	Record(ctx context.Context, input dto.CreateFocusSessionEventRequest) error
+	List(ctx context.Context, filter storage.ListFocusSessionEventFilter) ([]models.FocusSessionEvent, int64, error)
+}
+
+type focusSessionEventService struct {
+	storages storage.Storages
+	logger   logger.Logger
+}
+
+func NewFocusSessionEventService(storages storage.Storages, logger logger.Logger) FocusSessionEventService {
+	return &focusSessionEventService{
+		storages: storages,
+		logger:   logger,
+	}
+}
+
+func (s *focusSessionEventService) Record(ctx context.Context, input dto.CreateFocusSessionEventRequest) error {
+	const op = "focusSessionEventService.Record"
+	log := s.logger.With("operation", op)
+
+	event, err := models.NewFocusSessionEvent(
+		input.SessionID,
+		input.UserID,
+		input.Type,
+		input.Source,
+		input.OccurredAt,
+	)
+	if err != nil {
+		log.Error(ctx, errMsgCreateEvent, err)
+		return err
+	}
+
+	if err := s.storages.FocusSessionEvent.Create(ctx, event); err != nil {
+		log.Error(ctx, errMsgCreateEvent, err)
+		return err
+	}
+
+	return nil
+}
+
+func (s *focusSessionEventService) List(ctx context.Context, filter storage.ListFocusSessionEventFilter) ([]models.FocusSessionEvent, int64, error) {
+	const op = "focusSessionEventService.List"
+	log := s.logger.With("operation", op)
+
+	events, count, err := s.storages.FocusSessionEvent.List(ctx, filter)
+	if err != nil {
+		log.Error(ctx, errMsgListEvents, err)
+		return nil, 0, err
+	}
+
+	return events, count, nil
+}
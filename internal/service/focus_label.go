@@ -0,0 +1,149 @@
+package service
+
+import (
+	"attune/internal/models"
+	"attune/internal/storage"
+	"attune/pkg/apperrors"
+	"attune/pkg/clock"
+	"attune/pkg/logger"
+	"context"
+	"fmt"
+)
+
+var (
+	errMsgListLabels = "failed to list focus labels"
+	errMsgLabelStats = "failed to get focus label stats"
+	errMsgUseLabel   = "failed to use focus label %s"
+)
+
+type FocusLabelService interface {
+	Recent(ctx context.Context, vendorID string, limit uint64) ([]models.FocusLabel, error)
+	Get(ctx context.Context, vendorID, labelID string) (models.FocusLabel, error)
+	Stats(ctx context.Context, vendorID string) ([]models.FocusLabelStats, error)
+}
+
+type focusLabelService struct {
+	storages storage.Storages
+	logger   logger.Logger
+}
+
+func NewFocusLabelService(storages storage.Storages, logger logger.Logger) FocusLabelService {
+	return &focusLabelService{
+		storages: storages,
+		logger:   logger,
+	}
+}
+
+func (s *focusLabelService) Recent(ctx context.Context, vendorID string, limit uint64) ([]models.FocusLabel, error) {
+	const op = "focusLabelService.Recent"
+	log := s.logger.With("operation", op)
+
+	user, err := s.user(ctx, vendorID)
+	if err != nil {
+		return nil, err
+	}
+
+	labels, _, err := s.storages.FocusLabel.List(ctx, storage.ListFocusLabelFilter{
+		UserID: user.ID,
+		Limit:  limit,
+	})
+	if err != nil {
+		if !apperrors.IsCode(err, apperrors.NotFound) {
+			log.Error(ctx, errMsgListLabels, err)
+		}
+		return nil, err
+	}
+
+	return labels, nil
+}
+
+func (s *focusLabelService) Get(ctx context.Context, vendorID, labelID string) (models.FocusLabel, error) {
+	const op = "focusLabelService.Get"
+	log := s.logger.With("operation", op)
+
+	user, err := s.user(ctx, vendorID)
+	if err != nil {
+		return models.FocusLabel{}, err
+	}
+
+	labels, _, err := s.storages.FocusLabel.List(ctx, storage.ListFocusLabelFilter{
+		ID:     labelID,
+		UserID: user.ID,
+	})
+	if err != nil {
+		if !apperrors.IsCode(err, apperrors.NotFound) {
+			log.Error(ctx, errMsgListLabels, err)
+		}
+		return models.FocusLabel{}, err
+	}
+
+	return labels[0], nil
+}
+
+func (s *focusLabelService) Stats(ctx context.Context, vendorID string) ([]models.FocusLabelStats, error) {
+	const op = "focusLabelService.Stats"
+	log := s.logger.With("operation", op)
+
+	user, err := s.user(ctx, vendorID)
+	if err != nil {
+		return nil, err
+	}
+
+	stats, err := s.storages.FocusLabel.Stats(ctx, storage.FocusLabelStatsFilter{
+		UserID: user.ID,
+	})
+	if err != nil {
+		if !apperrors.IsCode(err, apperrors.NotFound) {
+			log.Error(ctx, errMsgLabelStats, err)
+		}
+		return nil, err
+	}
+
+	return stats, nil
+}
+
+func (s *focusLabelService) user(ctx context.Context, vendorID string) (models.User, error) {
+	users, _, err := s.storages.User.List(ctx, storage.ListUserFilter{
+		VendorID: vendorID,
+	})
+	if err != nil {
+		return models.User{}, apperrors.NewInternal().WithDescriptionAndCause(fmt.Sprintf(errMsgListUsers, vendorID), err)
+	}
+
+	return users[0], nil
+}
+
+func useFocusLabel(
+	ctx context.Context,
+	storages storage.Storages,
+	clk clock.Clock,
+	userID, name string,
+) (models.FocusLabel, error) {
+	label, err := models.NewFocusLabel(userID, name, clk)
+	if err != nil {
+		return models.FocusLabel{}, err
+	}
+
+	err = storages.FocusLabel.Create(ctx, label)
+	if err == nil {
+		return label, nil
+	}
+	if !apperrors.IsCode(err, apperrors.Conflict) {
+		return models.FocusLabel{}, apperrors.NewInternal().WithDescriptionAndCause(fmt.Sprintf(errMsgUseLabel, name), err)
+	}
+
+	labels, _, err := storages.FocusLabel.List(ctx, storage.ListFocusLabelFilter{
+		UserID: userID,
+		Name:   label.Name,
+	})
+	if err != nil {
+		return models.FocusLabel{}, apperrors.NewInternal().WithDescriptionAndCause(fmt.Sprintf(errMsgUseLabel, name), err)
+	}
+	existing := labels[0]
+
+	if err := storages.FocusLabel.Touch(ctx, existing.ID, clk.Now()); err != nil {
+		return models.FocusLabel{}, apperrors.NewInternal().WithDescriptionAndCause(fmt.Sprintf(errMsgUseLabel, name), err)
+	}
+
+	return existing, nil
+}
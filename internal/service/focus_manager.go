@@ -1,186 +1,995 @@
 package service
 
 import (
-	"log"
+	"context"
 	"sync"
 	"time"
 
 	"attune/internal/api"
+	"attune/internal/dto"
 	"attune/internal/models"
 	"attune/internal/storage"
 	"attune/pkg/apperrors"
 	"attune/pkg/cache"
+	"attune/pkg/clock"
+	"attune/pkg/logger"
 )
 
 const (
 	cacheTTLWindow = 3 * time.Minute
+	reopenWindow   = 10 * time.Minute
 )
 
 type FocusSessionManager interface {
-	Start(session models.FocusSession, duration time.Duration)
-	Pause(userID string) error
-	Resume(userID string) error
-	Stop(userID string) error
+	Start(session models.FocusSession, duration time.Duration, source models.FocusSessionEventSource) error
+	StartLinked(sessions []models.FocusSession, duration time.Duration, source models.FocusSessionEventSource) error
+	Pause(userID string, source models.FocusSessionEventSource) error
+	Resume(userID string, source models.FocusSessionEventSource) error
+	Stop(userID string, source models.FocusSessionEventSource) error
+	SkipBreak(userID string, source models.FocusSessionEventSource) error
+	Extend(userID string, delta time.Duration, source models.FocusSessionEventSource) error
+	State(userID string) (FocusSessionState, error)
+	Restore(ctx context.Context) error
 	GracefulShutdown()
 }
 
+type FocusSessionManagerConfig struct {
+	Milestones []models.FocusMilestone
+	Location   *time.Location
+	MaxPause   time.Duration
+}
+
+type FocusSessionState struct {
+	SessionID string
+	RoomID    string
+	Length    time.Duration
+	Remaining time.Duration
+	Paused    bool
+	Break     bool
+	Pomodoro  *models.PomodoroProgress
+}
+
 type focusSessionManager struct {
-	storages storage.Storages
-	cache    cache.Cache
-	apiCh    chan<- api.Trigger
+	storages     storage.Storages
+	events       FocusSessionEventService
+	achievements AchievementService
+	cache        cache.Cache
+	apiCh        chan<- api.Trigger
+	logger       logger.Logger
+	clock        clock.Clock
+	config       FocusSessionManagerConfig
+	shutdownCh   chan struct{}
+	shutdownOnce sync.Once
+	// startMu serializes the paths that put a new session into the cache.
+	startMu sync.Mutex
 }
 
 type sessionData struct {
 	mu        sync.Mutex
 	session   models.FocusSession
-	timer     *time.Timer
+	timer     clock.Timer
 	remaining time.Duration
 	lastStart time.Time
 	paused    bool
+	stopped   bool
 	pauseCh   chan struct{}
 	stopCh    chan struct{}
 }
 
+type pendingMilestone struct {
+	milestone models.FocusMilestone
+	timer     clock.Timer
+}
+
+func (p *pendingMilestone) C() <-chan time.Time {
+	if p == nil {
+		return nil
+	}
+
+	return p.timer.C()
+}
+
+func (p *pendingMilestone) stop() {
+	if p != nil {
+		p.timer.Stop()
+	}
+}
+
 func NewFocusSessionManager(
 	storages storage.Storages,
+	events FocusSessionEventService,
+	achievements AchievementService,
 	c cache.Cache,
 	apiCh chan<- api.Trigger,
+	logger logger.Logger,
+	clk clock.Clock,
+	config FocusSessionManagerConfig,
 ) FocusSessionManager {
-	return &focusSessionManager{
-		storages: storages,
-		cache:    c,
-		apiCh:    apiCh,
+	if config.Location == nil {
+		config.Location = time.UTC
 	}
+
+	m := &focusSessionManager{
+		storages:     storages,
+		events:       events,
+		achievements: achievements,
+		cache:        c,
+		apiCh:        apiCh,
+		logger:       logger,
+		clock:        clk,
+		config:       config,
+		shutdownCh:   make(chan struct{}),
+	}
+	c.OnEvict(func(_ string, value any) {
+		if data, ok := value.(*sessionData); ok {
+			// The evicting caller may hold data.mu, so the session is finished separately.
+			go m.abandonEvicted(data)
+		}
+	})
+
+	return m
 }
 
-func (m *focusSessionManager) Start(session models.FocusSession, duration time.Duration) {
-	data := &sessionData{
+func newSessionData(session models.FocusSession) *sessionData {
+	return &sessionData{
 		session:   session,
-		timer:     time.NewTimer(duration),
-		paused:    false,
-		remaining: duration,
-		lastStart: time.Now(),
+		remaining: session.Remaining,
+		lastStart: session.LastStartedAt,
+		paused:    session.Paused,
 		pauseCh:   make(chan struct{}),
 		stopCh:    make(chan struct{}),
 	}
+}
 
-	go m.cache.SetWithTTL(session.UserID, data, duration+cacheTTLWindow)
-	go m.track(data)
+func (d *sessionData) syncSession() {
+	d.session.Remaining = d.remaining
+	d.session.Paused = d.paused
+	d.session.LastStartedAt = d.lastStart
 }
 
-func (m *focusSessionManager) Pause(userID string) error {
-	v, ok := m.cache.Get(userID)
-	if !ok {
-		return apperrors.NewNotFound().WithDescription("session not found")
+func (d *sessionData) elapse(now time.Time) {
+	elapsed := now.Sub(d.lastStart)
+	if elapsed > d.remaining {
+		d.remaining = 0
+	} else {
+		d.remaining -= elapsed
 	}
-	data, ok := v.(*sessionData)
-	if !ok {
-		return apperrors.NewInternal().WithDescription("invalid session data type")
+}
+
+func (d *sessionData) expired(now time.Time) bool {
+	return !d.paused && now.Sub(d.lastStart) >= d.remaining
+}
+
+func (d *sessionData) phaseLength() time.Duration {
+	if d.session.Pomodoro == nil {
+		return d.session.PlannedDuration
+	}
+	if d.session.Pomodoro.IsBreak() {
+		return 0
+	}
+
+	return d.session.Pomodoro.PhaseDuration
+}
+
+func (d *sessionData) pomodoro() *models.PomodoroProgress {
+	if d.session.Pomodoro == nil {
+		return nil
+	}
+
+	progress := *d.session.Pomodoro
+	return &progress
+}
+
+func (m *focusSessionManager) Start(
+	session models.FocusSession,
+	duration time.Duration,
+	source models.FocusSessionEventSource,
+) error {
+	m.startMu.Lock()
+	defer m.startMu.Unlock()
+
+	if m.isRunning(session.UserID) {
+		return apperrors.NewConflict().WithDescription(errMsgSessionRunning)
+	}
+
+	if session.LastStartedAt.IsZero() {
+		session.LastStartedAt = m.clock.Now()
+	}
+	m.startSession(session, duration, source)
+
+	return nil
+}
+
+// Linked sessions share their start time so they end together.
+func (m *focusSessionManager) StartLinked(
+	sessions []models.FocusSession,
+	duration time.Duration,
+	source models.FocusSessionEventSource,
+) error {
+	m.startMu.Lock()
+	defer m.startMu.Unlock()
+
+	for _, session := range sessions {
+		if m.isRunning(session.UserID) {
+			return apperrors.NewConflict().WithDescription(errMsgSessionRunning)
+		}
+	}
+
+	now := m.clock.Now()
+	for _, session := range sessions {
+		session.LastStartedAt = now
+		m.startSession(session, duration, source)
+	}
+
+	return nil
+}
+
+// The caller must hold startMu.
+func (m *focusSessionManager) startSession(
+	session models.FocusSession,
+	duration time.Duration,
+	source models.FocusSessionEventSource,
+) {
+	session.Remaining = duration
+	data := newSessionData(session)
+
+	data.mu.Lock()
+	defer data.mu.Unlock()
+
+	m.recordEvent(session, models.FocusSessionEventTypeStart, source, session.LastStartedAt)
+
+	m.cacheSession(data)
+	m.startTimer(data)
+}
+
+func (m *focusSessionManager) Pause(userID string, source models.FocusSessionEventSource) error {
+	data, err := m.getSessionData(userID)
+	if err != nil {
+		return err
 	}
 
 	data.mu.Lock()
 	defer data.mu.Unlock()
 
+	if data.stopped {
+		return apperrors.NewNotFound().WithDescription("session not found")
+	}
 	if data.paused {
 		return apperrors.NewBadRequest().WithDescription("session is already paused")
 	}
+	if data.session.RoomID != "" {
+		return apperrors.NewBadRequest().WithDescription(errMsgRoomSessionLocked)
+	}
 
-	elapsed := time.Since(data.lastStart)
-	if elapsed > data.remaining {
-		data.remaining = 0
-	} else {
-		data.remaining -= elapsed
+	now := m.clock.Now()
+	if data.expired(now) {
+		// The timer is due but has not been handled yet, so there is nothing left to pause.
+		close(data.pauseCh)
+		m.expire(data, now)
+		if data.stopped {
+			return apperrors.NewNotFound().WithDescription("session not found")
+		}
 	}
 
+	data.elapse(now)
 	data.paused = true
+	data.session.Pause(now)
 
 	close(data.pauseCh)
+	m.startPauseTimer(data, 0)
+
+	m.cacheSession(data)
+	m.persist(data)
+	m.recordEvent(data.session, models.FocusSessionEventTypePause, source, now)
 
 	return nil
 }
 
-func (m *focusSessionManager) Resume(userID string) error {
-	v, ok := m.cache.Get(userID)
-	if !ok {
-		return apperrors.NewNotFound().WithDescription("session not found")
-	}
-	data, ok := v.(*sessionData)
-	if !ok {
-		return apperrors.NewInternal().WithDescription("invalid session data type")
+func (m *focusSessionManager) Resume(userID string, source models.FocusSessionEventSource) error {
+	data, err := m.getSessionData(userID)
+	if err != nil {
+		return err
 	}
 
 	data.mu.Lock()
 	defer data.mu.Unlock()
 
+	if data.stopped {
+		return apperrors.NewNotFound().WithDescription("session not found")
+	}
 	if !data.paused {
 		return apperrors.NewBadRequest().WithDescription("session is not paused")
 	}
 
-	data.timer = time.NewTimer(data.remaining)
-	data.lastStart = time.Now()
+	now := m.clock.Now()
+	data.session.Resume(now)
+	data.lastStart = now
 	data.paused = false
-	data.pauseCh = make(chan struct{})
 
-	go m.track(data)
+	close(data.pauseCh)
+	m.cacheSession(data)
+	m.startTimer(data)
+	m.persist(data)
+	m.recordEvent(data.session, models.FocusSessionEventTypeResume, source, now)
+
+	return nil
+}
+
+func (m *focusSessionManager) Stop(userID string, source models.FocusSessionEventSource) error {
+	data, err := m.getSessionData(userID)
+	if err != nil {
+		return err
+	}
+
+	data.mu.Lock()
+	defer data.mu.Unlock()
+
+	if data.stopped {
+		return apperrors.NewNotFound().WithDescription("session not found")
+	}
+
+	now := m.clock.Now()
+	sessionStatus := models.FocusSessionStatusStopped
+	if data.session.Pomodoro == nil && data.expired(now) {
+		// The timer ran out before the stop arrived.
+		sessionStatus = models.FocusSessionStatusCompleted
+	}
+
+	m.recordEvent(data.session, models.FocusSessionEventTypeStop, source, now)
+	m.completeSession(data, sessionStatus, source)
+
+	return nil
+}
+
+func (m *focusSessionManager) SkipBreak(userID string, source models.FocusSessionEventSource) error {
+	data, err := m.getSessionData(userID)
+	if err != nil {
+		return err
+	}
+
+	data.mu.Lock()
+	defer data.mu.Unlock()
+
+	if data.stopped {
+		return apperrors.NewNotFound().WithDescription("session not found")
+	}
+	if data.session.Pomodoro == nil || !data.session.Pomodoro.IsBreak() {
+		return apperrors.NewBadRequest().WithDescription("no break in progress")
+	}
+
+	now := m.clock.Now()
+	if data.paused {
+		data.session.Resume(now)
+		data.paused = false
+	}
+	close(data.pauseCh)
+
+	m.recordEvent(data.session, models.FocusSessionEventTypeSkipBreak, source, now)
+	m.advancePhase(data, now)
+
+	return nil
+}
+
+// A session that completed moments ago is reopened with delta left on the timer.
+func (m *focusSessionManager) Extend(
+	userID string,
+	delta time.Duration,
+	source models.FocusSessionEventSource,
+) error {
+	data, err := m.getSessionData(userID)
+	if err != nil {
+		if apperrors.IsCode(err, apperrors.NotFound) {
+			return m.reopen(userID, delta, source)
+		}
+
+		return err
+	}
+
+	data.mu.Lock()
+	defer data.mu.Unlock()
+
+	if data.stopped {
+		return apperrors.NewNotFound().WithDescription("session not found")
+	}
+	if data.session.RoomID != "" {
+		return apperrors.NewBadRequest().WithDescription(errMsgRoomSessionLocked)
+	}
+	if data.session.IsBreak() {
+		return apperrors.NewBadRequest().WithDescription(errMsgBreakExtend)
+	}
+	if err := data.session.Extend(delta); err != nil {
+		return err
+	}
+
+	now := m.clock.Now()
+	if data.paused {
+		data.remaining += delta
+	} else {
+		data.elapse(now)
+		data.lastStart = now
+		data.remaining += delta
+
+		close(data.pauseCh)
+		m.startTimer(data)
+	}
+
+	m.cacheSession(data)
+	m.persist(data)
+	m.recordEvent(data.session, models.FocusSessionEventTypeExtend, source, now)
+
+	return nil
+}
+
+func (m *focusSessionManager) reopen(
+	userID string,
+	delta time.Duration,
+	source models.FocusSessionEventSource,
+) error {
+	m.startMu.Lock()
+	defer m.startMu.Unlock()
+
+	if m.isRunning(userID) {
+		return apperrors.NewConflict().WithDescription(errMsgSessionRunning)
+	}
+
+	ctx := context.Background()
+	sessions, _, err := m.storages.FocusSession.List(ctx, storage.ListFocusSessionFilter{
+		UserID:   userID,
+		NoBreaks: true,
+		Limit:    1,
+	})
+	if err != nil {
+		return err
+	}
+
+	session := sessions[0]
+	now := m.clock.Now()
+	if session.Status != models.FocusSessionStatusCompleted || now.Sub(session.EndedAt) > reopenWindow {
+		return apperrors.NewNotFound().WithDescription("session not found")
+	}
+	if session.RoomID != "" {
+		return apperrors.NewBadRequest().WithDescription(errMsgRoomSessionLocked)
+	}
+
+	users, _, err := m.storages.User.List(ctx, storage.ListUserFilter{
+		ID: userID,
+	})
+	if err != nil {
+		return err
+	}
+	session.VendorID = users[0].VendorID
+
+	if err := session.Extend(delta); err != nil {
+		return err
+	}
+	session.Reopen(delta, now)
+
+	data := newSessionData(session)
+
+	data.mu.Lock()
+	defer data.mu.Unlock()
+
+	m.cacheSession(data)
+	m.startTimer(data)
+	m.persist(data)
+	m.recordEvent(data.session, models.FocusSessionEventTypeExtend, source, now)
+
+	return nil
+}
+
+func (m *focusSessionManager) State(userID string) (FocusSessionState, error) {
+	data, err := m.getSessionData(userID)
+	if err != nil {
+		return FocusSessionState{}, err
+	}
+
+	data.mu.Lock()
+	defer data.mu.Unlock()
+
+	if data.stopped {
+		return FocusSessionState{}, apperrors.NewNotFound().WithDescription("session not found")
+	}
+
+	remaining := data.remaining
+	if !data.paused {
+		remaining -= m.clock.Since(data.lastStart)
+		if remaining < 0 {
+			remaining = 0
+		}
+	}
+
+	length := data.session.PlannedDuration
+	if data.session.Pomodoro != nil {
+		length = data.session.Pomodoro.PhaseDuration
+	}
+
+	return FocusSessionState{
+		SessionID: data.session.ID,
+		RoomID:    data.session.RoomID,
+		Length:    length,
+		Remaining: remaining,
+		Paused:    data.paused,
+		Break:     data.session.IsBreak(),
+		Pomodoro:  data.pomodoro(),
+	}, nil
+}
+
+func (m *focusSessionManager) Restore(ctx context.Context) error {
+	sessions, _, err := m.storages.FocusSession.List(ctx, storage.ListFocusSessionFilter{
+		Status: models.FocusSessionStatusActive,
+	})
+	if err != nil {
+		if apperrors.IsCode(err, apperrors.NotFound) {
+			return nil
+		}
+
+		return err
+	}
+
+	for _, session := range sessions {
+		if err := m.restoreSession(ctx, session); err != nil {
+			m.logger.Error(ctx, "failed to restore focus session", err, "sessionID", session.ID)
+		}
+	}
 
 	return nil
 }
 
-func (m *focusSessionManager) Stop(userID string) error {
+func (m *focusSessionManager) restoreSession(ctx context.Context, session models.FocusSession) error {
+	users, _, err := m.storages.User.List(ctx, storage.ListUserFilter{
+		ID: session.UserID,
+	})
+	if err != nil {
+		return err
+	}
+	session.VendorID = users[0].VendorID
+
+	data := newSessionData(session)
+
+	data.mu.Lock()
+	defer data.mu.Unlock()
+
+	if data.paused {
+		pausedFor := m.clock.Since(data.session.PausedAt)
+		if m.config.MaxPause > 0 && pausedFor >= m.config.MaxPause {
+			m.abandon(data)
+			return nil
+		}
+
+		m.cacheSession(data)
+		m.startPauseTimer(data, pausedFor)
+		return nil
+	}
+
+	// Replay the phases that ran out while the service was down.
+	now := m.clock.Now()
+	elapsed := now.Sub(data.lastStart)
+	for elapsed >= data.remaining {
+		elapsed -= data.remaining
+		data.remaining = 0
+
+		progress := data.session.Pomodoro
+		if progress == nil || !progress.Advance() {
+			m.completeSession(data, models.FocusSessionStatusCompleted, models.FocusSessionEventSourceSystem)
+			return nil
+		}
+		data.remaining = progress.PhaseDuration
+	}
+
+	data.remaining -= elapsed
+	data.lastStart = now
+
+	m.persist(data)
+
+	m.cacheSession(data)
+	m.startTimer(data)
+
+	return nil
+}
+
+func (m *focusSessionManager) isRunning(userID string) bool {
+	data, err := m.getSessionData(userID)
+	if err != nil {
+		return false
+	}
+
+	data.mu.Lock()
+	defer data.mu.Unlock()
+
+	return !data.stopped
+}
+
+func (m *focusSessionManager) getSessionData(userID string) (*sessionData, error) {
 	v, ok := m.cache.Get(userID)
 	if !ok {
-		return apperrors.NewNotFound().WithDescription("session not found")
+		return nil, apperrors.NewNotFound().WithDescription("session not found")
 	}
 	data, ok := v.(*sessionData)
 	if !ok {
-		return apperrors.NewInternal().WithDescription("invalid session data type")
+		return nil, apperrors.NewInternal().WithDescription("invalid session data type")
 	}
 
-	close(data.stopCh)
-	go m.cache.Delete(userID)
+	return data, nil
+}
 
-	return nil
+// Paused sessions have no timer and stay cached until they are resumed or stopped.
+func (m *focusSessionManager) cacheSession(data *sessionData) {
+	var ttl time.Duration
+	if !data.paused {
+		ttl = data.remaining + cacheTTLWindow
+	}
+
+	m.cache.SetWithTTL(data.session.UserID, data, ttl)
 }
 
-func (m *focusSessionManager) track(data *sessionData) {
-	waitForTimerStop := func() {
-		if !data.timer.Stop() {
-			<-data.timer.C
-		}
+func (m *focusSessionManager) uncacheSession(data *sessionData) {
+	if v, ok := m.cache.Get(data.session.UserID); ok && v == data {
+		m.cache.Delete(data.session.UserID)
 	}
+}
 
+// The caller must hold data.mu.
+func (m *focusSessionManager) startTimer(data *sessionData) {
+	data.timer = m.clock.NewTimer(data.remaining)
+	data.pauseCh = make(chan struct{})
+
+	go m.track(data, data.timer, data.pauseCh)
+}
+
+// The caller must hold data.mu.
+func (m *focusSessionManager) startPauseTimer(data *sessionData, pausedFor time.Duration) {
+	data.pauseCh = make(chan struct{})
+	if m.config.MaxPause <= 0 {
+		return
+	}
+
+	go m.watchPause(data, m.clock.NewTimer(m.config.MaxPause-pausedFor), data.pauseCh)
+}
+
+func (m *focusSessionManager) watchPause(data *sessionData, timer clock.Timer, pauseCh chan struct{}) {
 	select {
-	case <-data.timer.C:
-		m.completeSession(data, models.FocusSessionStatusCompleted)
-	case <-data.pauseCh:
-		waitForTimerStop()
+	case <-timer.C():
+		data.mu.Lock()
+		defer data.mu.Unlock()
+
+		// The session was resumed or stopped while the timer was firing.
+		if data.stopped || !data.paused || data.pauseCh != pauseCh {
+			return
+		}
+		m.abandon(data)
+	case <-pauseCh:
+		timer.Stop()
 	case <-data.stopCh:
-		waitForTimerStop()
-		m.completeSession(data, models.FocusSessionStatusStopped)
+		timer.Stop()
+	case <-m.shutdownCh:
+		timer.Stop()
 	}
 }
 
-func (m *focusSessionManager) completeSession(data *sessionData, sessionStatus models.FocusSessionStatus) {
-	data.session.EndedAt = time.Now()
+func (m *focusSessionManager) abandonEvicted(data *sessionData) {
+	data.mu.Lock()
+	defer data.mu.Unlock()
 
-	if data.session.VendorID != "" {
-		go func() {
-			m.apiCh <- api.Trigger{
-				VendorID:           data.session.VendorID,
-				Type:               api.TriggerTypeFinishSession,
-				FocusSessionStatus: sessionStatus,
-			}
-		}()
+	if data.stopped {
+		return
+	}
+
+	select {
+	case <-m.shutdownCh:
+		// Left active in storage for Restore.
+		return
+	default:
 	}
 
-	go m.cache.Delete(data.session.UserID)
+	m.logger.Warn(context.Background(), "focus session evicted from cache", "sessionID", data.session.ID)
+	m.abandon(data)
 }
 
-func (m *focusSessionManager) GracefulShutdown() {
-	keys := m.cache.Keys()
-	for _, userID := range keys {
-		if err := m.Stop(userID); err != nil {
-			log.Printf("failed to stop session for user %s: %v", userID, err)
+// The caller must hold data.mu.
+func (m *focusSessionManager) abandon(data *sessionData) {
+	m.recordEvent(data.session, models.FocusSessionEventTypeAbandon, models.FocusSessionEventSourceSystem, m.clock.Now())
+	m.completeSession(data, models.FocusSessionStatusAbandoned, models.FocusSessionEventSourceSystem)
+}
+
+func (m *focusSessionManager) track(data *sessionData, timer clock.Timer, pauseCh chan struct{}) {
+	milestone := m.armMilestone(data, pauseCh)
+	defer func() {
+		milestone.stop()
+	}()
+
+	for {
+		select {
+		case <-timer.C():
+			m.onTimerFired(data, pauseCh)
+			return
+		case <-milestone.C():
+			m.onMilestone(data, milestone.milestone, pauseCh)
+			milestone = m.armMilestone(data, pauseCh)
+		case <-pauseCh:
+			timer.Stop()
+			return
+		case <-data.stopCh:
+			timer.Stop()
+			return
+		case <-m.shutdownCh:
+			// The persisted state stays active so the session is restored on the next start.
+			timer.Stop()
+			return
+		}
+	}
+}
+
+func (m *focusSessionManager) armMilestone(data *sessionData, pauseCh chan struct{}) *pendingMilestone {
+	data.mu.Lock()
+	defer data.mu.Unlock()
+
+	if data.stopped || data.paused || data.pauseCh != pauseCh || data.session.IsBreak() {
+		return nil
+	}
+
+	length := data.phaseLength()
+	remaining := data.remaining - m.clock.Since(data.lastStart)
+
+	var next *models.FocusMilestone
+	var nextAt time.Duration
+	for i, milestone := range m.config.Milestones {
+		at := milestone.RemainingAt(length)
+		if at <= 0 || at >= length || at >= remaining {
+			continue
+		}
+		if next == nil || at > nextAt {
+			next, nextAt = &m.config.Milestones[i], at
 		}
 	}
+	if next == nil {
+		return nil
+	}
+
+	return &pendingMilestone{
+		milestone: *next,
+		timer:     m.clock.NewTimer(remaining - nextAt),
+	}
+}
+
+func (m *focusSessionManager) onMilestone(data *sessionData, milestone models.FocusMilestone, pauseCh chan struct{}) {
+	data.mu.Lock()
+	defer data.mu.Unlock()
+
+	if data.stopped || data.paused || data.pauseCh != pauseCh {
+		return
+	}
+
+	triggerType := api.TriggerTypeMilestoneTimeLeft
+	if milestone.Kind == models.FocusMilestoneKindProgress {
+		triggerType = api.TriggerTypeMilestoneProgress
+	}
+
+	m.sendTrigger(api.Trigger{
+		VendorID:  data.session.VendorID,
+		SessionID: data.session.ID,
+		Type:      triggerType,
+		Milestone: milestone,
+		Remaining: data.remaining - m.clock.Since(data.lastStart),
+		Pomodoro:  data.pomodoro(),
+	})
+}
+
+func (m *focusSessionManager) onTimerFired(data *sessionData, pauseCh chan struct{}) {
+	data.mu.Lock()
+	defer data.mu.Unlock()
+
+	// The session was paused, stopped or re-armed while the timer was firing.
+	if data.stopped || data.paused || data.pauseCh != pauseCh {
+		return
+	}
+
+	m.expire(data, m.clock.Now())
+}
+
+// The caller must hold data.mu.
+func (m *focusSessionManager) expire(data *sessionData, now time.Time) {
+	data.remaining = 0
+	if data.session.Pomodoro != nil {
+		m.advancePhase(data, now)
+		return
+	}
+
+	m.completeSession(data, models.FocusSessionStatusCompleted, models.FocusSessionEventSourceSystem)
+}
+
+// The caller must hold data.mu.
+func (m *focusSessionManager) advancePhase(data *sessionData, now time.Time) {
+	progress := data.session.Pomodoro
+	if !progress.Advance() {
+		m.completeSession(data, models.FocusSessionStatusCompleted, models.FocusSessionEventSourceSystem)
+		return
+	}
+
+	data.remaining = progress.PhaseDuration
+	data.lastStart = now
+
+	m.cacheSession(data)
+	m.startTimer(data)
+	m.persist(data)
+
+	triggerType, eventType := api.TriggerTypeBreakEnd, models.FocusSessionEventTypeBreakEnd
+	if progress.IsBreak() {
+		triggerType, eventType = api.TriggerTypeBreakStart, models.FocusSessionEventTypeBreakStart
+	}
+
+	m.recordEvent(data.session, eventType, models.FocusSessionEventSourceSystem, now)
+	m.sendTrigger(api.Trigger{
+		VendorID:  data.session.VendorID,
+		SessionID: data.session.ID,
+		Type:      triggerType,
+		Pomodoro:  data.pomodoro(),
+	})
+}
+
+// The caller must hold data.mu.
+func (m *focusSessionManager) completeSession(
+	data *sessionData,
+	sessionStatus models.FocusSessionStatus,
+	source models.FocusSessionEventSource,
+) {
+	now := m.clock.Now()
+	if sessionStatus == models.FocusSessionStatusCompleted {
+		data.remaining = 0
+	} else if !data.paused {
+		data.elapse(now)
+	}
+
+	data.stopped = true
+	data.session.Finish(sessionStatus, data.remaining, now)
+	data.paused = false
+	close(data.stopCh)
+
+	m.persist(data)
+	m.uncacheSession(data)
+
+	if sessionStatus == models.FocusSessionStatusCompleted {
+		m.recordEvent(data.session, models.FocusSessionEventTypeComplete, models.FocusSessionEventSourceSystem, now)
+	}
+
+	triggerType := api.TriggerTypeFinishSession
+	switch {
+	case data.session.IsBreak():
+		triggerType = api.TriggerTypeBreakFinished
+	case sessionStatus == models.FocusSessionStatusAbandoned:
+		triggerType = api.TriggerTypeSessionAbandoned
+	case data.session.Pomodoro != nil && sessionStatus == models.FocusSessionStatusCompleted:
+		triggerType = api.TriggerTypeCycleComplete
+	}
+
+	var goal *models.DailyFocusGoalProgress
+	if !data.session.IsBreak() {
+		goal = m.dailyGoalProgress(data.session.UserID, now)
+	}
+	triggers := []api.Trigger{{
+		VendorID:           data.session.VendorID,
+		SessionID:          data.session.ID,
+		RoomID:             data.session.RoomID,
+		Type:               triggerType,
+		FocusSessionStatus: sessionStatus,
+		Source:             source,
+		FocusedDuration:    data.session.FocusedDuration,
+		RestDuration:       data.session.RestDuration,
+		PausedDuration:     data.session.PausedDuration,
+		Pomodoro:           data.pomodoro(),
+		DailyGoal:          goal,
+	}}
+	goalReached := goal != nil && goal.Reached() && m.markGoalReached(data.session.UserID, now)
+	if goalReached {
+		triggers = append(triggers, api.Trigger{
+			VendorID:  data.session.VendorID,
+			SessionID: data.session.ID,
+			Type:      api.TriggerTypeGoalReached,
+			DailyGoal: goal,
+		})
+	}
+	if badges := m.awardBadges(data.session, goalReached); len(badges) > 0 {
+		triggers = append(triggers, badgesTrigger(data.session.VendorID, badges))
+	}
+
+	m.sendTrigger(triggers...)
+}
+
+func (m *focusSessionManager) dailyGoalProgress(userID string, now time.Time) *models.DailyFocusGoalProgress {
+	ctx := context.Background()
+
+	settings, err := m.storages.UserSettings.List(ctx, storage.ListUserSettingsFilter{
+		UserID: userID,
+	})
+	if err != nil {
+		if !apperrors.IsCode(err, apperrors.NotFound) {
+			m.logger.Error(ctx, "failed to list user settings", err, "userID", userID)
+		}
+		return nil
+	}
+	if settings[0].DailyFocusGoal == 0 {
+		return nil
+	}
+
+	sessions, _, err := m.storages.FocusSession.List(ctx, storage.ListFocusSessionFilter{
+		UserID:      userID,
+		StartedFrom: localDayStart(now, m.config.Location),
+	})
+	if err != nil && !apperrors.IsCode(err, apperrors.NotFound) {
+		m.logger.Error(ctx, "failed to list today's focus sessions", err, "userID", userID)
+		return nil
+	}
+
+	progress := &models.DailyFocusGoalProgress{Goal: settings[0].DailyFocusGoal}
+	for _, session := range sessions {
+		progress.Focused += session.FocusedDuration
+	}
+
+	return progress
+}
+
+func (m *focusSessionManager) markGoalReached(userID string, now time.Time) bool {
+	reached, err := m.storages.UserSettings.MarkGoalReached(context.Background(), userID, now, localDayStart(now, m.config.Location))
+	if err != nil {
+		m.logger.Error(context.Background(), "failed to mark daily goal reached", err, "userID", userID)
+		return false
+	}
+
+	return reached
+}
+
+func (m *focusSessionManager) awardBadges(session models.FocusSession, goalReached bool) []models.Badge {
+	badges, err := m.achievements.RecordSession(context.Background(), session, goalReached)
+	if err != nil {
+		m.logger.Error(context.Background(), "failed to award badges", err, "sessionID", session.ID)
+	}
+
+	return badges
+}
+
+func (m *focusSessionManager) sendTrigger(triggers ...api.Trigger) {
+	if len(triggers) == 0 || triggers[0].VendorID == "" {
+		return
+	}
+
+	go func() {
+		for _, trigger := range triggers {
+			m.apiCh <- trigger
+		}
+	}()
+}
+
+// The caller must hold data.mu.
+func (m *focusSessionManager) persist(data *sessionData) {
+	data.syncSession()
+	data.session.UpdatedAt = m.clock.Now()
+
+	if err := m.storages.FocusSession.Update(context.Background(), data.session); err != nil {
+		m.logger.Error(context.Background(), "failed to persist focus session", err, "sessionID", data.session.ID)
+	}
+}
+
+func (m *focusSessionManager) recordEvent(
+	session models.FocusSession,
+	eventType models.FocusSessionEventType,
+	source models.FocusSessionEventSource,
+	occurredAt time.Time,
+) {
+	err := m.events.Record(context.Background(), dto.CreateFocusSessionEventRequest{
+		SessionID:  session.ID,
+		UserID:     session.UserID,
+		Type:       eventType,
+		Source:     source,
+		OccurredAt: occurredAt,
+	})
+	if err != nil {
+		m.logger.Error(context.Background(), "failed to record focus session event", err, "sessionID", session.ID, "type", eventType)
+	}
+}
+
+// Restore picks the halted sessions up on the next start.
+func (m *focusSessionManager) GracefulShutdown() {
+	m.shutdownOnce.Do(func() {
+		close(m.shutdownCh)
+	})
+	m.logger.Info(context.Background(), "focus session timers halted", "sessions", len(m.cache.Keys()))
 }
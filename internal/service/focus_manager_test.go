@@ -0,0 +1,712 @@
+package service
+
+import (
+	"context"
+	"sort"
+	"sync"
+	"testing"
+	"time"
+
+	"attune/internal/api"
+	"attune/internal/dto"
+	"attune/internal/models"
+	"attune/internal/storage"
+	"attune/pkg/apperrors"
+	"attune/pkg/cache"
+	"attune/pkg/clock"
+	"attune/pkg/logger"
+)
+
+const (
+	testUserID   = "user-1"
+	testVendorID = "42"
+
+	// triggerWait bounds how long a test waits for the manager goroutines in real time.
+	triggerWait = time.Second
+)
+
+type fakeFocusSessionStorage struct {
+	mu       sync.Mutex
+	sessions map[string]models.FocusSession
+}
+
+func (s *fakeFocusSessionStorage) Create(_ context.Context, session models.FocusSession) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.sessions[session.ID] = session
+	return nil
+}
+
+func (s *fakeFocusSessionStorage) List(
+	_ context.Context,
+	filter storage.ListFocusSessionFilter,
+) ([]models.FocusSession, int64, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	var sessions []models.FocusSession
+	for _, session := range s.sessions {
+		if filter.ID != "" && session.ID != filter.ID {
+			continue
+		}
+		if filter.UserID != "" && session.UserID != filter.UserID {
+			continue
+		}
+		if filter.Status != "" && session.Status != filter.Status {
+			continue
+		}
+		if filter.NoBreaks && session.IsBreak() {
+			continue
+		}
+		sessions = append(sessions, session)
+	}
+	if len(sessions) == 0 {
+		return nil, 0, apperrors.NewNotFound().WithDescription("focus sessions not found")
+	}
+
+	sort.Slice(sessions, func(i, j int) bool {
+		return sessions[i].CreatedAt.After(sessions[j].CreatedAt)
+	})
+	if filter.Limit > 0 && uint64(len(sessions)) > filter.Limit {
+		sessions = sessions[:filter.Limit]
+	}
+
+	return sessions, int64(len(sessions)), nil
+}
+
+func (s *fakeFocusSessionStorage) Update(_ context.Context, session models.FocusSession) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.sessions[session.ID] = session
+	return nil
+}
+
+func (s *fakeFocusSessionStorage) Delete(_ context.Context, id string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	delete(s.sessions, id)
+	return nil
+}
+
+func (s *fakeFocusSessionStorage) Totals(_ context.Context, userID string) (int, time.Duration, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	var (
+		sessions int
+		focused  time.Duration
+	)
+	for _, session := range s.sessions {
+		if session.UserID != userID || session.Status == models.FocusSessionStatusActive || session.IsBreak() {
+			continue
+		}
+		focused += session.FocusedDuration
+		if session.Status == models.FocusSessionStatusCompleted {
+			sessions++
+		}
+	}
+
+	return sessions, focused, nil
+}
+
+func (s *fakeFocusSessionStorage) get(id string) models.FocusSession {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	return s.sessions[id]
+}
+
+type fakeUserStorage struct {
+	storage.UserStorage
+}
+
+func (fakeUserStorage) List(_ context.Context, _ storage.ListUserFilter) ([]models.User, int64, error) {
+	return []models.User{{ID: testUserID, VendorID: testVendorID}}, 1, nil
+}
+
+type fakeUserSettingsStorage struct {
+	storage.UserSettingsStorage
+	mu            sync.Mutex
+	goal          time.Duration
+	goalReachedAt time.Time
+}
+
+func (s *fakeUserSettingsStorage) List(
+	_ context.Context,
+	_ storage.ListUserSettingsFilter,
+) ([]models.UserSettings, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	return []models.UserSettings{{
+		UserID:         testUserID,
+		DailyFocusGoal: s.goal,
+		GoalReachedAt:  s.goalReachedAt,
+	}}, nil
+}
+
+func (s *fakeUserSettingsStorage) MarkGoalReached(_ context.Context, _ string, at, dayStart time.Time) (bool, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if !s.goalReachedAt.Before(dayStart) {
+		return false, nil
+	}
+	s.goalReachedAt = at
+	return true, nil
+}
+
+type fakeEventService struct {
+	mu     sync.Mutex
+	events []models.FocusSessionEventType
+}
+
+func (s *fakeEventService) Record(_ context.Context, input dto.CreateFocusSessionEventRequest) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.events = append(s.events, input.Type)
+	return nil
+}
+
+func (s *fakeEventService) List(
+	_ context.Context,
+	_ storage.ListFocusSessionEventFilter,
+) ([]models.FocusSessionEvent, int64, error) {
+	return nil, 0, nil
+}
+
+func (s *fakeEventService) count(eventType models.FocusSessionEventType) int {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	n := 0
+	for _, e := range s.events {
+		if e == eventType {
+			n++
+		}
+	}
+	return n
+}
+
+// fakeAchievementService awards the pending badges to the next session it records.
+type fakeAchievementService struct {
+	AchievementService
+	mu          sync.Mutex
+	pending     []models.Badge
+	goalReached []bool
+}
+
+func (s *fakeAchievementService) RecordSession(
+	_ context.Context,
+	_ models.FocusSession,
+	goalReached bool,
+) ([]models.Badge, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.goalReached = append(s.goalReached, goalReached)
+	badges := s.pending
+	s.pending = nil
+	return badges, nil
+}
+
+type nopLogger struct{}
+
+func (l nopLogger) With(...interface{}) logger.Logger           { return l }
+func (nopLogger) Debug(context.Context, string, ...interface{}) {}
+func (nopLogger) Info(context.Context, string, ...interface{})  {}
+func (nopLogger) Warn(context.Context, string, ...interface{})  {}
+func (nopLogger) Error(context.Context, string, ...interface{}) {}
+
+type managerHarness struct {
+	manager      FocusSessionManager
+	clock        *clock.FakeClock
+	sessions     *fakeFocusSessionStorage
+	settings     *fakeUserSettingsStorage
+	events       *fakeEventService
+	achievements *fakeAchievementService
+	apiCh        chan api.Trigger
+}
+
+func newManagerHarness(t *testing.T) *managerHarness {
+	t.Helper()
+
+	return newManagerHarnessWithConfig(t, FocusSessionManagerConfig{})
+}
+
+func newManagerHarnessWithConfig(t *testing.T, config FocusSessionManagerConfig) *managerHarness {
+	t.Helper()
+
+	h := &managerHarness{
+		clock:        clock.NewFakeClock(time.Date(2026, 1, 1, 9, 0, 0, 0, time.UTC)),
+		sessions:     &fakeFocusSessionStorage{sessions: make(map[string]models.FocusSession)},
+		settings:     &fakeUserSettingsStorage{},
+		events:       &fakeEventService{},
+		achievements: &fakeAchievementService{},
+		apiCh:        make(chan api.Trigger, 16),
+	}
+	storages := storage.Storages{
+		User:         fakeUserStorage{},
+		UserSettings: h.settings,
+		FocusSession: h.sessions,
+	}
+	h.manager = NewFocusSessionManager(
+		storages,
+		h.events,
+		h.achievements,
+		cache.NewCache(cache.WithClock(h.clock)),
+		h.apiCh,
+		nopLogger{},
+		h.clock,
+		config,
+	)
+	t.Cleanup(h.manager.GracefulShutdown)
+
+	return h
+}
+
+func (h *managerHarness) start(t *testing.T, duration time.Duration) models.FocusSession {
+	t.Helper()
+
+	session, err := models.NewFocusSession(testUserID, duration, h.clock)
+	if err != nil {
+		t.Fatalf("new focus session: %v", err)
+	}
+	session.VendorID = testVendorID
+	if err := h.sessions.Create(context.Background(), session); err != nil {
+		t.Fatalf("create focus session: %v", err)
+	}
+
+	if err := h.manager.Start(session, duration, models.FocusSessionEventSourceTelegram); err != nil {
+		t.Fatalf("start focus session: %v", err)
+	}
+
+	return session
+}
+
+func (h *managerHarness) startBreak(t *testing.T, duration time.Duration) models.FocusSession {
+	t.Helper()
+
+	session, err := models.NewBreakSession(testUserID, duration, h.clock)
+	if err != nil {
+		t.Fatalf("new break session: %v", err)
+	}
+	session.VendorID = testVendorID
+	if err := h.sessions.Create(context.Background(), session); err != nil {
+		t.Fatalf("create break session: %v", err)
+	}
+	if err := h.manager.Start(session, duration, models.FocusSessionEventSourceTelegram); err != nil {
+		t.Fatalf("start break session: %v", err)
+	}
+
+	return session
+}
+
+func (h *managerHarness) waitTrigger(t *testing.T) api.Trigger {
+	t.Helper()
+
+	select {
+	case trigger := <-h.apiCh:
+		return trigger
+	case <-time.After(triggerWait):
+		t.Fatal("no trigger was sent")
+		return api.Trigger{}
+	}
+}
+
+func (h *managerHarness) expectNoTrigger(t *testing.T) {
+	t.Helper()
+
+	select {
+	case trigger := <-h.apiCh:
+		t.Fatalf("unexpected trigger %q", trigger.Type)
+	case <-time.After(50 * time.Millisecond):
+	}
+}
+
+func TestFocusSessionManager_PauseAfterExpiry(t *testing.T) {
+	h := newManagerHarness(t)
+	session := h.start(t, 25*time.Minute)
+
+	// Whether or not the timer goroutine got there first, the session is over.
+	h.clock.Advance(25 * time.Minute)
+	err := h.manager.Pause(testUserID, models.FocusSessionEventSourceTelegram)
+	if !apperrors.IsCode(err, apperrors.NotFound) {
+		t.Fatalf("pause after expiry: got %v, want not found", err)
+	}
+
+	trigger := h.waitTrigger(t)
+	if trigger.Type != api.TriggerTypeFinishSession {
+		t.Fatalf("trigger type: got %q, want %q", trigger.Type, api.TriggerTypeFinishSession)
+	}
+	if trigger.FocusSessionStatus != models.FocusSessionStatusCompleted {
+		t.Fatalf("trigger status: got %q, want completed", trigger.FocusSessionStatus)
+	}
+	h.expectNoTrigger(t)
+
+	stored := h.sessions.get(session.ID)
+	if stored.Paused || stored.Status != models.FocusSessionStatusCompleted {
+		t.Fatalf("stored session: paused %v, status %q", stored.Paused, stored.Status)
+	}
+	if stored.FocusedDuration != 25*time.Minute {
+		t.Fatalf("focused duration: got %s, want 25m", stored.FocusedDuration)
+	}
+	if n := h.events.count(models.FocusSessionEventTypePause); n != 0 {
+		t.Fatalf("pause events: got %d, want 0", n)
+	}
+}
+
+func TestFocusSessionManager_DoubleStop(t *testing.T) {
+	h := newManagerHarness(t)
+	session := h.start(t, 25*time.Minute)
+	h.clock.Advance(10 * time.Minute)
+
+	var wg sync.WaitGroup
+	errs := make([]error, 2)
+	for i := range errs {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			errs[i] = h.manager.Stop(testUserID, models.FocusSessionEventSourceTelegram)
+		}()
+	}
+	wg.Wait()
+
+	stopped := 0
+	for _, err := range errs {
+		switch {
+		case err == nil:
+			stopped++
+		case !apperrors.IsCode(err, apperrors.NotFound):
+			t.Fatalf("second stop: got %v, want not found", err)
+		}
+	}
+	if stopped != 1 {
+		t.Fatalf("successful stops: got %d, want 1", stopped)
+	}
+
+	trigger := h.waitTrigger(t)
+	if trigger.FocusSessionStatus != models.FocusSessionStatusStopped {
+		t.Fatalf("trigger status: got %q, want stopped", trigger.FocusSessionStatus)
+	}
+
+	// The stopped timer must not fire later on.
+	h.clock.Advance(time.Hour)
+	h.expectNoTrigger(t)
+
+	stored := h.sessions.get(session.ID)
+	if stored.FocusedDuration != 10*time.Minute {
+		t.Fatalf("focused duration: got %s, want 10m", stored.FocusedDuration)
+	}
+	if n := h.events.count(models.FocusSessionEventTypeStop); n != 1 {
+		t.Fatalf("stop events: got %d, want 1", n)
+	}
+}
+
+func TestFocusSessionManager_ResumeAfterTTL(t *testing.T) {
+	h := newManagerHarness(t)
+	session := h.start(t, 25*time.Minute)
+
+	h.clock.Advance(10 * time.Minute)
+	if err := h.manager.Pause(testUserID, models.FocusSessionEventSourceTelegram); err != nil {
+		t.Fatalf("pause: %v", err)
+	}
+
+	// Stay paused well past the TTL the running session was cached with.
+	h.clock.Advance(time.Hour)
+	if err := h.manager.Resume(testUserID, models.FocusSessionEventSourceTelegram); err != nil {
+		t.Fatalf("resume after TTL: %v", err)
+	}
+
+	state, err := h.manager.State(testUserID)
+	if err != nil {
+		t.Fatalf("state: %v", err)
+	}
+	if state.Paused || state.Remaining != 15*time.Minute {
+		t.Fatalf("state: paused %v, remaining %s", state.Paused, state.Remaining)
+	}
+
+	h.clock.Advance(15 * time.Minute)
+	trigger := h.waitTrigger(t)
+	if trigger.FocusSessionStatus != models.FocusSessionStatusCompleted {
+		t.Fatalf("trigger status: got %q, want completed", trigger.FocusSessionStatus)
+	}
+	if trigger.FocusedDuration != 25*time.Minute || trigger.PausedDuration != time.Hour {
+		t.Fatalf("trigger durations: focused %s, paused %s", trigger.FocusedDuration, trigger.PausedDuration)
+	}
+
+	stored := h.sessions.get(session.ID)
+	if stored.Status != models.FocusSessionStatusCompleted {
+		t.Fatalf("stored status: got %q, want completed", stored.Status)
+	}
+}
+
+func TestFocusSessionManager_ExtendWhilePaused(t *testing.T) {
+	h := newManagerHarness(t)
+	session := h.start(t, 25*time.Minute)
+
+	h.clock.Advance(20 * time.Minute)
+	if err := h.manager.Pause(testUserID, models.FocusSessionEventSourceTelegram); err != nil {
+		t.Fatalf("pause: %v", err)
+	}
+	if err := h.manager.Extend(testUserID, 5*time.Minute, models.FocusSessionEventSourceTelegram); err != nil {
+		t.Fatalf("extend: %v", err)
+	}
+	if err := h.manager.Resume(testUserID, models.FocusSessionEventSourceTelegram); err != nil {
+		t.Fatalf("resume: %v", err)
+	}
+
+	h.clock.Advance(5 * time.Minute)
+	h.expectNoTrigger(t)
+
+	h.clock.Advance(5 * time.Minute)
+	trigger := h.waitTrigger(t)
+	if trigger.FocusedDuration != 30*time.Minute {
+		t.Fatalf("focused duration: got %s, want 30m", trigger.FocusedDuration)
+	}
+
+	stored := h.sessions.get(session.ID)
+	if stored.PlannedDuration != 30*time.Minute {
+		t.Fatalf("planned duration: got %s, want 30m", stored.PlannedDuration)
+	}
+}
+
+func TestFocusSessionManager_StartWhileRunning(t *testing.T) {
+	h := newManagerHarness(t)
+	first := h.start(t, 25*time.Minute)
+
+	second, err := models.NewFocusSession(testUserID, 30*time.Minute, h.clock)
+	if err != nil {
+		t.Fatalf("new focus session: %v", err)
+	}
+	err = h.manager.Start(second, 30*time.Minute, models.FocusSessionEventSourceTelegram)
+	if !apperrors.IsCode(err, apperrors.Conflict) {
+		t.Fatalf("second start: got %v, want conflict", err)
+	}
+
+	state, err := h.manager.State(testUserID)
+	if err != nil {
+		t.Fatalf("state: %v", err)
+	}
+	if state.SessionID != first.ID {
+		t.Fatalf("running session: got %s, want %s", state.SessionID, first.ID)
+	}
+}
+
+func TestFocusSessionManager_DailyGoalReachedOnce(t *testing.T) {
+	h := newManagerHarness(t)
+	h.settings.goal = 40 * time.Minute
+
+	h.start(t, 25*time.Minute)
+	h.clock.Advance(25 * time.Minute)
+	trigger := h.waitTrigger(t)
+	if trigger.DailyGoal == nil || trigger.DailyGoal.Focused != 25*time.Minute {
+		t.Fatalf("first session progress: got %+v, want 25m focused", trigger.DailyGoal)
+	}
+	h.expectNoTrigger(t)
+
+	h.start(t, 25*time.Minute)
+	h.clock.Advance(25 * time.Minute)
+	if trigger := h.waitTrigger(t); trigger.Type != api.TriggerTypeFinishSession {
+		t.Fatalf("second session: got %q, want finish first", trigger.Type)
+	}
+	trigger = h.waitTrigger(t)
+	if trigger.Type != api.TriggerTypeGoalReached || trigger.DailyGoal.Focused != 50*time.Minute {
+		t.Fatalf("celebration: got %q with %+v", trigger.Type, trigger.DailyGoal)
+	}
+
+	h.start(t, 25*time.Minute)
+	h.clock.Advance(25 * time.Minute)
+	h.waitTrigger(t)
+	h.expectNoTrigger(t)
+}
+
+func TestFocusSessionManager_BadgesAfterGoal(t *testing.T) {
+	h := newManagerHarness(t)
+	h.settings.goal = 25 * time.Minute
+	first, _ := models.LookupBadge(models.BadgeFirstSession)
+	h.achievements.pending = []models.Badge{first}
+
+	h.start(t, 25*time.Minute)
+	h.clock.Advance(25 * time.Minute)
+	for _, want := range []api.TriggerType{
+		api.TriggerTypeFinishSession,
+		api.TriggerTypeGoalReached,
+		api.TriggerTypeBadgesEarned,
+	} {
+		if trigger := h.waitTrigger(t); trigger.Type != want {
+			t.Fatalf("trigger type: got %q, want %q", trigger.Type, want)
+		}
+	}
+	h.expectNoTrigger(t)
+
+	h.start(t, 25*time.Minute)
+	h.clock.Advance(25 * time.Minute)
+	h.waitTrigger(t)
+	h.expectNoTrigger(t)
+
+	h.achievements.mu.Lock()
+	defer h.achievements.mu.Unlock()
+	if len(h.achievements.goalReached) != 2 || !h.achievements.goalReached[0] || h.achievements.goalReached[1] {
+		t.Fatalf("recorded goal days: got %v, want [true false]", h.achievements.goalReached)
+	}
+}
+
+func TestFocusSessionManager_AbandonAfterMaxPause(t *testing.T) {
+	h := newManagerHarnessWithConfig(t, FocusSessionManagerConfig{MaxPause: 30 * time.Minute})
+	session := h.start(t, 25*time.Minute)
+
+	h.clock.Advance(10 * time.Minute)
+	if err := h.manager.Pause(testUserID, models.FocusSessionEventSourceTelegram); err != nil {
+		t.Fatalf("pause: %v", err)
+	}
+
+	// A resume resets the pause limit.
+	h.clock.Advance(20 * time.Minute)
+	if err := h.manager.Resume(testUserID, models.FocusSessionEventSourceTelegram); err != nil {
+		t.Fatalf("resume: %v", err)
+	}
+	if err := h.manager.Pause(testUserID, models.FocusSessionEventSourceTelegram); err != nil {
+		t.Fatalf("second pause: %v", err)
+	}
+	h.clock.Advance(20 * time.Minute)
+	h.expectNoTrigger(t)
+
+	h.clock.Advance(10 * time.Minute)
+	trigger := h.waitTrigger(t)
+	if trigger.Type != api.TriggerTypeSessionAbandoned {
+		t.Fatalf("trigger: got %q, want %q", trigger.Type, api.TriggerTypeSessionAbandoned)
+	}
+	if trigger.FocusedDuration != 10*time.Minute {
+		t.Fatalf("focused duration: got %s, want 10m", trigger.FocusedDuration)
+	}
+
+	stored := h.sessions.get(session.ID)
+	if stored.Status != models.FocusSessionStatusAbandoned {
+		t.Fatalf("stored status: got %q, want abandoned", stored.Status)
+	}
+	if n := h.events.count(models.FocusSessionEventTypeAbandon); n != 1 {
+		t.Fatalf("abandon events: got %d, want 1", n)
+	}
+	if _, err := h.manager.State(testUserID); !apperrors.IsCode(err, apperrors.NotFound) {
+		t.Fatalf("state after abandon: got %v, want not found", err)
+	}
+}
+
+func TestFocusSessionManager_StartLinked(t *testing.T) {
+	h := newManagerHarness(t)
+	const otherUserID = "other-user"
+
+	newRoomSession := func(userID string) models.FocusSession {
+		session, err := models.NewFocusSession(userID, 25*time.Minute, h.clock)
+		if err != nil {
+			t.Fatalf("new focus session: %v", err)
+		}
+		session.VendorID = testVendorID
+		session.RoomID = "room"
+		if err := h.sessions.Create(context.Background(), session); err != nil {
+			t.Fatalf("create focus session: %v", err)
+		}
+		return session
+	}
+
+	// One busy member keeps the whole room from starting.
+	h.start(t, 10*time.Minute)
+	err := h.manager.StartLinked(
+		[]models.FocusSession{newRoomSession(otherUserID), newRoomSession(testUserID)},
+		25*time.Minute,
+		models.FocusSessionEventSourceTelegram,
+	)
+	if !apperrors.IsCode(err, apperrors.Conflict) {
+		t.Fatalf("linked start with a busy member: got %v, want conflict", err)
+	}
+	if _, err := h.manager.State(otherUserID); !apperrors.IsCode(err, apperrors.NotFound) {
+		t.Fatalf("state of free member: got %v, want not found", err)
+	}
+	if err := h.manager.Stop(testUserID, models.FocusSessionEventSourceTelegram); err != nil {
+		t.Fatalf("stop: %v", err)
+	}
+	h.waitTrigger(t)
+
+	err = h.manager.StartLinked(
+		[]models.FocusSession{newRoomSession(otherUserID), newRoomSession(testUserID)},
+		25*time.Minute,
+		models.FocusSessionEventSourceTelegram,
+	)
+	if err != nil {
+		t.Fatalf("linked start: %v", err)
+	}
+	if err := h.manager.Pause(testUserID, models.FocusSessionEventSourceTelegram); !apperrors.IsCode(err, apperrors.BadRequest) {
+		t.Fatalf("pause of room session: got %v, want bad request", err)
+	}
+
+	h.clock.Advance(25 * time.Minute)
+	for range 2 {
+		trigger := h.waitTrigger(t)
+		if trigger.Type != api.TriggerTypeFinishSession || trigger.RoomID != "room" {
+			t.Fatalf("trigger: got %q for room %q, want finish for room", trigger.Type, trigger.RoomID)
+		}
+	}
+}
+
+func TestFocusSessionManager_ExtendSkipsBreaks(t *testing.T) {
+	h := newManagerHarness(t)
+	focus := h.start(t, 25*time.Minute)
+	h.clock.Advance(25 * time.Minute)
+	h.waitTrigger(t)
+
+	rest := h.startBreak(t, 5*time.Minute)
+	err := h.manager.Extend(testUserID, 5*time.Minute, models.FocusSessionEventSourceTelegram)
+	if !apperrors.IsCode(err, apperrors.BadRequest) {
+		t.Fatalf("extend during break: got %v, want bad request", err)
+	}
+	state, err := h.manager.State(testUserID)
+	if err != nil || !state.Break || state.Remaining != 5*time.Minute {
+		t.Fatalf("break state: got %+v, %v, want 5m of break left", state, err)
+	}
+
+	h.clock.Advance(5 * time.Minute)
+	h.waitTrigger(t)
+
+	if err := h.manager.Extend(testUserID, 5*time.Minute, models.FocusSessionEventSourceTelegram); err != nil {
+		t.Fatalf("extend after break: %v", err)
+	}
+	state, err = h.manager.State(testUserID)
+	if err != nil || state.Break || state.SessionID != focus.ID || state.Remaining != 5*time.Minute {
+		t.Fatalf("reopened state: got %+v, %v, want focus session %s with 5m left", state, err, focus.ID)
+	}
+	if stored := h.sessions.get(rest.ID); stored.Status != models.FocusSessionStatusCompleted {
+		t.Fatalf("break status: got %q, want completed", stored.Status)
+	}
+}
+
+func TestFocusSessionManager_BreakIsNotFocus(t *testing.T) {
+	h := newManagerHarness(t)
+	h.settings.goal = 10 * time.Minute
+
+	session := h.startBreak(t, 5*time.Minute)
+
+	state, err := h.manager.State(testUserID)
+	if err != nil || !state.Break {
+		t.Fatalf("state: got %+v, %v, want a break", state, err)
+	}
+
+	h.clock.Advance(5 * time.Minute)
+	trigger := h.waitTrigger(t)
+	if trigger.Type != api.TriggerTypeBreakFinished {
+		t.Fatalf("trigger: got %q, want %q", trigger.Type, api.TriggerTypeBreakFinished)
+	}
+	if trigger.RestDuration != 5*time.Minute || trigger.FocusedDuration != 0 || trigger.DailyGoal != nil {
+		t.Fatalf("break trigger: got rest %s, focused %s, goal %+v", trigger.RestDuration, trigger.FocusedDuration, trigger.DailyGoal)
+	}
+	h.expectNoTrigger(t)
+
+	stored := h.sessions.get(session.ID)
+	if stored.RestDuration != 5*time.Minute || stored.FocusedDuration != 0 {
+		t.Fatalf("stored break: got rest %s, focused %s", stored.RestDuration, stored.FocusedDuration)
+	}
+}
@@ -0,0 +1,385 @@
+package service
+
+import (
+	"attune/internal/dto"
+	"attune/internal/models"
+	"attune/internal/storage"
+	"attune/pkg/apperrors"
+	"attune/pkg/clock"
+	"attune/pkg/logger"
+	"context"
+	"fmt"
+)
+
+var (
+	errMsgOpenRoom    = "failed to open focus room"
+	errMsgJoinRoom    = "failed to join focus room with id %s"
+	errMsgStartRoom   = "failed to start focus room with id %s"
+	errMsgCancelRoom  = "failed to cancel focus room with id %s"
+	errMsgFinishRoom  = "failed to finish focus room with id %s"
+	errMsgRoomSummary = "failed to build summary of focus room with id %s"
+	errMsgRoomBusy    = "Nobody in the room is free to focus right now"
+)
+
+type FocusRoomService interface {
+	Open(ctx context.Context, input dto.OpenFocusRoomRequest) (models.FocusRoom, []models.FocusRoomParticipant, error)
+	Join(ctx context.Context, roomID, vendorID string) (models.FocusRoom, []models.FocusRoomParticipant, error)
+	Start(ctx context.Context, roomID, vendorID string, source models.FocusSessionEventSource) (models.FocusRoom, []models.FocusRoomParticipant, error)
+	Cancel(ctx context.Context, roomID, vendorID string) (models.FocusRoom, error)
+	Summary(ctx context.Context, roomID string) (models.FocusRoom, []models.FocusRoomParticipant, error)
+	// Finish reports whether this call finished the room.
+	Finish(ctx context.Context, roomID string) (bool, error)
+	SetSummaryMessage(ctx context.Context, roomID, messageID string) error
+}
+
+type focusRoomService struct {
+	storages            storage.Storages
+	focusSessionManager FocusSessionManager
+	logger              logger.Logger
+	clock               clock.Clock
+}
+
+func NewFocusRoomService(
+	storages storage.Storages,
+	focusSessionManager FocusSessionManager,
+	logger logger.Logger,
+	clk clock.Clock,
+) FocusRoomService {
+	return &focusRoomService{
+		storages:            storages,
+		focusSessionManager: focusSessionManager,
+		logger:              logger,
+		clock:               clk,
+	}
+}
+
+func (s *focusRoomService) Open(
+	ctx context.Context,
+	input dto.OpenFocusRoomRequest,
+) (models.FocusRoom, []models.FocusRoomParticipant, error) {
+	const op = "focusRoomService.Open"
+	log := s.logger.With("operation", op)
+
+	user, err := s.user(ctx, input.VendorID)
+	if err != nil {
+		return models.FocusRoom{}, nil, err
+	}
+
+	room, err := models.NewFocusRoom(input.ChatID, user.ID, input.Duration, s.clock)
+	if err != nil {
+		return models.FocusRoom{}, nil, err
+	}
+
+	if err := s.storages.FocusRoom.Create(ctx, room); err != nil {
+		log.Error(ctx, errMsgOpenRoom, err)
+		return models.FocusRoom{}, nil, err
+	}
+
+	if err := s.storages.FocusRoom.AddParticipant(ctx, models.FocusRoomParticipant{
+		RoomID:   room.ID,
+		UserID:   user.ID,
+		JoinedAt: room.CreatedAt,
+	}); err != nil {
+		log.Error(ctx, errMsgOpenRoom, err)
+		return models.FocusRoom{}, nil, err
+	}
+
+	participants, err := s.storages.FocusRoom.ListParticipants(ctx, room.ID)
+	if err != nil {
+		log.Error(ctx, errMsgOpenRoom, err)
+		return models.FocusRoom{}, nil, err
+	}
+
+	return room, participants, nil
+}
+
+func (s *focusRoomService) Join(
+	ctx context.Context,
+	roomID, vendorID string,
+) (models.FocusRoom, []models.FocusRoomParticipant, error) {
+	const op = "focusRoomService.Join"
+	log := s.logger.With("operation", op)
+
+	user, err := s.user(ctx, vendorID)
+	if err != nil {
+		return models.FocusRoom{}, nil, err
+	}
+
+	room, participants, err := s.room(ctx, roomID)
+	if err != nil {
+		return models.FocusRoom{}, nil, err
+	}
+	if room.Status != models.FocusRoomStatusOpen {
+		return models.FocusRoom{}, nil, apperrors.NewBadRequest().WithDescription(models.ErrRoomNotOpen)
+	}
+	if len(participants) >= models.FocusRoomMaxParticipants {
+		return models.FocusRoom{}, nil, apperrors.NewBadRequest().WithDescription(models.ErrRoomFull)
+	}
+
+	participant := models.FocusRoomParticipant{
+		RoomID:   room.ID,
+		UserID:   user.ID,
+		JoinedAt: s.clock.Now(),
+	}
+	if err := s.storages.FocusRoom.AddParticipant(ctx, participant); err != nil {
+		if !apperrors.IsCode(err, apperrors.Conflict) {
+			log.Error(ctx, fmt.Sprintf(errMsgJoinRoom, roomID), err)
+		}
+		return models.FocusRoom{}, nil, err
+	}
+
+	participants, err = s.storages.FocusRoom.ListParticipants(ctx, room.ID)
+	if err != nil {
+		log.Error(ctx, fmt.Sprintf(errMsgJoinRoom, roomID), err)
+		return models.FocusRoom{}, nil, err
+	}
+
+	return room, participants, nil
+}
+
+func (s *focusRoomService) Start(
+	ctx context.Context,
+	roomID, vendorID string,
+	source models.FocusSessionEventSource,
+) (models.FocusRoom, []models.FocusRoomParticipant, error) {
+	const op = "focusRoomService.Start"
+	log := s.logger.With("operation", op)
+
+	user, err := s.user(ctx, vendorID)
+	if err != nil {
+		return models.FocusRoom{}, nil, err
+	}
+
+	room, participants, err := s.room(ctx, roomID)
+	if err != nil {
+		return models.FocusRoom{}, nil, err
+	}
+	if room.HostUserID != user.ID {
+		return models.FocusRoom{}, nil, apperrors.NewBadRequest().WithDescription(models.ErrRoomNotHost)
+	}
+
+	sessions, breaks, err := s.newRoomSessions(ctx, room, participants)
+	if err != nil {
+		log.Error(ctx, fmt.Sprintf(errMsgStartRoom, roomID), err)
+		return models.FocusRoom{}, nil, err
+	}
+	if len(sessions) == 0 {
+		return models.FocusRoom{}, nil, apperrors.NewConflict().WithDescription(errMsgRoomBusy)
+	}
+
+	claimed, err := s.storages.FocusRoom.Transition(ctx, room.ID, models.FocusRoomStatusOpen, models.FocusRoomStatusRunning)
+	if err != nil {
+		log.Error(ctx, fmt.Sprintf(errMsgStartRoom, roomID), err)
+		return models.FocusRoom{}, nil, err
+	}
+	if !claimed {
+		return models.FocusRoom{}, nil, apperrors.NewBadRequest().WithDescription(models.ErrRoomNotOpen)
+	}
+
+	for userID, active := range breaks {
+		err := stopActiveSessions(ctx, s.storages, s.focusSessionManager, s.clock, userID, active, models.FocusSessionEventSourceSystem)
+		if err != nil {
+			log.Error(ctx, fmt.Sprintf(errMsgStartRoom, roomID), err, "userID", userID)
+		}
+	}
+
+	created := make([]models.FocusSession, 0, len(sessions))
+	for _, session := range sessions {
+		err := s.storages.FocusSession.Create(ctx, session)
+		switch {
+		case err == nil:
+			created = append(created, session)
+		case apperrors.IsCode(err, apperrors.Conflict):
+			// The member started a session of their own since the check.
+		default:
+			log.Error(ctx, fmt.Sprintf(errMsgStartRoom, roomID), err)
+			s.rollbackStart(ctx, room, created)
+			return models.FocusRoom{}, nil, apperrors.NewInternal().WithDescriptionAndCause(errMsgCreateSession, err)
+		}
+	}
+	if len(created) == 0 {
+		s.rollbackStart(ctx, room, created)
+		return models.FocusRoom{}, nil, apperrors.NewConflict().WithDescription(errMsgRoomBusy)
+	}
+	sessions = created
+
+	if err := s.focusSessionManager.StartLinked(sessions, room.Duration, source); err != nil {
+		log.Error(ctx, fmt.Sprintf(errMsgStartRoom, roomID), err)
+		s.rollbackStart(ctx, room, sessions)
+		return models.FocusRoom{}, nil, err
+	}
+
+	room.Status = models.FocusRoomStatusRunning
+	room.StartedAt = s.clock.Now()
+	room.UpdatedAt = room.StartedAt
+	if err := s.storages.FocusRoom.Update(ctx, room); err != nil {
+		log.Error(ctx, fmt.Sprintf(errMsgStartRoom, roomID), err)
+	}
+
+	started := make(map[string]models.FocusSession, len(sessions))
+	for _, session := range sessions {
+		started[session.UserID] = session
+	}
+	for i := range participants {
+		if session, ok := started[participants[i].UserID]; ok {
+			participants[i].Session = &session
+		}
+	}
+
+	return room, participants, nil
+}
+
+// Members on a break join, and their breaks are returned by user ID to be ended first.
+func (s *focusRoomService) newRoomSessions(
+	ctx context.Context,
+	room models.FocusRoom,
+	participants []models.FocusRoomParticipant,
+) ([]models.FocusSession, map[string][]models.FocusSession, error) {
+	sessions := make([]models.FocusSession, 0, len(participants))
+	breaks := make(map[string][]models.FocusSession)
+	for _, participant := range participants {
+		active, _, err := s.storages.FocusSession.List(ctx, storage.ListFocusSessionFilter{
+			UserID: participant.UserID,
+			Status: models.FocusSessionStatusActive,
+		})
+		if err != nil && !apperrors.IsCode(err, apperrors.NotFound) {
+			return nil, nil, apperrors.NewInternal().WithDescriptionAndCause(errMsgListSessions, err)
+		}
+		if len(active) > 0 {
+			if !active[0].IsBreak() {
+				continue
+			}
+			breaks[participant.UserID] = active
+		}
+
+		session, err := models.NewFocusSession(participant.UserID, room.Duration, s.clock)
+		if err != nil {
+			return nil, nil, err
+		}
+		session.VendorID = participant.VendorID
+		session.RoomID = room.ID
+		sessions = append(sessions, session)
+	}
+
+	return sessions, breaks, nil
+}
+
+func (s *focusRoomService) rollbackStart(ctx context.Context, room models.FocusRoom, sessions []models.FocusSession) {
+	log := s.logger.With("operation", "focusRoomService.rollbackStart")
+
+	for _, session := range sessions {
+		if err := s.storages.FocusSession.Delete(ctx, session.ID); err != nil && !apperrors.IsCode(err, apperrors.NotFound) {
+			log.Error(ctx, fmt.Sprintf(errMsgDeleteSession, session.ID), err)
+		}
+	}
+	if _, err := s.storages.FocusRoom.Transition(ctx, room.ID, models.FocusRoomStatusRunning, models.FocusRoomStatusOpen); err != nil {
+		log.Error(ctx, fmt.Sprintf(errMsgStartRoom, room.ID), err)
+	}
+}
+
+func (s *focusRoomService) Cancel(ctx context.Context, roomID, vendorID string) (models.FocusRoom, error) {
+	const op = "focusRoomService.Cancel"
+	log := s.logger.With("operation", op)
+
+	user, err := s.user(ctx, vendorID)
+	if err != nil {
+		return models.FocusRoom{}, err
+	}
+
+	room, _, err := s.room(ctx, roomID)
+	if err != nil {
+		return models.FocusRoom{}, err
+	}
+	if room.HostUserID != user.ID {
+		return models.FocusRoom{}, apperrors.NewBadRequest().WithDescription(models.ErrRoomNotHost)
+	}
+
+	claimed, err := s.storages.FocusRoom.Transition(ctx, room.ID, models.FocusRoomStatusOpen, models.FocusRoomStatusCancelled)
+	if err != nil {
+		log.Error(ctx, fmt.Sprintf(errMsgCancelRoom, roomID), err)
+		return models.FocusRoom{}, err
+	}
+	if !claimed {
+		return models.FocusRoom{}, apperrors.NewBadRequest().WithDescription(models.ErrRoomNotOpen)
+	}
+	room.Status = models.FocusRoomStatusCancelled
+
+	return room, nil
+}
+
+func (s *focusRoomService) Summary(ctx context.Context, roomID string) (models.FocusRoom, []models.FocusRoomParticipant, error) {
+	const op = "focusRoomService.Summary"
+	log := s.logger.With("operation", op)
+
+	room, participants, err := s.room(ctx, roomID)
+	if err != nil {
+		return models.FocusRoom{}, nil, err
+	}
+
+	sessions, _, err := s.storages.FocusSession.List(ctx, storage.ListFocusSessionFilter{
+		RoomID: room.ID,
+	})
+	if err != nil && !apperrors.IsCode(err, apperrors.NotFound) {
+		log.Error(ctx, fmt.Sprintf(errMsgRoomSummary, roomID), err)
+		return models.FocusRoom{}, nil, err
+	}
+
+	byUser := make(map[string]models.FocusSession, len(sessions))
+	for _, session := range sessions {
+		byUser[session.UserID] = session
+	}
+	for i := range participants {
+		if session, ok := byUser[participants[i].UserID]; ok {
+			participants[i].Session = &session
+		}
+	}
+
+	return room, participants, nil
+}
+
+func (s *focusRoomService) Finish(ctx context.Context, roomID string) (bool, error) {
+	claimed, err := s.storages.FocusRoom.Transition(ctx, roomID, models.FocusRoomStatusRunning, models.FocusRoomStatusFinished)
+	if err != nil {
+		s.logger.Error(ctx, fmt.Sprintf(errMsgFinishRoom, roomID), err, "operation", "focusRoomService.Finish")
+		return false, err
+	}
+
+	return claimed, nil
+}
+
+func (s *focusRoomService) SetSummaryMessage(ctx context.Context, roomID, messageID string) error {
+	room, _, err := s.room(ctx, roomID)
+	if err != nil {
+		return err
+	}
+
+	room.SummaryMessageID = messageID
+	room.UpdatedAt = s.clock.Now()
+
+	return s.storages.FocusRoom.Update(ctx, room)
+}
+
+func (s *focusRoomService) room(ctx context.Context, id string) (models.FocusRoom, []models.FocusRoomParticipant, error) {
+	rooms, _, err := s.storages.FocusRoom.List(ctx, storage.ListFocusRoomFilter{ID: id})
+	if err != nil {
+		return models.FocusRoom{}, nil, err
+	}
+
+	participants, err := s.storages.FocusRoom.ListParticipants(ctx, id)
+	if err != nil {
+		return models.FocusRoom{}, nil, err
+	}
+
+	return rooms[0], participants, nil
+}
+
+func (s *focusRoomService) user(ctx context.Context, vendorID string) (models.User, error) {
+	users, _, err := s.storages.User.List(ctx, storage.ListUserFilter{
+		VendorID: vendorID,
+	})
+	if err != nil {
+		return models.User{}, apperrors.NewInternal().WithDescriptionAndCause(fmt.Sprintf(errMsgListUsers, vendorID), err)
+	}
+
+	return users[0], nil
+}
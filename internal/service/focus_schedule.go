@@ -0,0 +1,169 @@
+package service
+
+import (
+	"attune/internal/dto"
+	"attune/internal/models"
+	"attune/internal/storage"
+	"attune/pkg/apperrors"
+	"attune/pkg/clock"
+	"attune/pkg/logger"
+	"context"
+	"fmt"
+	"time"
+)
+
+var (
+	errMsgCreateSchedule = "failed to create focus schedule"
+	errMsgListSchedules  = "failed to list focus schedules"
+	errMsgDeleteSchedule = "failed to delete focus schedule with id %s"
+	errMsgSnoozeSchedule = "failed to snooze focus schedule with id %s"
+)
+
+type FocusScheduleService interface {
+	Create(ctx context.Context, input dto.CreateFocusScheduleRequest) (models.FocusSchedule, error)
+	List(ctx context.Context, vendorID string) ([]models.FocusSchedule, error)
+	Get(ctx context.Context, vendorID, id string) (models.FocusSchedule, error)
+	Snooze(ctx context.Context, vendorID, id string, delay time.Duration) (models.FocusSchedule, error)
+	Delete(ctx context.Context, vendorID, id string) error
+}
+
+type focusScheduleService struct {
+	storages storage.Storages
+	logger   logger.Logger
+	clock    clock.Clock
+	timezone string
+}
+
+func NewFocusScheduleService(
+	storages storage.Storages,
+	logger logger.Logger,
+	clk clock.Clock,
+	timezone string,
+) FocusScheduleService {
+	return &focusScheduleService{
+		storages: storages,
+		logger:   logger,
+		clock:    clk,
+		timezone: timezone,
+	}
+}
+
+func (s *focusScheduleService) Create(ctx context.Context, input dto.CreateFocusScheduleRequest) (models.FocusSchedule, error) {
+	const op = "focusScheduleService.Create"
+	log := s.logger.With("operation", op)
+
+	user, err := s.user(ctx, input.VendorID)
+	if err != nil {
+		return models.FocusSchedule{}, err
+	}
+
+	schedule, err := models.NewFocusSchedule(
+		user.ID,
+		input.Rule,
+		input.Date,
+		input.StartTime,
+		input.Duration,
+		s.timezone,
+		s.clock,
+	)
+	if err != nil {
+		log.Error(ctx, errMsgCreateSchedule, err)
+		return models.FocusSchedule{}, err
+	}
+
+	if err := s.storages.FocusSchedule.Create(ctx, schedule); err != nil {
+		log.Error(ctx, errMsgCreateSchedule, err)
+		return models.FocusSchedule{}, err
+	}
+
+	return schedule, nil
+}
+
+func (s *focusScheduleService) List(ctx context.Context, vendorID string) ([]models.FocusSchedule, error) {
+	const op = "focusScheduleService.List"
+	log := s.logger.With("operation", op)
+
+	user, err := s.user(ctx, vendorID)
+	if err != nil {
+		return nil, err
+	}
+
+	schedules, _, err := s.storages.FocusSchedule.List(ctx, storage.ListFocusScheduleFilter{
+		UserID:     user.ID,
+		OnlyActive: true,
+	})
+	if err != nil {
+		if !apperrors.IsCode(err, apperrors.NotFound) {
+			log.Error(ctx, errMsgListSchedules, err)
+		}
+		return nil, err
+	}
+
+	return schedules, nil
+}
+
+func (s *focusScheduleService) Get(ctx context.Context, vendorID, id string) (models.FocusSchedule, error) {
+	user, err := s.user(ctx, vendorID)
+	if err != nil {
+		return models.FocusSchedule{}, err
+	}
+
+	schedules, _, err := s.storages.FocusSchedule.List(ctx, storage.ListFocusScheduleFilter{
+		ID:     id,
+		UserID: user.ID,
+	})
+	if err != nil {
+		return models.FocusSchedule{}, err
+	}
+
+	return schedules[0], nil
+}
+
+func (s *focusScheduleService) Snooze(
+	ctx context.Context,
+	vendorID, id string,
+	delay time.Duration,
+) (models.FocusSchedule, error) {
+	const op = "focusScheduleService.Snooze"
+	log := s.logger.With("operation", op)
+
+	schedule, err := s.Get(ctx, vendorID, id)
+	if err != nil {
+		return models.FocusSchedule{}, err
+	}
+
+	snoozed := models.NewSnoozedFocusSchedule(schedule, delay, s.clock)
+	if err := s.storages.FocusSchedule.Create(ctx, snoozed); err != nil {
+		log.Error(ctx, fmt.Sprintf(errMsgSnoozeSchedule, id), err)
+		return models.FocusSchedule{}, err
+	}
+
+	return snoozed, nil
+}
+
+func (s *focusScheduleService) Delete(ctx context.Context, vendorID, id string) error {
+	const op = "focusScheduleService.Delete"
+	log := s.logger.With("operation", op)
+
+	if _, err := s.Get(ctx, vendorID, id); err != nil {
+		return err
+	}
+
+	if err := s.storages.FocusSchedule.Delete(ctx, id); err != nil {
+		log.Error(ctx, fmt.Sprintf(errMsgDeleteSchedule, id), err)
+		return err
+	}
+
+	return nil
+}
+
+func (s *focusScheduleService) user(ctx context.Context, vendorID string) (models.User, error) {
+	users, _, err := s.storages.User.List(ctx, storage.ListUserFilter{
+		VendorID: vendorID,
+	})
+	if err != nil {
+		return models.User{}, apperrors.NewInternal().WithDescriptionAndCause(fmt.Sprintf(errMsgListUsers, vendorID), err)
+	}
+
+	return users[0], nil
+}
@@ -0,0 +1,111 @@
+package service
+
+import (
+	"attune/internal/api"
+	"attune/internal/models"
+	"attune/internal/storage"
+	"attune/pkg/apperrors"
+	"attune/pkg/clock"
+	"attune/pkg/logger"
+	"context"
+	"time"
+)
+
+type FocusScheduler interface {
+	Start(ctx context.Context)
+}
+
+type FocusSchedulerConfig struct {
+	Interval time.Duration
+	// Runs older than GraceWindow are skipped, e.g. after a restart.
+	GraceWindow time.Duration
+}
+
+type focusScheduler struct {
+	storages    storage.Storages
+	externalAPI api.ExternalAPI
+	logger      logger.Logger
+	clock       clock.Clock
+	config      FocusSchedulerConfig
+}
+
+func NewFocusScheduler(
+	storages storage.Storages,
+	externalAPI api.ExternalAPI,
+	logger logger.Logger,
+	clk clock.Clock,
+	config FocusSchedulerConfig,
+) FocusScheduler {
+	return &focusScheduler{
+		storages:    storages,
+		externalAPI: externalAPI,
+		logger:      logger,
+		clock:       clk,
+		config:      config,
+	}
+}
+
+func (s *focusScheduler) Start(ctx context.Context) {
+	timer := s.clock.NewTimer(0)
+	defer timer.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-timer.C():
+			s.fireDue(ctx)
+			timer.Reset(s.config.Interval)
+		}
+	}
+}
+
+func (s *focusScheduler) fireDue(ctx context.Context) {
+	now := s.clock.Now()
+	schedules, _, err := s.storages.FocusSchedule.List(ctx, storage.ListFocusScheduleFilter{
+		DueBefore: now,
+	})
+	if err != nil {
+		if !apperrors.IsCode(err, apperrors.NotFound) {
+			s.logger.Error(ctx, "failed to list due focus schedules", err)
+		}
+		return
+	}
+
+	for _, schedule := range schedules {
+		if err := s.fire(ctx, schedule, now); err != nil {
+			s.logger.Error(ctx, "failed to fire focus schedule", err, "scheduleID", schedule.ID)
+		}
+	}
+}
+
+// The schedule moves to its next run before the current one is announced, so a run is
+// announced at most once even if several instances or a restart race for it.
+func (s *focusScheduler) fire(ctx context.Context, schedule models.FocusSchedule, now time.Time) error {
+	runAt := schedule.NextRunAt
+	schedule.Advance(now)
+
+	claimed, err := s.storages.FocusSchedule.Claim(ctx, schedule, runAt)
+	if err != nil || !claimed {
+		return err
+	}
+
+	if now.Sub(runAt) > s.config.GraceWindow {
+		s.logger.Info(ctx, "skipped missed focus schedule run", "scheduleID", schedule.ID, "runAt", runAt)
+		return nil
+	}
+
+	users, _, err := s.storages.User.List(ctx, storage.ListUserFilter{
+		ID: schedule.UserID,
+	})
+	if err != nil {
+		return err
+	}
+	schedule.VendorID = users[0].VendorID
+
+	return s.externalAPI.Trigger(ctx, schedule.VendorID, api.Trigger{
+		VendorID: schedule.VendorID,
+		Type:     api.TriggerTypeScheduledSession,
+		Schedule: &schedule,
+	})
+}
@@ -0,0 +1,298 @@
+package service
+
+import (
+	"attune/internal/models"
+	"attune/internal/storage"
+	"attune/pkg/apperrors"
+	"attune/pkg/clock"
+	"attune/pkg/logger"
+	"context"
+	"fmt"
+	"math"
+	"sort"
+	"time"
+)
+
+var (
+	errMsgInsights = "failed to compute insights for VendorID %s"
+)
+
+const (
+	insightWindowDays = 90
+
+	insightMinSamples      = 10
+	insightMinPartSessions = 5
+	insightMinCorrelation  = 0.3
+	insightMinDifference   = 1.0
+)
+
+// Two-tailed critical values of Student's t at the 5% level. Lookups round the degrees
+// of freedom down, which errs on the strict side.
+var criticalT = []struct {
+	df int
+	t  float64
+}{
+	{1, 12.706}, {2, 4.303}, {3, 3.182}, {4, 2.776}, {5, 2.571}, {6, 2.447}, {7, 2.365},
+	{8, 2.306}, {9, 2.262}, {10, 2.228}, {12, 2.179}, {15, 2.131}, {20, 2.086},
+	{30, 2.042}, {60, 2.000}, {120, 1.980},
+}
+
+type InsightService interface {
+	Insights(ctx context.Context, vendorID string) (models.Insights, error)
+}
+
+type insightService struct {
+	storages storage.Storages
+	logger   logger.Logger
+	clock    clock.Clock
+	location *time.Location
+}
+
+func NewInsightService(
+	storages storage.Storages,
+	logger logger.Logger,
+	clk clock.Clock,
+	location *time.Location,
+) InsightService {
+	return &insightService{
+		storages: storages,
+		logger:   logger,
+		clock:    clk,
+		location: location,
+	}
+}
+
+func (s *insightService) Insights(ctx context.Context, vendorID string) (models.Insights, error) {
+	const op = "insightService.Insights"
+	log := s.logger.With("operation", op)
+
+	users, _, err := s.storages.User.List(ctx, storage.ListUserFilter{
+		VendorID: vendorID,
+	})
+	if err != nil {
+		return models.Insights{}, apperrors.NewInternal().WithDescriptionAndCause(fmt.Sprintf(errMsgListUsers, vendorID), err)
+	}
+	userID := users[0].ID
+
+	loc := s.location
+	today := models.LocalDate(s.clock.Now(), loc)
+	from := today.AddDate(0, 0, 1-insightWindowDays)
+	startedFrom := time.Date(from.Year(), from.Month(), from.Day(), 0, 0, 0, 0, loc)
+
+	records, _, err := s.storages.DayRecord.List(ctx, storage.ListDayRecordFilter{
+		UserID:   userID,
+		DateFrom: from,
+		DateTo:   today,
+	})
+	if err != nil && !apperrors.IsCode(err, apperrors.NotFound) {
+		log.Error(ctx, fmt.Sprintf(errMsgInsights, vendorID), err)
+		return models.Insights{}, err
+	}
+
+	sessions, _, err := s.storages.FocusSession.List(ctx, storage.ListFocusSessionFilter{
+		UserID:       userID,
+		OnlyFinished: true,
+		StartedFrom:  startedFrom,
+	})
+	if err != nil && !apperrors.IsCode(err, apperrors.NotFound) {
+		log.Error(ctx, fmt.Sprintf(errMsgInsights, vendorID), err)
+		return models.Insights{}, err
+	}
+
+	distractions, _, err := s.storages.FocusDistraction.List(ctx, storage.ListFocusDistractionFilter{
+		UserID: userID,
+	})
+	if err != nil && !apperrors.IsCode(err, apperrors.NotFound) {
+		log.Error(ctx, fmt.Sprintf(errMsgInsights, vendorID), err)
+		return models.Insights{}, err
+	}
+
+	focusedByDay := make(map[time.Time]float64)
+	var rated []models.FocusSession
+	for _, session := range sessions {
+		if session.IsBreak() {
+			continue
+		}
+		focusedByDay[models.LocalDate(session.StartedAt, loc)] += session.FocusedDuration.Minutes()
+		if session.Quality > 0 {
+			session.StartedAt = session.StartedAt.In(loc)
+			rated = append(rated, session)
+		}
+	}
+
+	distractionsByDay := make(map[time.Time]float64)
+	for _, distraction := range distractions {
+		if !distraction.CreatedAt.Before(startedFrom) {
+			distractionsByDay[models.LocalDate(distraction.CreatedAt, loc)]++
+		}
+	}
+
+	result := models.Insights{Window: insightWindowDays * 24 * time.Hour}
+	add := func(insight models.Insight, gap models.InsightGap, found bool) {
+		switch {
+		case gap.Samples < gap.Needed:
+			result.Gaps = append(result.Gaps, gap)
+		case found:
+			result.Insights = append(result.Insights, insight)
+		}
+	}
+
+	var focused, dayQuality []float64
+	for _, record := range records {
+		focused = append(focused, focusedByDay[record.LocalDate])
+		dayQuality = append(dayQuality, float64(record.Quality))
+	}
+	add(correlationInsight(models.InsightKindFocusDayQuality, focused, dayQuality))
+
+	var length, sessionQuality []float64
+	for _, session := range rated {
+		length = append(length, session.FocusedDuration.Minutes())
+		sessionQuality = append(sessionQuality, float64(session.Quality))
+	}
+	add(correlationInsight(models.InsightKindSessionLength, length, sessionQuality))
+
+	var interruptions, mood []float64
+	for _, record := range records {
+		if len(record.Mood.Emotions) == 0 {
+			continue
+		}
+		interruptions = append(interruptions, distractionsByDay[record.LocalDate])
+		mood = append(mood, float64(models.MoodScore(record.Mood)))
+	}
+	add(correlationInsight(models.InsightKindMoodDistractions, interruptions, mood))
+
+	add(timeOfDayInsight(rated))
+
+	return result, nil
+}
+
+func correlationInsight(kind models.InsightKind, xs, ys []float64) (models.Insight, models.InsightGap, bool) {
+	gap := models.InsightGap{Kind: kind, Samples: len(xs), Needed: insightMinSamples}
+	if len(xs) < insightMinSamples {
+		return models.Insight{}, gap, false
+	}
+
+	r, ok := pearson(xs, ys)
+	if !ok || math.Abs(r) < insightMinCorrelation {
+		return models.Insight{}, gap, false
+	}
+
+	df := len(xs) - 2
+	t := math.Inf(1)
+	if math.Abs(r) < 1 {
+		t = math.Abs(r) * math.Sqrt(float64(df)/(1-r*r))
+	}
+	if t < criticalTValue(df) {
+		return models.Insight{}, gap, false
+	}
+
+	return models.Insight{Kind: kind, Samples: len(xs), Correlation: r}, gap, true
+}
+
+// Welch's t-test between the parts of the day with the best and worst average rating.
+func timeOfDayInsight(sessions []models.FocusSession) (models.Insight, models.InsightGap, bool) {
+	byPart := make(map[models.PartOfDay][]float64)
+	for _, session := range sessions {
+		part := models.PartOfDayOf(session.StartedAt)
+		byPart[part] = append(byPart[part], float64(session.Quality))
+	}
+
+	type partStats struct {
+		part     models.PartOfDay
+		ratings  []float64
+		average  float64
+		variance float64
+	}
+	var parts []partStats
+	for part, ratings := range byPart {
+		if len(ratings) < insightMinPartSessions {
+			continue
+		}
+		average, variance := meanVariance(ratings)
+		parts = append(parts, partStats{part: part, ratings: ratings, average: average, variance: variance})
+	}
+
+	gap := models.InsightGap{Kind: models.InsightKindTimeOfDay, Samples: len(parts), Needed: 2}
+	if len(parts) < 2 {
+		return models.Insight{}, gap, false
+	}
+
+	sort.Slice(parts, func(i, j int) bool { return parts[i].average > parts[j].average })
+	best, worst := parts[0], parts[len(parts)-1]
+	if best.average-worst.average < insightMinDifference {
+		return models.Insight{}, gap, false
+	}
+
+	bestN, worstN := float64(len(best.ratings)), float64(len(worst.ratings))
+	bestVar, worstVar := best.variance/bestN, worst.variance/worstN
+	insight := models.Insight{
+		Kind:         models.InsightKindTimeOfDay,
+		Samples:      len(best.ratings) + len(worst.ratings),
+		Best:         best.part,
+		BestAverage:  best.average,
+		Worst:        worst.part,
+		WorstAverage: worst.average,
+	}
+	// Ratings that never vary leave nothing to test.
+	if bestVar+worstVar == 0 {
+		return insight, gap, true
+	}
+
+	t := (best.average - worst.average) / math.Sqrt(bestVar+worstVar)
+	df := math.Pow(bestVar+worstVar, 2) /
+		(bestVar*bestVar/(bestN-1) + worstVar*worstVar/(worstN-1))
+	if t < criticalTValue(int(df)) {
+		return models.Insight{}, gap, false
+	}
+
+	return insight, gap, true
+}
+
+func pearson(xs, ys []float64) (float64, bool) {
+	meanX, varX := meanVariance(xs)
+	meanY, varY := meanVariance(ys)
+	if varX == 0 || varY == 0 {
+		return 0, false
+	}
+
+	var covariance float64
+	for i := range xs {
+		covariance += (xs[i] - meanX) * (ys[i] - meanY)
+	}
+	covariance /= float64(len(xs) - 1)
+
+	return covariance / math.Sqrt(varX*varY), true
+}
+
+func meanVariance(values []float64) (float64, float64) {
+	var sum float64
+	for _, value := range values {
+		sum += value
+	}
+	mean := sum / float64(len(values))
+
+	if len(values) < 2 {
+		return mean, 0
+	}
+	var squares float64
+	for _, value := range values {
+		squares += (value - mean) * (value - mean)
+	}
+
+	return mean, squares / float64(len(values)-1)
+}
+
+func criticalTValue(df int) float64 {
+	value := math.Inf(1)
+	for _, entry := range criticalT {
+		if entry.df > df {
+			break
+		}
+		value = entry.t
+	}
+	if df > criticalT[len(criticalT)-1].df {
+		return 1.96
+	}
+
+	return value
+}
@@ -0,0 +1,302 @@
+package service
+
+import (
+	"math"
+	"testing"
+	"time"
+
+	"attune/internal/models"
+)
+
+const insightTolerance = 1e-9
+
+func TestPearson(t *testing.T) {
+	tests := []struct {
+		name   string
+		xs, ys []float64
+		want   float64
+		wantOK bool
+	}{
+		{
+			name:   "perfect positive",
+			xs:     []float64{1, 2, 3, 4, 5},
+			ys:     []float64{2, 4, 6, 8, 10},
+			want:   1,
+			wantOK: true,
+		},
+		{
+			name:   "perfect negative",
+			xs:     []float64{1, 2, 3, 4, 5},
+			ys:     []float64{10, 8, 6, 4, 2},
+			want:   -1,
+			wantOK: true,
+		},
+		{
+			// A covariance of 6/4 against variances of 10/4 and 6/4.
+			name:   "partial",
+			xs:     []float64{1, 2, 3, 4, 5},
+			ys:     []float64{2, 4, 5, 4, 5},
+			want:   6 / math.Sqrt(60),
+			wantOK: true,
+		},
+		{
+			name: "constant x",
+			xs:   []float64{3, 3, 3, 3, 3},
+			ys:   []float64{1, 2, 3, 4, 5},
+		},
+		{
+			name: "constant y",
+			xs:   []float64{1, 2, 3, 4, 5},
+			ys:   []float64{4, 4, 4, 4, 4},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, ok := pearson(tt.xs, tt.ys)
+			if ok != tt.wantOK {
+				t.Fatalf("ok: got %v, want %v", ok, tt.wantOK)
+			}
+			if math.Abs(got-tt.want) > insightTolerance {
+				t.Fatalf("r: got %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestMeanVariance(t *testing.T) {
+	tests := []struct {
+		name         string
+		values       []float64
+		wantMean     float64
+		wantVariance float64
+	}{
+		{
+			name:         "sample variance",
+			values:       []float64{2, 4, 4, 4, 5, 5, 7, 9},
+			wantMean:     5,
+			wantVariance: 32.0 / 7,
+		},
+		{
+			name:         "constant",
+			values:       []float64{3, 3, 3},
+			wantMean:     3,
+			wantVariance: 0,
+		},
+		{
+			name:         "single value",
+			values:       []float64{7},
+			wantMean:     7,
+			wantVariance: 0,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mean, variance := meanVariance(tt.values)
+			if math.Abs(mean-tt.wantMean) > insightTolerance {
+				t.Fatalf("mean: got %v, want %v", mean, tt.wantMean)
+			}
+			if math.Abs(variance-tt.wantVariance) > insightTolerance {
+				t.Fatalf("variance: got %v, want %v", variance, tt.wantVariance)
+			}
+		})
+	}
+}
+
+func TestCriticalTValue(t *testing.T) {
+	tests := []struct {
+		df   int
+		want float64
+	}{
+		{df: 0, want: math.Inf(1)},
+		{df: 1, want: 12.706},
+		{df: 10, want: 2.228},
+		// Between table entries the lower degrees of freedom are used.
+		{df: 11, want: 2.228},
+		{df: 12, want: 2.179},
+		{df: 119, want: 2.000},
+		{df: 120, want: 1.980},
+		// Past the table the normal distribution takes over.
+		{df: 121, want: 1.96},
+		{df: 1000, want: 1.96},
+	}
+
+	for _, tt := range tests {
+		if got := criticalTValue(tt.df); got != tt.want {
+			t.Errorf("criticalTValue(%d): got %v, want %v", tt.df, got, tt.want)
+		}
+	}
+}
+
+func TestCorrelationInsight(t *testing.T) {
+	days := []float64{1, 2, 3, 4, 5, 6, 7, 8, 9, 10}
+
+	tests := []struct {
+		name    string
+		xs, ys  []float64
+		want    float64
+		wantOK  bool
+		samples int
+	}{
+		{
+			// r = 31/33 gives t = 7.75 over 8 degrees of freedom, well past 2.306.
+			name:    "significant",
+			xs:      days,
+			ys:      []float64{2, 1, 4, 3, 6, 5, 8, 7, 10, 9},
+			want:    31.0 / 33,
+			wantOK:  true,
+			samples: 10,
+		},
+		{
+			name:    "significant negative",
+			xs:      days,
+			ys:      []float64{9, 10, 7, 8, 5, 6, 3, 4, 1, 2},
+			want:    -31.0 / 33,
+			wantOK:  true,
+			samples: 10,
+		},
+		{
+			// r is about 0.53, strong enough but t is only about 1.76.
+			name:    "not significant",
+			xs:      days,
+			ys:      []float64{3, 1, 2, 5, 2, 8, 3, 4, 9, 4},
+			samples: 10,
+		},
+		{
+			name:    "too weak",
+			xs:      days,
+			ys:      []float64{5, 1, 4, 2, 3, 5, 1, 4, 2, 3},
+			samples: 10,
+		},
+		{
+			name:    "constant series",
+			xs:      days,
+			ys:      []float64{4, 4, 4, 4, 4, 4, 4, 4, 4, 4},
+			samples: 10,
+		},
+		{
+			name:    "too few samples",
+			xs:      days[:9],
+			ys:      []float64{1, 2, 3, 4, 5, 6, 7, 8, 9},
+			samples: 9,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			insight, gap, ok := correlationInsight(models.InsightKindSessionLength, tt.xs, tt.ys)
+			if ok != tt.wantOK {
+				t.Fatalf("ok: got %v, want %v", ok, tt.wantOK)
+			}
+			if gap.Kind != models.InsightKindSessionLength || gap.Samples != tt.samples || gap.Needed != insightMinSamples {
+				t.Fatalf("gap: got %+v", gap)
+			}
+			if !ok {
+				return
+			}
+			if insight.Kind != models.InsightKindSessionLength || insight.Samples != tt.samples {
+				t.Fatalf("insight: got %+v", insight)
+			}
+			if math.Abs(insight.Correlation-tt.want) > insightTolerance {
+				t.Fatalf("correlation: got %v, want %v", insight.Correlation, tt.want)
+			}
+		})
+	}
+}
+
+func TestTimeOfDayInsight(t *testing.T) {
+	sessionsAt := func(hour int, ratings ...int) []models.FocusSession {
+		sessions := make([]models.FocusSession, 0, len(ratings))
+		for i, rating := range ratings {
+			sessions = append(sessions, models.FocusSession{
+				StartedAt: time.Date(2026, time.October, 1+i, hour, 0, 0, 0, time.UTC),
+				Quality:   rating,
+			})
+		}
+		return sessions
+	}
+	join := func(parts ...[]models.FocusSession) []models.FocusSession {
+		var sessions []models.FocusSession
+		for _, part := range parts {
+			sessions = append(sessions, part...)
+		}
+		return sessions
+	}
+
+	tests := []struct {
+		name         string
+		sessions     []models.FocusSession
+		wantOK       bool
+		wantParts    int
+		best, worst  models.PartOfDay
+		bestAverage  float64
+		worstAverage float64
+	}{
+		{
+			// Welch's t is about 7.6 over 7 degrees of freedom.
+			name:         "significant",
+			sessions:     join(sessionsAt(9, 5, 5, 4, 5, 5), sessionsAt(19, 2, 3, 2, 3, 2)),
+			wantOK:       true,
+			wantParts:    2,
+			best:         models.PartOfDayMorning,
+			worst:        models.PartOfDayEvening,
+			bestAverage:  4.8,
+			worstAverage: 2.4,
+		},
+		{
+			// Ratings that never vary are reported without a test.
+			name:         "no variance",
+			sessions:     join(sessionsAt(14, 3, 3, 3, 3, 3), sessionsAt(23, 5, 5, 5, 5, 5)),
+			wantOK:       true,
+			wantParts:    2,
+			best:         models.PartOfDayNight,
+			worst:        models.PartOfDayAfternoon,
+			bestAverage:  5,
+			worstAverage: 3,
+		},
+		{
+			// A difference of 1.6 with t about 1.26.
+			name:      "not significant",
+			sessions:  join(sessionsAt(9, 5, 1, 5, 1, 5), sessionsAt(19, 1, 1, 5, 1, 1)),
+			wantParts: 2,
+		},
+		{
+			name:      "difference too small",
+			sessions:  join(sessionsAt(9, 4, 4, 4, 4, 4), sessionsAt(19, 3, 4, 4, 4, 4)),
+			wantParts: 2,
+		},
+		{
+			// The evening has too few sessions to be compared.
+			name:      "one part",
+			sessions:  join(sessionsAt(9, 5, 5, 5, 5, 5), sessionsAt(19, 1, 1, 1, 1)),
+			wantParts: 1,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			insight, gap, ok := timeOfDayInsight(tt.sessions)
+			if ok != tt.wantOK {
+				t.Fatalf("ok: got %v, want %v", ok, tt.wantOK)
+			}
+			if gap.Kind != models.InsightKindTimeOfDay || gap.Samples != tt.wantParts || gap.Needed != 2 {
+				t.Fatalf("gap: got %+v", gap)
+			}
+			if !ok {
+				return
+			}
+			if insight.Best != tt.best || insight.Worst != tt.worst {
+				t.Fatalf("parts: got best %q worst %q, want %q and %q", insight.Best, insight.Worst, tt.best, tt.worst)
+			}
+			if math.Abs(insight.BestAverage-tt.bestAverage) > insightTolerance ||
+				math.Abs(insight.WorstAverage-tt.worstAverage) > insightTolerance {
+				t.Fatalf("averages: got %v and %v, want %v and %v",
+					insight.BestAverage, insight.WorstAverage, tt.bestAverage, tt.worstAverage)
+			}
+			if insight.Samples != 10 {
+				t.Fatalf("samples: got %d, want 10", insight.Samples)
+			}
+		})
+	}
+}
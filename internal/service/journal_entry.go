@@ -0,0 +1,162 @@
+package service
+
+import (
+	"attune/internal/dto"
+	"attune/internal/models"
+	"attune/internal/storage"
+	"attune/pkg/apperrors"
+	"attune/pkg/clock"
+	"attune/pkg/logger"
+	"context"
+	"fmt"
+	"time"
+)
+
+var (
+	errMsgCreateJournalEntry = "failed to create journal entry for VendorID %s"
+	errMsgListJournalEntries = "failed to list journal entries for VendorID %s"
+	errMsgUpdateJournalEntry = "failed to update journal entry %s"
+	errMsgDeleteJournalEntry = "failed to delete journal entry %s"
+)
+
+type JournalEntryService interface {
+	Create(ctx context.Context, input dto.CreateJournalEntryRequest) (models.JournalEntry, error)
+	Recent(ctx context.Context, vendorID string, limit uint64) ([]models.JournalEntry, error)
+	Search(ctx context.Context, vendorID, query string, limit uint64) ([]models.JournalEntry, error)
+	Get(ctx context.Context, vendorID, entryID string) (models.JournalEntry, error)
+	Update(ctx context.Context, input dto.UpdateJournalEntryRequest) (models.JournalEntry, error)
+	Delete(ctx context.Context, vendorID, entryID string) error
+}
+
+type journalEntryService struct {
+	storages storage.Storages
+	logger   logger.Logger
+	clock    clock.Clock
+	location *time.Location
+}
+
+func NewJournalEntryService(
+	storages storage.Storages,
+	logger logger.Logger,
+	clk clock.Clock,
+	location *time.Location,
+) JournalEntryService {
+	return &journalEntryService{
+		storages: storages,
+		logger:   logger,
+		clock:    clk,
+		location: location,
+	}
+}
+
+func (s *journalEntryService) Create(ctx context.Context, input dto.CreateJournalEntryRequest) (models.JournalEntry, error) {
+	const op = "journalEntryService.Create"
+	log := s.logger.With("operation", op)
+
+	user, err := s.user(ctx, input.VendorID)
+	if err != nil {
+		return models.JournalEntry{}, err
+	}
+
+	entry, err := models.NewJournalEntry(user.ID, models.LocalDate(s.clock.Now(), s.location), input.Text, s.clock)
+	if err != nil {
+		return models.JournalEntry{}, err
+	}
+
+	if err := s.storages.JournalEntry.Create(ctx, entry); err != nil {
+		log.Error(ctx, fmt.Sprintf(errMsgCreateJournalEntry, input.VendorID), err)
+		return models.JournalEntry{}, err
+	}
+
+	return entry, nil
+}
+
+func (s *journalEntryService) Recent(ctx context.Context, vendorID string, limit uint64) ([]models.JournalEntry, error) {
+	return s.list(ctx, vendorID, storage.ListJournalEntryFilter{Limit: limit})
+}
+
+func (s *journalEntryService) Search(ctx context.Context, vendorID, query string, limit uint64) ([]models.JournalEntry, error) {
+	return s.list(ctx, vendorID, storage.ListJournalEntryFilter{Query: query, Limit: limit})
+}
+
+func (s *journalEntryService) Get(ctx context.Context, vendorID, entryID string) (models.JournalEntry, error) {
+	entries, err := s.list(ctx, vendorID, storage.ListJournalEntryFilter{ID: entryID})
+	if err != nil {
+		return models.JournalEntry{}, err
+	}
+
+	return entries[0], nil
+}
+
+func (s *journalEntryService) Update(ctx context.Context, input dto.UpdateJournalEntryRequest) (models.JournalEntry, error) {
+	const op = "journalEntryService.Update"
+	log := s.logger.With("operation", op)
+
+	entry, err := s.Get(ctx, input.VendorID, input.EntryID)
+	if err != nil {
+		return models.JournalEntry{}, err
+	}
+
+	if err := entry.UpdateText(input.Text, s.clock); err != nil {
+		return models.JournalEntry{}, err
+	}
+	if err := s.storages.JournalEntry.Update(ctx, entry); err != nil {
+		log.Error(ctx, fmt.Sprintf(errMsgUpdateJournalEntry, input.EntryID), err)
+		return models.JournalEntry{}, err
+	}
+
+	return entry, nil
+}
+
+func (s *journalEntryService) Delete(ctx context.Context, vendorID, entryID string) error {
+	const op = "journalEntryService.Delete"
+	log := s.logger.With("operation", op)
+
+	// Looking the entry up first makes sure it belongs to the user.
+	if _, err := s.Get(ctx, vendorID, entryID); err != nil {
+		return err
+	}
+
+	if err := s.storages.JournalEntry.Delete(ctx, entryID); err != nil {
+		log.Error(ctx, fmt.Sprintf(errMsgDeleteJournalEntry, entryID), err)
+		return err
+	}
+
+	return nil
+}
+
+func (s *journalEntryService) list(
+	ctx context.Context,
+	vendorID string,
+	filter storage.ListJournalEntryFilter,
+) ([]models.JournalEntry, error) {
+	const op = "journalEntryService.list"
+	log := s.logger.With("operation", op)
+
+	user, err := s.user(ctx, vendorID)
+	if err != nil {
+		return nil, err
+	}
+
+	filter.UserID = user.ID
+	entries, _, err := s.storages.JournalEntry.List(ctx, filter)
+	if err != nil {
+		if !apperrors.IsCode(err, apperrors.NotFound) {
+			log.Error(ctx, fmt.Sprintf(errMsgListJournalEntries, vendorID), err)
+		}
+		return nil, err
+	}
+
+	return entries, nil
+}
+
+func (s *journalEntryService) user(ctx context.Context, vendorID string) (models.User, error) {
+	users, _, err := s.storages.User.List(ctx, storage.ListUserFilter{
+		VendorID: vendorID,
+	})
+	if err != nil {
+		return models.User{}, apperrors.NewInternal().WithDescriptionAndCause(fmt.Sprintf(errMsgListUsers, vendorID), err)
+	}
+
+	return users[0], nil
+}
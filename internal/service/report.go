@@ -0,0 +1,161 @@
+package service
+
+import (
+	"attune/internal/models"
+	"attune/internal/storage"
+	"attune/pkg/apperrors"
+	"attune/pkg/clock"
+	"attune/pkg/logger"
+	"context"
+	"fmt"
+	"time"
+)
+
+var (
+	errMsgReport = "failed to build report for VendorID %s"
+)
+
+type ReportService interface {
+	Current(ctx context.Context, vendorID string, period models.ReportPeriod) (models.Report, error)
+	Previous(ctx context.Context, vendorID string, period models.ReportPeriod) (models.Report, error)
+}
+
+type reportService struct {
+	storages storage.Storages
+	logger   logger.Logger
+	clock    clock.Clock
+	location *time.Location
+}
+
+func NewReportService(
+	storages storage.Storages,
+	logger logger.Logger,
+	clk clock.Clock,
+	location *time.Location,
+) ReportService {
+	return &reportService{
+		storages: storages,
+		logger:   logger,
+		clock:    clk,
+		location: location,
+	}
+}
+
+func (s *reportService) Current(ctx context.Context, vendorID string, period models.ReportPeriod) (models.Report, error) {
+	today := models.LocalDate(s.clock.Now(), s.location)
+
+	return s.build(ctx, vendorID, period, today)
+}
+
+func (s *reportService) Previous(ctx context.Context, vendorID string, period models.ReportPeriod) (models.Report, error) {
+	today := models.LocalDate(s.clock.Now(), s.location)
+
+	return s.build(ctx, vendorID, period, period.Start(today).AddDate(0, 0, -1))
+}
+
+func (s *reportService) build(
+	ctx context.Context,
+	vendorID string,
+	period models.ReportPeriod,
+	date time.Time,
+) (models.Report, error) {
+	const op = "reportService.build"
+	log := s.logger.With("operation", op)
+
+	if !period.IsValid() {
+		return models.Report{}, apperrors.NewBadRequest().WithDescription(models.ErrInvalidReportPeriod)
+	}
+
+	users, _, err := s.storages.User.List(ctx, storage.ListUserFilter{
+		VendorID: vendorID,
+	})
+	if err != nil {
+		return models.Report{}, apperrors.NewInternal().WithDescriptionAndCause(fmt.Sprintf(errMsgListUsers, vendorID), err)
+	}
+
+	from := period.Start(date)
+	to := period.End(date)
+	if today := models.LocalDate(s.clock.Now(), s.location); to.After(today) {
+		to = today
+	}
+	previousTo := from.AddDate(0, 0, -1)
+
+	report := models.Report{Period: period}
+	report.Current, err = s.totals(ctx, users[0].ID, from, to)
+	if err != nil {
+		log.Error(ctx, fmt.Sprintf(errMsgReport, vendorID), err)
+		return models.Report{}, err
+	}
+	report.Previous, err = s.totals(ctx, users[0].ID, period.Start(previousTo), previousTo)
+	if err != nil {
+		log.Error(ctx, fmt.Sprintf(errMsgReport, vendorID), err)
+		return models.Report{}, err
+	}
+
+	return report, nil
+}
+
+func (s *reportService) totals(ctx context.Context, userID string, from, to time.Time) (models.ReportTotals, error) {
+	totals := models.ReportTotals{From: from, To: to}
+	loc := s.location
+
+	sessions, _, err := s.storages.FocusSession.List(ctx, storage.ListFocusSessionFilter{
+		UserID:       userID,
+		OnlyFinished: true,
+		StartedFrom:  time.Date(from.Year(), from.Month(), from.Day(), 0, 0, 0, 0, loc),
+		StartedTo:    time.Date(to.Year(), to.Month(), to.Day()+1, 0, 0, 0, 0, loc),
+	})
+	if err != nil && !apperrors.IsCode(err, apperrors.NotFound) {
+		return totals, err
+	}
+
+	var sessionQuality int
+	for _, session := range sessions {
+		if session.IsBreak() {
+			continue
+		}
+
+		totals.Focused += session.FocusedDuration
+		if session.Status == models.FocusSessionStatusCompleted {
+			totals.Completed++
+		} else {
+			totals.Stopped++
+		}
+		if session.Quality > 0 {
+			totals.RatedSessions++
+			sessionQuality += session.Quality
+		}
+	}
+	if totals.RatedSessions > 0 {
+		totals.AverageSessionQuality = float64(sessionQuality) / float64(totals.RatedSessions)
+	}
+
+	records, _, err := s.storages.DayRecord.List(ctx, storage.ListDayRecordFilter{
+		UserID:   userID,
+		DateFrom: from,
+		DateTo:   to,
+	})
+	if err != nil && !apperrors.IsCode(err, apperrors.NotFound) {
+		return totals, err
+	}
+
+	var dayQuality int
+	for _, record := range records {
+		totals.RatedDays++
+		dayQuality += record.Quality
+
+		// Records come newest first, so ties go to the earlier day.
+		day := &models.ReportDay{Date: record.LocalDate, Quality: record.Quality}
+		if totals.BestDay == nil || day.Quality >= totals.BestDay.Quality {
+			totals.BestDay = day
+		}
+		if totals.WorstDay == nil || day.Quality <= totals.WorstDay.Quality {
+			totals.WorstDay = day
+		}
+	}
+	if totals.RatedDays > 0 {
+		totals.AverageDayQuality = float64(dayQuality) / float64(totals.RatedDays)
+	}
+
+	return totals, nil
+}
@@ -0,0 +1,139 @@
+package service
+
+import (
+	"attune/internal/api"
+	"attune/internal/models"
+	"attune/internal/storage"
+	"attune/pkg/apperrors"
+	"attune/pkg/clock"
+	"attune/pkg/logger"
+	"context"
+	"time"
+)
+
+const reportSchedulerInterval = time.Minute
+
+type ReportScheduler interface {
+	Start(ctx context.Context)
+}
+
+type ReportSchedulerConfig struct {
+	At       string
+	Location *time.Location
+}
+
+type reportScheduler struct {
+	storages      storage.Storages
+	reportService ReportService
+	externalAPI   api.ExternalAPI
+	logger        logger.Logger
+	clock         clock.Clock
+	config        ReportSchedulerConfig
+}
+
+func NewReportScheduler(
+	storages storage.Storages,
+	reportService ReportService,
+	externalAPI api.ExternalAPI,
+	logger logger.Logger,
+	clk clock.Clock,
+	config ReportSchedulerConfig,
+) ReportScheduler {
+	if config.Location == nil {
+		config.Location = time.UTC
+	}
+
+	return &reportScheduler{
+		storages:      storages,
+		reportService: reportService,
+		externalAPI:   externalAPI,
+		logger:        logger,
+		clock:         clk,
+		config:        config,
+	}
+}
+
+// Each report is claimed in the user's settings before it is sent, so a restart still
+// sends the latest due reports and several instances never send one twice.
+func (s *reportScheduler) Start(ctx context.Context) {
+	if s.config.At == "" {
+		return
+	}
+	at, err := time.Parse("15:04", s.config.At)
+	if err != nil {
+		s.logger.Error(ctx, "invalid report time", err, "at", s.config.At)
+		return
+	}
+
+	checked := make(map[models.ReportPeriod]time.Time, 2)
+	timer := s.clock.NewTimer(reportSchedulerInterval)
+	defer timer.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-timer.C():
+			now := s.clock.Now()
+			day := latestDueDay(now, s.config.Location, at)
+			for _, period := range []models.ReportPeriod{models.ReportPeriodWeek, models.ReportPeriodMonth} {
+				// Before the send time on a Monday or the 1st, the report of the period that
+				// has just ended is not due yet.
+				if !period.Start(day).Equal(period.Start(now.In(s.config.Location))) {
+					continue
+				}
+				if due := s.dueStart(period, day); due.After(checked[period]) {
+					checked[period] = due
+					s.send(ctx, period, due)
+				}
+			}
+			timer.Reset(reportSchedulerInterval)
+		}
+	}
+}
+
+func (s *reportScheduler) dueStart(period models.ReportPeriod, day time.Time) time.Time {
+	start := period.Start(day)
+	return time.Date(start.Year(), start.Month(), start.Day(), 0, 0, 0, 0, s.config.Location)
+}
+
+func (s *reportScheduler) send(ctx context.Context, period models.ReportPeriod, dueStart time.Time) {
+	users, _, err := s.storages.User.List(ctx, storage.ListUserFilter{
+		VendorType: string(models.VendorTelegram),
+	})
+	if err != nil {
+		if !apperrors.IsCode(err, apperrors.NotFound) {
+			s.logger.Error(ctx, "failed to list users for reports", err)
+		}
+		return
+	}
+
+	for _, user := range users {
+		report, err := s.reportService.Previous(ctx, user.VendorID, period)
+		if err != nil {
+			s.logger.Error(ctx, "failed to build report", err, "user", user.VendorID, "period", period)
+			continue
+		}
+		if report.Current.Sessions() == 0 && report.Current.RatedDays == 0 {
+			continue
+		}
+
+		claimed, err := s.storages.UserSettings.MarkReportSent(ctx, user.ID, period, s.clock.Now(), dueStart)
+		if err != nil {
+			s.logger.Error(ctx, "failed to claim report", err, "user", user.VendorID, "period", period)
+			continue
+		}
+		if !claimed {
+			continue
+		}
+
+		err = s.externalAPI.Trigger(ctx, user.VendorID, api.Trigger{
+			VendorID: user.VendorID,
+			Type:     api.TriggerTypeReport,
+			Report:   &report,
+		})
+		if err != nil {
+			s.logger.Error(ctx, "failed to send report", err, "user", user.VendorID, "period", period)
+		}
+	}
+}
@@ -1,30 +1,80 @@
 package service
 
 import (
+	"attune/internal/api"
 	"attune/internal/storage"
 	"attune/pkg/cache"
+	"attune/pkg/clock"
 	"attune/pkg/logger"
 	"attune/pkg/transactor"
+	"time"
 )
 
 type Services struct {
-	UserService         UserService
-	UserSettingsService UserSettingsService
-	FocusSessionManager FocusSessionManager
-	FocusSessionService FocusSessionService
-	cache               cache.Cache
+	UserService              UserService
+	UserSettingsService      UserSettingsService
+	DayService               DayService
+	JournalEntryService      JournalEntryService
+	ChartService             ChartService
+	ReportService            ReportService
+	InsightService           InsightService
+	AchievementService       AchievementService
+	FocusSessionManager      FocusSessionManager
+	FocusSessionService      FocusSessionService
+	FocusSessionEventService FocusSessionEventService
+	FocusScheduleService     FocusScheduleService
+	FocusLabelService        FocusLabelService
+	FocusRoomService         FocusRoomService
+	cache                    cache.Cache
+}
+
+type ServicesConfig struct {
+	Timezone            string
+	Location            *time.Location
+	FocusSessionManager FocusSessionManagerConfig
 }
 
 func NewServices(
 	storages storage.Storages,
-	focusSessionManager FocusSessionManager,
+	focusSessionManagerCache cache.Cache,
 	transactor transactor.Transactor,
 	logger logger.Logger,
 	cache cache.Cache,
+	clk clock.Clock,
+	apiCh chan<- api.Trigger,
+	config ServicesConfig,
 ) *Services {
+	location := config.Location
+	if location == nil {
+		location = time.UTC
+	}
+	achievementService := NewAchievementService(storages, logger, clk, location)
+	focusSessionEventService := NewFocusSessionEventService(storages, logger)
+	focusSessionManager := NewFocusSessionManager(
+		storages,
+		focusSessionEventService,
+		achievementService,
+		focusSessionManagerCache,
+		apiCh,
+		logger,
+		clk,
+		config.FocusSessionManager,
+	)
+
 	return &Services{
-		UserService:         NewUserService(storages, logger),
-		UserSettingsService: NewUserSettingsService(storages, logger),
-		FocusSessionService: NewFocusSessionService(storages, focusSessionManager, transactor, logger, cache),
+		UserService:              NewUserService(storages, logger),
+		UserSettingsService:      NewUserSettingsService(storages, logger),
+		DayService:               NewDayService(storages, achievementService, apiCh, logger, clk, location),
+		JournalEntryService:      NewJournalEntryService(storages, logger, clk, location),
+		ChartService:             NewChartService(storages, logger, clk, location),
+		ReportService:            NewReportService(storages, logger, clk, location),
+		InsightService:           NewInsightService(storages, logger, clk, location),
+		AchievementService:       achievementService,
+		FocusSessionManager:      focusSessionManager,
+		FocusSessionService:      NewFocusSessionService(storages, focusSessionManager, transactor, logger, cache, clk, location),
+		FocusSessionEventService: focusSessionEventService,
+		FocusScheduleService:     NewFocusScheduleService(storages, logger, clk, config.Timezone),
+		FocusLabelService:        NewFocusLabelService(storages, logger),
+		FocusRoomService:         NewFocusRoomService(storages, focusSessionManager, logger, clk),
 	}
 }
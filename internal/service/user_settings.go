@@ -4,8 +4,10 @@ import (
 	"attune/internal/dto"
 	"attune/internal/models"
 	"attune/internal/storage"
+	"attune/pkg/apperrors"
 	"attune/pkg/logger"
 	"context"
+	"fmt"
 	"time"
 )
 
@@ -13,6 +15,8 @@ type UserSettingsService interface {
 	Create(ctx context.Context, input dto.CreateUserSettingsRequest) error
 	List(ctx context.Context, filter storage.ListUserSettingsFilter) ([]models.UserSettings, error)
 	UpdateSentDailyStatsAt(ctx context.Context, userID string, sentDailyStatsAt time.Time) error
+	Get(ctx context.Context, vendorID string) (models.UserSettings, error)
+	SetDailyFocusGoal(ctx context.Context, vendorID string, goal time.Duration) error
 	Delete(ctx context.Context, userID string) error
 }
 
@@ -79,6 +83,87 @@ func (s *userSettingsService) UpdateSentDailyStatsAt(ctx context.Context, userID
 	return nil
 }
 
+func (s *userSettingsService) Get(ctx context.Context, vendorID string) (models.UserSettings, error) {
+	const op = "userSettingsService.Get"
+
+	log := s.logger.With("operation", op)
+
+	user, err := s.user(ctx, vendorID)
+	if err != nil {
+		return models.UserSettings{}, err
+	}
+
+	settings, err := s.storages.UserSettings.List(ctx, storage.ListUserSettingsFilter{
+		UserID: user.ID,
+	})
+	if err != nil {
+		if apperrors.IsCode(err, apperrors.NotFound) {
+			return models.NewUserSettings(user.ID, time.Time{})
+		}
+		log.Error(ctx, "failed to list user settings", err)
+		return models.UserSettings{}, err
+	}
+
+	return settings[0], nil
+}
+
+func (s *userSettingsService) SetDailyFocusGoal(ctx context.Context, vendorID string, goal time.Duration) error {
+	const op = "userSettingsService.SetDailyFocusGoal"
+
+	log := s.logger.With("operation", op)
+
+	user, err := s.user(ctx, vendorID)
+	if err != nil {
+		return err
+	}
+
+	settings, err := s.storages.UserSettings.List(ctx, storage.ListUserSettingsFilter{
+		UserID: user.ID,
+	})
+	if err != nil && !apperrors.IsCode(err, apperrors.NotFound) {
+		log.Error(ctx, "failed to list user settings", err)
+		return err
+	}
+
+	if len(settings) == 0 {
+		created, err := models.NewUserSettings(user.ID, time.Time{})
+		if err != nil {
+			return err
+		}
+		if err := created.UpdateDailyFocusGoal(goal); err != nil {
+			return err
+		}
+		if err := s.storages.UserSettings.Create(ctx, created); err != nil {
+			log.Error(ctx, "failed to create user settings in storage", err)
+			return err
+		}
+
+		return nil
+	}
+
+	current := settings[0]
+	if err := current.UpdateDailyFocusGoal(goal); err != nil {
+		return err
+	}
+	if err := s.storages.UserSettings.UpdateDailyFocusGoal(ctx, user.ID, current.DailyFocusGoal); err != nil {
+		log.Error(ctx, "failed to update daily focus goal", err)
+		return err
+	}
+
+	return nil
+}
+
+func (s *userSettingsService) user(ctx context.Context, vendorID string) (models.User, error) {
+	users, _, err := s.storages.User.List(ctx, storage.ListUserFilter{
+		VendorID: vendorID,
+	})
+	if err != nil {
+		return models.User{}, apperrors.NewInternal().WithDescriptionAndCause(fmt.Sprintf(errMsgListUsers, vendorID), err)
+	}
+
+	return users[0], nil
+}
+
 func (s *userSettingsService) Delete(ctx context.Context, userID string) error {
 	const op = "userSettingsService.Delete"
 
@@ -5,20 +5,26 @@ import (
 	"attune/pkg/apperrors"
 	"context"
 	"github.com/Masterminds/squirrel"
+	"github.com/jackc/pgx/v5"
 	"github.com/jackc/pgx/v5/pgxpool"
 	"time"
 )
 
 type DayRecordStorage interface {
-	Create(ctx context.Context, record models.DayRecord) error
+	Upsert(ctx context.Context, record models.DayRecord) (models.DayRecord, error)
+	// Rate only changes the quality of an existing record of the same local date.
+	Rate(ctx context.Context, record models.DayRecord) (models.DayRecord, error)
 	List(ctx context.Context, filter ListDayRecordFilter) ([]models.DayRecord, int64, error)
 	Update(ctx context.Context, record models.DayRecord) error
 	Delete(ctx context.Context, id string) error
+	EmotionFrequencies(ctx context.Context, filter ListDayRecordFilter, limit int) ([]models.EmotionFrequency, error)
 }
 
 type ListDayRecordFilter struct {
-	ID     string `json:"id"`
-	UserID string `json:"userId"`
+	ID       string    `json:"id"`
+	UserID   string    `json:"userId"`
+	DateFrom time.Time `json:"dateFrom"`
+	DateTo   time.Time `json:"dateTo"`
 }
 
 type dayRecordStorage struct {
@@ -35,36 +41,93 @@ func NewDayRecordStorage(conn *pgxpool.Pool) DayRecordStorage {
 	}
 }
 
-func (s *dayRecordStorage) Create(ctx context.Context, record models.DayRecord) error {
+func (s *dayRecordStorage) Upsert(ctx context.Context, record models.DayRecord) (models.DayRecord, error) {
 	query, args, err := s.builder.
 		Insert(dayRecordsTableName).
 		Columns(
 			"id",
 			"user_id",
+			"local_date",
 			"quality",
-			"mood",
+			"mood_note",
 			"created_at",
 			"updated_at",
 		).
 		Values(
 			record.ID,
 			record.UserID,
+			record.LocalDate,
 			record.Quality,
-			record.Mood,
+			record.Mood.Note,
 			record.CreatedAt,
 			record.UpdatedAt,
 		).
+		Suffix(`ON CONFLICT (user_id, local_date) DO UPDATE
+			SET quality = EXCLUDED.quality, mood_note = EXCLUDED.mood_note, updated_at = EXCLUDED.updated_at
+			RETURNING id, created_at`).
 		ToSql()
 	if err != nil {
-		return apperrors.NewInternal().WithDescriptionAndCause("failed to build create day record query", err)
+		return models.DayRecord{}, apperrors.NewInternal().WithDescriptionAndCause("failed to build upsert day record query", err)
 	}
 
-	_, err = s.conn.Exec(ctx, query, args...)
+	tx, err := s.conn.Begin(ctx)
 	if err != nil {
-		return apperrors.NewInternal().WithDescriptionAndCause("failed to create day record", err)
+		return models.DayRecord{}, apperrors.NewInternal().WithDescriptionAndCause("failed to begin upsert day record", err)
 	}
+	defer func() { _ = tx.Rollback(ctx) }()
 
-	return nil
+	if err := tx.QueryRow(ctx, query, args...).Scan(&record.ID, &record.CreatedAt); err != nil {
+		return models.DayRecord{}, apperrors.NewInternal().WithDescriptionAndCause("failed to upsert day record", err)
+	}
+	if err := s.replaceEmotions(ctx, tx, record); err != nil {
+		return models.DayRecord{}, err
+	}
+	if err := tx.Commit(ctx); err != nil {
+		return models.DayRecord{}, apperrors.NewInternal().WithDescriptionAndCause("failed to upsert day record", err)
+	}
+
+	return record, nil
+}
+
+func (s *dayRecordStorage) Rate(ctx context.Context, record models.DayRecord) (models.DayRecord, error) {
+	query, args, err := s.builder.
+		Insert(dayRecordsTableName).
+		Columns(
+			"id",
+			"user_id",
+			"local_date",
+			"quality",
+			"mood_note",
+			"created_at",
+			"updated_at",
+		).
+		Values(
+			record.ID,
+			record.UserID,
+			record.LocalDate,
+			record.Quality,
+			record.Mood.Note,
+			record.CreatedAt,
+			record.UpdatedAt,
+		).
+		Suffix(`ON CONFLICT (user_id, local_date) DO UPDATE
+			SET quality = EXCLUDED.quality, updated_at = EXCLUDED.updated_at
+			RETURNING id, mood_note, created_at`).
+		ToSql()
+	if err != nil {
+		return models.DayRecord{}, apperrors.NewInternal().WithDescriptionAndCause("failed to build rate day record query", err)
+	}
+
+	if err := s.conn.QueryRow(ctx, query, args...).Scan(&record.ID, &record.Mood.Note, &record.CreatedAt); err != nil {
+		return models.DayRecord{}, apperrors.NewInternal().WithDescriptionAndCause("failed to rate day record", err)
+	}
+
+	records := []models.DayRecord{record}
+	if err := s.loadEmotions(ctx, records); err != nil {
+		return models.DayRecord{}, err
+	}
+
+	return records[0], nil
 }
 
 func (s *dayRecordStorage) List(ctx context.Context, filter ListDayRecordFilter) ([]models.DayRecord, int64, error) {
@@ -75,22 +138,17 @@ func (s *dayRecordStorage) List(ctx context.Context, filter ListDayRecordFilter)
 		Select(
 			"id",
 			"user_id",
+			"local_date",
 			"quality",
-			"mood",
+			"mood_note",
 			"created_at",
 			"updated_at",
 			"COUNT(*) OVER() AS total_count",
 		).
 		From(dayRecordsTableName)
+	qb = applyDayRecordFilter(qb, filter, "")
 
-	if filter.ID != "" {
-		qb = qb.Where(squirrel.Eq{"id": filter.ID})
-	}
-	if filter.UserID != "" {
-		qb = qb.Where(squirrel.Eq{"user_id": filter.UserID})
-	}
-
-	query, args, err := qb.ToSql()
+	query, args, err := qb.OrderBy("local_date DESC").ToSql()
 	if err != nil {
 		return nil, 0, apperrors.NewInternal().WithDescriptionAndCause("failed to build list day records query", err)
 	}
@@ -107,8 +165,9 @@ func (s *dayRecordStorage) List(ctx context.Context, filter ListDayRecordFilter)
 		if err := rows.Scan(
 			&record.ID,
 			&record.UserID,
+			&record.LocalDate,
 			&record.Quality,
-			&record.Mood,
+			&record.Mood.Note,
 			&record.CreatedAt,
 			&record.UpdatedAt,
 			&count,
@@ -124,6 +183,10 @@ func (s *dayRecordStorage) List(ctx context.Context, filter ListDayRecordFilter)
 		return nil, 0, apperrors.NewNotFound().WithDescription("day records not found")
 	}
 
+	if err := s.loadEmotions(ctx, records); err != nil {
+		return nil, 0, err
+	}
+
 	return records, totalCount, nil
 }
 
@@ -131,7 +194,7 @@ func (s *dayRecordStorage) Update(ctx context.Context, record models.DayRecord)
 	query, args, err := s.builder.
 		Update(dayRecordsTableName).
 		Set("quality", record.Quality).
-		Set("mood", record.Mood).
+		Set("mood_note", record.Mood.Note).
 		Set("updated_at", time.Now()).
 		Where(squirrel.Eq{"id": record.ID}).
 		ToSql()
@@ -139,13 +202,25 @@ func (s *dayRecordStorage) Update(ctx context.Context, record models.DayRecord)
 		return apperrors.NewInternal().WithDescriptionAndCause("failed to build update day record query", err)
 	}
 
-	result, err := s.conn.Exec(ctx, query, args...)
+	tx, err := s.conn.Begin(ctx)
+	if err != nil {
+		return apperrors.NewInternal().WithDescriptionAndCause("failed to begin update day record", err)
+	}
+	defer func() { _ = tx.Rollback(ctx) }()
+
+	result, err := tx.Exec(ctx, query, args...)
 	if err != nil {
 		return apperrors.NewInternal().WithDescriptionAndCause("failed to update day record", err)
 	}
 	if result.RowsAffected() == 0 {
 		return apperrors.NewNotFound().WithDescription("day record not found")
 	}
+	if err := s.replaceEmotions(ctx, tx, record); err != nil {
+		return err
+	}
+	if err := tx.Commit(ctx); err != nil {
+		return apperrors.NewInternal().WithDescriptionAndCause("failed to update day record", err)
+	}
 
 	return nil
 }
@@ -169,3 +244,150 @@ func (s *dayRecordStorage) Delete(ctx context.Context, id string) error {
 
 	return nil
 }
+
+func (s *dayRecordStorage) EmotionFrequencies(
+	ctx context.Context,
+	filter ListDayRecordFilter,
+	limit int,
+) ([]models.EmotionFrequency, error) {
+	qb := s.builder.
+		Select(
+			"e.emotion",
+			"COUNT(*)",
+			"AVG(e.intensity)::FLOAT8",
+		).
+		From(dayRecordEmotionsTableName+" e").
+		Join(dayRecordsTableName+" r ON r.id = e.day_record_id").
+		GroupBy("e.emotion").
+		OrderBy("COUNT(*) DESC", "e.emotion")
+	qb = applyDayRecordFilter(qb, filter, "r.")
+	if limit > 0 {
+		qb = qb.Limit(uint64(limit))
+	}
+
+	query, args, err := qb.ToSql()
+	if err != nil {
+		return nil, apperrors.NewInternal().WithDescriptionAndCause("failed to build emotion frequencies query", err)
+	}
+
+	rows, err := s.conn.Query(ctx, query, args...)
+	if err != nil {
+		return nil, apperrors.NewInternal().WithDescriptionAndCause("failed to get emotion frequencies", err)
+	}
+	defer rows.Close()
+
+	var frequencies []models.EmotionFrequency
+	for rows.Next() {
+		var frequency models.EmotionFrequency
+		if err := rows.Scan(&frequency.Emotion, &frequency.Count, &frequency.AverageIntensity); err != nil {
+			return nil, apperrors.NewInternal().WithDescriptionAndCause("failed to scan emotion frequency", err)
+		}
+		if definition, ok := models.LookupEmotion(frequency.Emotion); ok {
+			frequency.Category = definition.Category
+		}
+		frequencies = append(frequencies, frequency)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, apperrors.NewInternal().WithDescriptionAndCause("failed to get emotion frequencies", err)
+	}
+	if len(frequencies) == 0 {
+		return nil, apperrors.NewNotFound().WithDescription("no emotions found")
+	}
+
+	return frequencies, nil
+}
+
+func (s *dayRecordStorage) replaceEmotions(ctx context.Context, tx pgx.Tx, record models.DayRecord) error {
+	query, args, err := s.builder.
+		Delete(dayRecordEmotionsTableName).
+		Where(squirrel.Eq{"day_record_id": record.ID}).
+		ToSql()
+	if err != nil {
+		return apperrors.NewInternal().WithDescriptionAndCause("failed to build delete day record emotions query", err)
+	}
+	if _, err := tx.Exec(ctx, query, args...); err != nil {
+		return apperrors.NewInternal().WithDescriptionAndCause("failed to delete day record emotions", err)
+	}
+
+	if len(record.Mood.Emotions) == 0 {
+		return nil
+	}
+
+	qb := s.builder.
+		Insert(dayRecordEmotionsTableName).
+		Columns("day_record_id", "emotion", "intensity")
+	for _, emotion := range record.Mood.Emotions {
+		qb = qb.Values(record.ID, emotion.Emotion, emotion.Intensity)
+	}
+
+	query, args, err = qb.ToSql()
+	if err != nil {
+		return apperrors.NewInternal().WithDescriptionAndCause("failed to build create day record emotions query", err)
+	}
+	if _, err := tx.Exec(ctx, query, args...); err != nil {
+		return apperrors.NewInternal().WithDescriptionAndCause("failed to create day record emotions", err)
+	}
+
+	return nil
+}
+
+func (s *dayRecordStorage) loadEmotions(ctx context.Context, records []models.DayRecord) error {
+	ids := make([]string, 0, len(records))
+	byID := make(map[string]*models.DayRecord, len(records))
+	for i := range records {
+		ids = append(ids, records[i].ID)
+		byID[records[i].ID] = &records[i]
+	}
+
+	query, args, err := s.builder.
+		Select("day_record_id", "emotion", "intensity").
+		From(dayRecordEmotionsTableName).
+		Where(squirrel.Eq{"day_record_id": ids}).
+		ToSql()
+	if err != nil {
+		return apperrors.NewInternal().WithDescriptionAndCause("failed to build list day record emotions query", err)
+	}
+
+	rows, err := s.conn.Query(ctx, query, args...)
+	if err != nil {
+		return apperrors.NewInternal().WithDescriptionAndCause("failed to list day record emotions", err)
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var recordID string
+		var emotion models.DayEmotion
+		if err := rows.Scan(&recordID, &emotion.Emotion, &emotion.Intensity); err != nil {
+			return apperrors.NewInternal().WithDescriptionAndCause("failed to scan day record emotion", err)
+		}
+		if record, ok := byID[recordID]; ok {
+			record.Mood.Emotions = append(record.Mood.Emotions, emotion)
+		}
+	}
+	if err := rows.Err(); err != nil {
+		return apperrors.NewInternal().WithDescriptionAndCause("failed to list day record emotions", err)
+	}
+
+	for _, record := range records {
+		models.SortDayEmotions(record.Mood.Emotions)
+	}
+
+	return nil
+}
+
+func applyDayRecordFilter(qb squirrel.SelectBuilder, filter ListDayRecordFilter, alias string) squirrel.SelectBuilder {
+	if filter.ID != "" {
+		qb = qb.Where(squirrel.Eq{alias + "id": filter.ID})
+	}
+	if filter.UserID != "" {
+		qb = qb.Where(squirrel.Eq{alias + "user_id": filter.UserID})
+	}
+	if !filter.DateFrom.IsZero() {
+		qb = qb.Where(squirrel.GtOrEq{alias + "local_date": filter.DateFrom})
+	}
+	if !filter.DateTo.IsZero() {
+		qb = qb.Where(squirrel.LtOrEq{alias + "local_date": filter.DateTo})
+	}
+
+	return qb
+}
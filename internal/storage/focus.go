@@ -4,8 +4,11 @@ import (
 	"attune/internal/models"
 	"attune/pkg/apperrors"
 	"context"
+	"errors"
 	"github.com/Masterminds/squirrel"
+	"github.com/jackc/pgx/v5/pgconn"
 	"github.com/jackc/pgx/v5/pgxpool"
+	"strings"
 	"time"
 )
 
@@ -14,11 +17,23 @@ type FocusSessionStorage interface {
 	List(ctx context.Context, filter ListFocusSessionFilter) ([]models.FocusSession, int64, error)
 	Update(ctx context.Context, session models.FocusSession) error
 	Delete(ctx context.Context, id string) error
+	// Totals counts completed focus sessions and sums up the focused time of all finished
+	// ones, breaks aside.
+	Totals(ctx context.Context, userID string) (int, time.Duration, error)
 }
 
 type ListFocusSessionFilter struct {
-	ID     string `json:"id"`
-	UserID string `json:"userId"`
+	ID           string                    `json:"id"`
+	UserID       string                    `json:"userId"`
+	Status       models.FocusSessionStatus `json:"status"`
+	LabelID      string                    `json:"labelId"`
+	RoomID       string                    `json:"roomId"`
+	StartedFrom  time.Time                 `json:"startedFrom"`
+	StartedTo    time.Time                 `json:"startedTo"`
+	OnlyFinished bool                      `json:"onlyFinished"`
+	NoBreaks     bool                      `json:"noBreaks"`
+	NoteQuery    string                    `json:"noteQuery"`
+	Limit        uint64                    `json:"limit"`
 }
 
 type focusSessionStorage struct {
@@ -40,8 +55,21 @@ func (s *focusSessionStorage) Create(ctx context.Context, session models.FocusSe
 		Columns(
 			"id",
 			"user_id",
+			"label_id",
+			"room_id",
 			"quality",
+			"note",
 			"status",
+			"mode",
+			"pomodoro",
+			"planned_duration",
+			"remaining",
+			"paused",
+			"paused_at",
+			"paused_duration",
+			"focused_duration",
+			"rest_duration",
+			"last_started_at",
 			"started_at",
 			"ended_at",
 			"created_at",
@@ -50,8 +78,21 @@ func (s *focusSessionStorage) Create(ctx context.Context, session models.FocusSe
 		Values(
 			session.ID,
 			session.UserID,
+			nullableID(session.LabelID),
+			nullableID(session.RoomID),
 			session.Quality,
+			session.Note,
 			session.Status,
+			session.Mode,
+			session.Pomodoro,
+			session.PlannedDuration,
+			session.Remaining,
+			session.Paused,
+			session.PausedAt,
+			session.PausedDuration,
+			session.FocusedDuration,
+			session.RestDuration,
+			session.LastStartedAt,
 			session.StartedAt,
 			session.EndedAt,
 			session.CreatedAt,
@@ -64,6 +105,11 @@ func (s *focusSessionStorage) Create(ctx context.Context, session models.FocusSe
 
 	_, err = s.conn.Exec(ctx, query, args...)
 	if err != nil {
+		var pgErr *pgconn.PgError
+		if errors.As(err, &pgErr) && pgErr.Code == codeUnique {
+			return apperrors.NewConflict().WithDescription("user already has an active focus session")
+		}
+
 		return apperrors.NewInternal().WithDescriptionAndCause("failed to create focus session", err)
 	}
 
@@ -78,8 +124,21 @@ func (s *focusSessionStorage) List(ctx context.Context, filter ListFocusSessionF
 		Select(
 			"id",
 			"user_id",
+			"label_id",
+			"room_id",
 			"quality",
+			"note",
 			"status",
+			"mode",
+			"pomodoro",
+			"planned_duration",
+			"remaining",
+			"paused",
+			"paused_at",
+			"paused_duration",
+			"focused_duration",
+			"rest_duration",
+			"last_started_at",
 			"started_at",
 			"ended_at",
 			"created_at",
@@ -94,8 +153,35 @@ func (s *focusSessionStorage) List(ctx context.Context, filter ListFocusSessionF
 	if filter.UserID != "" {
 		qb = qb.Where(squirrel.Eq{"user_id": filter.UserID})
 	}
+	if filter.Status != "" {
+		qb = qb.Where(squirrel.Eq{"status": filter.Status})
+	}
+	if filter.LabelID != "" {
+		qb = qb.Where(squirrel.Eq{"label_id": filter.LabelID})
+	}
+	if filter.RoomID != "" {
+		qb = qb.Where(squirrel.Eq{"room_id": filter.RoomID})
+	}
+	if !filter.StartedFrom.IsZero() {
+		qb = qb.Where(squirrel.GtOrEq{"started_at": filter.StartedFrom})
+	}
+	if !filter.StartedTo.IsZero() {
+		qb = qb.Where(squirrel.Lt{"started_at": filter.StartedTo})
+	}
+	if filter.OnlyFinished {
+		qb = qb.Where(squirrel.NotEq{"status": models.FocusSessionStatusActive})
+	}
+	if filter.NoBreaks {
+		qb = qb.Where(squirrel.NotEq{"mode": models.FocusSessionModeBreak})
+	}
+	if filter.NoteQuery != "" {
+		qb = qb.Where(squirrel.ILike{"note": "%" + likeEscaper.Replace(filter.NoteQuery) + "%"})
+	}
+	if filter.Limit > 0 {
+		qb = qb.Limit(filter.Limit)
+	}
 
-	query, args, err := qb.ToSql()
+	query, args, err := qb.OrderBy("created_at DESC").ToSql()
 	if err != nil {
 		return nil, 0, apperrors.NewInternal().WithDescriptionAndCause("failed to build list focus sessions query", err)
 	}
@@ -108,12 +194,26 @@ func (s *focusSessionStorage) List(ctx context.Context, filter ListFocusSessionF
 
 	for rows.Next() {
 		var session models.FocusSession
+		var labelID, roomID *string
 		var count int64
 		if err := rows.Scan(
 			&session.ID,
 			&session.UserID,
+			&labelID,
+			&roomID,
 			&session.Quality,
+			&session.Note,
 			&session.Status,
+			&session.Mode,
+			&session.Pomodoro,
+			&session.PlannedDuration,
+			&session.Remaining,
+			&session.Paused,
+			&session.PausedAt,
+			&session.PausedDuration,
+			&session.FocusedDuration,
+			&session.RestDuration,
+			&session.LastStartedAt,
 			&session.StartedAt,
 			&session.EndedAt,
 			&session.CreatedAt,
@@ -122,6 +222,12 @@ func (s *focusSessionStorage) List(ctx context.Context, filter ListFocusSessionF
 		); err != nil {
 			return nil, 0, apperrors.NewInternal().WithDescriptionAndCause("failed to scan focus session", err)
 		}
+		if labelID != nil {
+			session.LabelID = *labelID
+		}
+		if roomID != nil {
+			session.RoomID = *roomID
+		}
 		if totalCount == 0 {
 			totalCount = count
 		}
@@ -133,11 +239,22 @@ func (s *focusSessionStorage) List(ctx context.Context, filter ListFocusSessionF
 
 	return sessions, totalCount, nil
 }
+
 func (s *focusSessionStorage) Update(ctx context.Context, session models.FocusSession) error {
 	query, args, err := s.builder.
 		Update(focusSessionsTableName).
 		Set("quality", session.Quality).
+		Set("note", session.Note).
 		Set("status", session.Status).
+		Set("pomodoro", session.Pomodoro).
+		Set("planned_duration", session.PlannedDuration).
+		Set("remaining", session.Remaining).
+		Set("paused", session.Paused).
+		Set("paused_at", session.PausedAt).
+		Set("paused_duration", session.PausedDuration).
+		Set("focused_duration", session.FocusedDuration).
+		Set("rest_duration", session.RestDuration).
+		Set("last_started_at", session.LastStartedAt).
 		Set("started_at", session.StartedAt).
 		Set("ended_at", session.EndedAt).
 		Set("updated_at", time.Now()).
@@ -177,3 +294,38 @@ func (s *focusSessionStorage) Delete(ctx context.Context, id string) error {
 
 	return nil
 }
+
+func (s *focusSessionStorage) Totals(ctx context.Context, userID string) (int, time.Duration, error) {
+	query, args, err := s.builder.
+		Select().
+		Column(squirrel.Expr("COUNT(*) FILTER (WHERE status = ?)", models.FocusSessionStatusCompleted)).
+		Column("COALESCE(SUM(focused_duration), INTERVAL '0')").
+		From(focusSessionsTableName).
+		Where(squirrel.Eq{"user_id": userID}).
+		Where(squirrel.NotEq{"status": models.FocusSessionStatusActive}).
+		Where(squirrel.NotEq{"mode": models.FocusSessionModeBreak}).
+		ToSql()
+	if err != nil {
+		return 0, 0, apperrors.NewInternal().WithDescriptionAndCause("failed to build focus session totals query", err)
+	}
+
+	var (
+		sessions int
+		focused  time.Duration
+	)
+	if err := s.conn.QueryRow(ctx, query, args...).Scan(&sessions, &focused); err != nil {
+		return 0, 0, apperrors.NewInternal().WithDescriptionAndCause("failed to get focus session totals", err)
+	}
+
+	return sessions, focused, nil
+}
+
+func nullableID(id string) *string {
+	if id == "" {
+		return nil
+	}
+
+	return &id
+}
+
+var likeEscaper = strings.NewReplacer(`\`, `\\`, `%`, `\%`, `_`, `\_`)
@@ -0,0 +1,241 @@
+package storage
+
+import (
+	"attune/internal/models"
+	"attune/pkg/apperrors"
+	"context"
+	"github.com/Masterminds/squirrel"
+	"github.com/jackc/pgx/v5/pgxpool"
+	"time"
+)
+
+const focusDistractionTopReasons = 3
+
+type FocusDistractionStorage interface {
+	Create(ctx context.Context, distraction models.FocusDistraction) error
+	List(ctx context.Context, filter ListFocusDistractionFilter) ([]models.FocusDistraction, int64, error)
+	Stats(ctx context.Context, filter FocusDistractionStatsFilter) (models.FocusDistractionStats, error)
+}
+
+type ListFocusDistractionFilter struct {
+	SessionID string `json:"sessionId"`
+	UserID    string `json:"userId"`
+}
+
+type FocusDistractionStatsFilter struct {
+	UserID string    `json:"userId"`
+	From   time.Time `json:"from"`
+	To     time.Time `json:"to"`
+}
+
+type focusDistractionStorage struct {
+	conn    *pgxpool.Pool
+	builder squirrel.StatementBuilderType
+}
+
+func NewFocusDistractionStorage(conn *pgxpool.Pool) FocusDistractionStorage {
+	builder := squirrel.StatementBuilder.PlaceholderFormat(squirrel.Dollar)
+	return &focusDistractionStorage{
+		conn:    conn,
+		builder: builder,
+	}
+}
+
+func (s *focusDistractionStorage) Create(ctx context.Context, distraction models.FocusDistraction) error {
+	query, args, err := s.builder.
+		Insert(focusDistractionsTableName).
+		Columns(
+			"id",
+			"session_id",
+			"user_id",
+			"reason",
+			"created_at",
+		).
+		Values(
+			distraction.ID,
+			distraction.SessionID,
+			distraction.UserID,
+			distraction.Reason,
+			distraction.CreatedAt,
+		).
+		ToSql()
+	if err != nil {
+		return apperrors.NewInternal().WithDescriptionAndCause("failed to build create focus distraction query", err)
+	}
+
+	_, err = s.conn.Exec(ctx, query, args...)
+	if err != nil {
+		return apperrors.NewInternal().WithDescriptionAndCause("failed to create focus distraction", err)
+	}
+
+	return nil
+}
+
+func (s *focusDistractionStorage) List(ctx context.Context, filter ListFocusDistractionFilter) ([]models.FocusDistraction, int64, error) {
+	var distractions []models.FocusDistraction
+	var totalCount int64
+
+	qb := s.builder.
+		Select(
+			"id",
+			"session_id",
+			"user_id",
+			"reason",
+			"created_at",
+			"COUNT(*) OVER() AS total_count",
+		).
+		From(focusDistractionsTableName)
+
+	if filter.SessionID != "" {
+		qb = qb.Where(squirrel.Eq{"session_id": filter.SessionID})
+	}
+	if filter.UserID != "" {
+		qb = qb.Where(squirrel.Eq{"user_id": filter.UserID})
+	}
+
+	query, args, err := qb.OrderBy("created_at").ToSql()
+	if err != nil {
+		return nil, 0, apperrors.NewInternal().WithDescriptionAndCause("failed to build list focus distractions query", err)
+	}
+
+	rows, err := s.conn.Query(ctx, query, args...)
+	if err != nil {
+		return nil, 0, apperrors.NewInternal().WithDescriptionAndCause("failed to list focus distractions", err)
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var distraction models.FocusDistraction
+		var count int64
+		if err := rows.Scan(
+			&distraction.ID,
+			&distraction.SessionID,
+			&distraction.UserID,
+			&distraction.Reason,
+			&distraction.CreatedAt,
+			&count,
+		); err != nil {
+			return nil, 0, apperrors.NewInternal().WithDescriptionAndCause("failed to scan focus distraction", err)
+		}
+		if totalCount == 0 {
+			totalCount = count
+		}
+		distractions = append(distractions, distraction)
+	}
+	if len(distractions) == 0 {
+		return nil, 0, apperrors.NewNotFound().WithDescription("no focus distractions found")
+	}
+
+	return distractions, totalCount, nil
+}
+
+func (s *focusDistractionStorage) Stats(ctx context.Context, filter FocusDistractionStatsFilter) (models.FocusDistractionStats, error) {
+	var stats models.FocusDistractionStats
+
+	sessions := s.builder.
+		Select(
+			"s.quality",
+			"(SELECT COUNT(*) FROM "+focusDistractionsTableName+" d WHERE d.session_id = s.id) AS distractions",
+		).
+		From(focusSessionsTableName + " s").
+		Where(squirrel.Eq{"s.user_id": filter.UserID}).
+		Where(squirrel.NotEq{"s.status": models.FocusSessionStatusActive}).
+		Where(squirrel.NotEq{"s.mode": models.FocusSessionModeBreak})
+	if !filter.From.IsZero() {
+		sessions = sessions.Where(squirrel.GtOrEq{"s.started_at": filter.From})
+	}
+	if !filter.To.IsZero() {
+		sessions = sessions.Where(squirrel.Lt{"s.started_at": filter.To})
+	}
+
+	query, args, err := s.builder.
+		Select(
+			"distractions",
+			"COUNT(*)",
+			"COUNT(*) FILTER (WHERE quality > 0)",
+			"COALESCE(AVG(quality) FILTER (WHERE quality > 0), 0)::FLOAT8",
+		).
+		FromSelect(sessions, "t").
+		GroupBy("distractions").
+		OrderBy("distractions").
+		ToSql()
+	if err != nil {
+		return stats, apperrors.NewInternal().WithDescriptionAndCause("failed to build focus distraction stats query", err)
+	}
+
+	rows, err := s.conn.Query(ctx, query, args...)
+	if err != nil {
+		return stats, apperrors.NewInternal().WithDescriptionAndCause("failed to get focus distraction stats", err)
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var quality models.FocusDistractionQuality
+		if err := rows.Scan(
+			&quality.Distractions,
+			&quality.Sessions,
+			&quality.RatedSessions,
+			&quality.AverageQuality,
+		); err != nil {
+			return stats, apperrors.NewInternal().WithDescriptionAndCause("failed to scan focus distraction stats", err)
+		}
+		stats.Sessions += quality.Sessions
+		stats.Distractions += quality.Distractions * quality.Sessions
+		stats.Quality = append(stats.Quality, quality)
+	}
+	if err := rows.Err(); err != nil {
+		return stats, apperrors.NewInternal().WithDescriptionAndCause("failed to get focus distraction stats", err)
+	}
+	if stats.Sessions == 0 {
+		return stats, apperrors.NewNotFound().WithDescription("no finished focus sessions found")
+	}
+
+	stats.TopReasons, err = s.topReasons(ctx, filter)
+	if err != nil {
+		return stats, err
+	}
+
+	return stats, nil
+}
+
+func (s *focusDistractionStorage) topReasons(ctx context.Context, filter FocusDistractionStatsFilter) ([]models.FocusDistractionReason, error) {
+	var reasons []models.FocusDistractionReason
+
+	qb := s.builder.
+		Select("d.reason", "COUNT(*)").
+		From(focusDistractionsTableName + " d").
+		Join(focusSessionsTableName + " s ON s.id = d.session_id").
+		Where(squirrel.Eq{"d.user_id": filter.UserID}).
+		Where(squirrel.NotEq{"d.reason": ""})
+	if !filter.From.IsZero() {
+		qb = qb.Where(squirrel.GtOrEq{"s.started_at": filter.From})
+	}
+	if !filter.To.IsZero() {
+		qb = qb.Where(squirrel.Lt{"s.started_at": filter.To})
+	}
+
+	query, args, err := qb.
+		GroupBy("d.reason").
+		OrderBy("COUNT(*) DESC", "d.reason").
+		Limit(focusDistractionTopReasons).
+		ToSql()
+	if err != nil {
+		return nil, apperrors.NewInternal().WithDescriptionAndCause("failed to build focus distraction reasons query", err)
+	}
+
+	rows, err := s.conn.Query(ctx, query, args...)
+	if err != nil {
+		return nil, apperrors.NewInternal().WithDescriptionAndCause("failed to get focus distraction reasons", err)
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var reason models.FocusDistractionReason
+		if err := rows.Scan(&reason.Reason, &reason.Count); err != nil {
+			return nil, apperrors.NewInternal().WithDescriptionAndCause("failed to scan focus distraction reason", err)
+		}
+		reasons = append(reasons, reason)
+	}
+
+	return reasons, nil
+}
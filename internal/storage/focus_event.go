@@ -0,0 +1,141 @@
+package storage
+
+import (
+	"attune/internal/models"
+	"attune/pkg/apperrors"
+	"context"
+	"github.com/Masterminds/squirrel"
+	"github.com/jackc/pgx/v5/pgxpool"
+	"time"
+)
+
+type FocusSessionEventStorage interface {
+	Create(ctx context.Context, event models.FocusSessionEvent) error
+	List(ctx context.Context, filter ListFocusSessionEventFilter) ([]models.FocusSessionEvent, int64, error)
+}
+
+type ListFocusSessionEventFilter struct {
+	SessionID      string                       `json:"sessionId"`
+	UserID         string                       `json:"userId"`
+	Type           models.FocusSessionEventType `json:"type"`
+	OccurredAfter  time.Time                    `json:"occurredAfter"`
+	OccurredBefore time.Time                    `json:"occurredBefore"`
+}
+
+type focusSessionEventStorage struct {
+	conn    *pgxpool.Pool
+	builder squirrel.StatementBuilderType
+}
+
+func NewFocusSessionEventStorage(conn *pgxpool.Pool) FocusSessionEventStorage {
+	builder := squirrel.StatementBuilder.PlaceholderFormat(squirrel.Dollar)
+	return &focusSessionEventStorage{
+		conn:    conn,
+		builder: builder,
+	}
+}
+
+func (s *focusSessionEventStorage) Create(ctx context.Context, event models.FocusSessionEvent) error {
+	query, args, err := s.builder.
+		Insert(focusSessionEventsTableName).
+		Columns(
+			"id",
+			"session_id",
+			"user_id",
+			"type",
+			"source",
+			"occurred_at",
+			"created_at",
+		).
+		Values(
+			event.ID,
+			event.SessionID,
+			event.UserID,
+			event.Type,
+			event.Source,
+			event.OccurredAt,
+			event.CreatedAt,
+		).
+		ToSql()
+	if err != nil {
+		return apperrors.NewInternal().WithDescriptionAndCause("failed to build create focus session event query", err)
+	}
+
+	_, err = s.conn.Exec(ctx, query, args...)
+	if err != nil {
+		return apperrors.NewInternal().WithDescriptionAndCause("failed to create focus session event", err)
+	}
+
+	return nil
+}
+
+func (s *focusSessionEventStorage) List(ctx context.Context, filter ListFocusSessionEventFilter) ([]models.FocusSessionEvent, int64, error) {
+	var events []models.FocusSessionEvent
+	var totalCount int64
+
+	qb := s.builder.
+		Select(
+			"id",
+			"session_id",
+			"user_id",
+			"type",
+			"source",
+			"occurred_at",
+			"created_at",
+			"COUNT(*) OVER() AS total_count",
+		).
+		From(focusSessionEventsTableName)
+
+	if filter.SessionID != "" {
+		qb = qb.Where(squirrel.Eq{"session_id": filter.SessionID})
+	}
+	if filter.UserID != "" {
+		qb = qb.Where(squirrel.Eq{"user_id": filter.UserID})
+	}
+	if filter.Type != "" {
+		qb = qb.Where(squirrel.Eq{"type": filter.Type})
+	}
+	if !filter.OccurredAfter.IsZero() {
+		qb = qb.Where(squirrel.GtOrEq{"occurred_at": filter.OccurredAfter})
+	}
+	if !filter.OccurredBefore.IsZero() {
+		qb = qb.Where(squirrel.Lt{"occurred_at": filter.OccurredBefore})
+	}
+
+	query, args, err := qb.OrderBy("occurred_at ASC").ToSql()
+	if err != nil {
+		return nil, 0, apperrors.NewInternal().WithDescriptionAndCause("failed to build list focus session events query", err)
+	}
+
+	rows, err := s.conn.Query(ctx, query, args...)
+	if err != nil {
+		return nil, 0, apperrors.NewInternal().WithDescriptionAndCause("failed to list focus session events", err)
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var event models.FocusSessionEvent
+		var count int64
+		if err := rows.Scan(
+			&event.ID,
+			&event.SessionID,
+			&event.UserID,
+			&event.Type,
+			&event.Source,
+			&event.OccurredAt,
+			&event.CreatedAt,
+			&count,
+		); err != nil {
+			return nil, 0, apperrors.NewInternal().WithDescriptionAndCause("failed to scan focus session event", err)
+		}
+		if totalCount == 0 {
+			totalCount = count
+		}
+		events = append(events, event)
+	}
+	if len(events) == 0 {
+		return nil, 0, apperrors.NewNotFound().WithDescription("no focus session events found")
+	}
+
+	return events, totalCount, nil
+}
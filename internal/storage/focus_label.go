@@ -0,0 +1,254 @@
+package storage
+
+import (
+	"attune/internal/models"
+	"attune/pkg/apperrors"
+	"context"
+	"errors"
+	"github.com/Masterminds/squirrel"
+	"github.com/jackc/pgx/v5/pgconn"
+	"github.com/jackc/pgx/v5/pgxpool"
+	"time"
+)
+
+type FocusLabelStorage interface {
+	Create(ctx context.Context, label models.FocusLabel) error
+	List(ctx context.Context, filter ListFocusLabelFilter) ([]models.FocusLabel, int64, error)
+	Touch(ctx context.Context, id string, at time.Time) error
+	Stats(ctx context.Context, filter FocusLabelStatsFilter) ([]models.FocusLabelStats, error)
+	Delete(ctx context.Context, id string) error
+}
+
+type ListFocusLabelFilter struct {
+	ID     string `json:"id"`
+	UserID string `json:"userId"`
+	Name   string `json:"name"`
+	Limit  uint64 `json:"limit"`
+}
+
+type FocusLabelStatsFilter struct {
+	UserID  string    `json:"userId"`
+	LabelID string    `json:"labelId"`
+	From    time.Time `json:"from"`
+	To      time.Time `json:"to"`
+}
+
+type focusLabelStorage struct {
+	conn    *pgxpool.Pool
+	builder squirrel.StatementBuilderType
+}
+
+func NewFocusLabelStorage(conn *pgxpool.Pool) FocusLabelStorage {
+	builder := squirrel.StatementBuilder.PlaceholderFormat(squirrel.Dollar)
+	return &focusLabelStorage{
+		conn:    conn,
+		builder: builder,
+	}
+}
+
+func (s *focusLabelStorage) Create(ctx context.Context, label models.FocusLabel) error {
+	query, args, err := s.builder.
+		Insert(focusLabelsTableName).
+		Columns(
+			"id",
+			"user_id",
+			"name",
+			"last_used_at",
+			"created_at",
+			"updated_at",
+		).
+		Values(
+			label.ID,
+			label.UserID,
+			label.Name,
+			label.LastUsedAt,
+			label.CreatedAt,
+			label.UpdatedAt,
+		).
+		ToSql()
+	if err != nil {
+		return apperrors.NewInternal().WithDescriptionAndCause("failed to build create focus label query", err)
+	}
+
+	_, err = s.conn.Exec(ctx, query, args...)
+	if err != nil {
+		var pgErr *pgconn.PgError
+		if errors.As(err, &pgErr) && pgErr.Code == codeUnique {
+			return apperrors.NewConflict().WithDescription("focus label already exists")
+		}
+
+		return apperrors.NewInternal().WithDescriptionAndCause("failed to create focus label", err)
+	}
+
+	return nil
+}
+
+func (s *focusLabelStorage) List(ctx context.Context, filter ListFocusLabelFilter) ([]models.FocusLabel, int64, error) {
+	var labels []models.FocusLabel
+	var totalCount int64
+
+	qb := s.builder.
+		Select(
+			"id",
+			"user_id",
+			"name",
+			"last_used_at",
+			"created_at",
+			"updated_at",
+			"COUNT(*) OVER() AS total_count",
+		).
+		From(focusLabelsTableName)
+
+	if filter.ID != "" {
+		qb = qb.Where(squirrel.Eq{"id": filter.ID})
+	}
+	if filter.UserID != "" {
+		qb = qb.Where(squirrel.Eq{"user_id": filter.UserID})
+	}
+	if filter.Name != "" {
+		qb = qb.Where(squirrel.Expr("LOWER(name) = LOWER(?)", filter.Name))
+	}
+	if filter.Limit > 0 {
+		qb = qb.Limit(filter.Limit)
+	}
+
+	query, args, err := qb.OrderBy("last_used_at DESC").ToSql()
+	if err != nil {
+		return nil, 0, apperrors.NewInternal().WithDescriptionAndCause("failed to build list focus labels query", err)
+	}
+
+	rows, err := s.conn.Query(ctx, query, args...)
+	if err != nil {
+		return nil, 0, apperrors.NewInternal().WithDescriptionAndCause("failed to list focus labels", err)
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var label models.FocusLabel
+		var count int64
+		if err := rows.Scan(
+			&label.ID,
+			&label.UserID,
+			&label.Name,
+			&label.LastUsedAt,
+			&label.CreatedAt,
+			&label.UpdatedAt,
+			&count,
+		); err != nil {
+			return nil, 0, apperrors.NewInternal().WithDescriptionAndCause("failed to scan focus label", err)
+		}
+		if totalCount == 0 {
+			totalCount = count
+		}
+		labels = append(labels, label)
+	}
+	if len(labels) == 0 {
+		return nil, 0, apperrors.NewNotFound().WithDescription("no focus labels found")
+	}
+
+	return labels, totalCount, nil
+}
+
+func (s *focusLabelStorage) Touch(ctx context.Context, id string, at time.Time) error {
+	query, args, err := s.builder.
+		Update(focusLabelsTableName).
+		Set("last_used_at", at).
+		Set("updated_at", time.Now()).
+		Where(squirrel.Eq{"id": id}).
+		ToSql()
+	if err != nil {
+		return apperrors.NewInternal().WithDescriptionAndCause("failed to build touch focus label query", err)
+	}
+
+	result, err := s.conn.Exec(ctx, query, args...)
+	if err != nil {
+		return apperrors.NewInternal().WithDescriptionAndCause("failed to touch focus label", err)
+	}
+	if result.RowsAffected() == 0 {
+		return apperrors.NewNotFound().WithDescription("focus label not found")
+	}
+
+	return nil
+}
+
+func (s *focusLabelStorage) Stats(ctx context.Context, filter FocusLabelStatsFilter) ([]models.FocusLabelStats, error) {
+	var stats []models.FocusLabelStats
+
+	qb := s.builder.
+		Select(
+			"l.id",
+			"l.name",
+			"COUNT(s.id)",
+			"COALESCE(SUM(s.focused_duration), INTERVAL '0')",
+			"COALESCE(AVG(s.quality) FILTER (WHERE s.quality > 0), 0)::FLOAT8",
+		).
+		From(focusLabelsTableName + " l").
+		Join(focusSessionsTableName + " s ON s.label_id = l.id").
+		Where(squirrel.NotEq{"s.status": models.FocusSessionStatusActive})
+
+	if filter.UserID != "" {
+		qb = qb.Where(squirrel.Eq{"l.user_id": filter.UserID})
+	}
+	if filter.LabelID != "" {
+		qb = qb.Where(squirrel.Eq{"l.id": filter.LabelID})
+	}
+	if !filter.From.IsZero() {
+		qb = qb.Where(squirrel.GtOrEq{"s.started_at": filter.From})
+	}
+	if !filter.To.IsZero() {
+		qb = qb.Where(squirrel.Lt{"s.started_at": filter.To})
+	}
+
+	query, args, err := qb.
+		GroupBy("l.id", "l.name").
+		OrderBy("SUM(s.focused_duration) DESC").
+		ToSql()
+	if err != nil {
+		return nil, apperrors.NewInternal().WithDescriptionAndCause("failed to build focus label stats query", err)
+	}
+
+	rows, err := s.conn.Query(ctx, query, args...)
+	if err != nil {
+		return nil, apperrors.NewInternal().WithDescriptionAndCause("failed to get focus label stats", err)
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var stat models.FocusLabelStats
+		if err := rows.Scan(
+			&stat.LabelID,
+			&stat.Name,
+			&stat.Sessions,
+			&stat.FocusedDuration,
+			&stat.AverageQuality,
+		); err != nil {
+			return nil, apperrors.NewInternal().WithDescriptionAndCause("failed to scan focus label stats", err)
+		}
+		stats = append(stats, stat)
+	}
+	if len(stats) == 0 {
+		return nil, apperrors.NewNotFound().WithDescription("no focus label stats found")
+	}
+
+	return stats, nil
+}
+
+func (s *focusLabelStorage) Delete(ctx context.Context, id string) error {
+	query, args, err := s.builder.
+		Delete(focusLabelsTableName).
+		Where(squirrel.Eq{"id": id}).
+		ToSql()
+	if err != nil {
+		return apperrors.NewInternal().WithDescriptionAndCause("failed to build delete focus label query", err)
+	}
+
+	result, err := s.conn.Exec(ctx, query, args...)
+	if err != nil {
+		return apperrors.NewInternal().WithDescriptionAndCause("failed to delete focus label", err)
+	}
+	if result.RowsAffected() == 0 {
+		return apperrors.NewNotFound().WithDescription("focus label not found")
+	}
+
+	return nil
+}
@@ -0,0 +1,272 @@
+package storage
+
+import (
+	"attune/internal/models"
+	"attune/pkg/apperrors"
+	"context"
+	"errors"
+	"github.com/Masterminds/squirrel"
+	"github.com/jackc/pgx/v5/pgconn"
+	"github.com/jackc/pgx/v5/pgxpool"
+	"time"
+)
+
+type FocusRoomStorage interface {
+	Create(ctx context.Context, room models.FocusRoom) error
+	List(ctx context.Context, filter ListFocusRoomFilter) ([]models.FocusRoom, int64, error)
+	Update(ctx context.Context, room models.FocusRoom) error
+	// Transition reports whether the room was still in the expected status, so concurrent
+	// callers act on it only once.
+	Transition(ctx context.Context, id string, from, to models.FocusRoomStatus) (bool, error)
+	AddParticipant(ctx context.Context, participant models.FocusRoomParticipant) error
+	ListParticipants(ctx context.Context, roomID string) ([]models.FocusRoomParticipant, error)
+}
+
+type ListFocusRoomFilter struct {
+	ID     string                 `json:"id"`
+	ChatID string                 `json:"chatId"`
+	Status models.FocusRoomStatus `json:"status"`
+}
+
+type focusRoomStorage struct {
+	conn    *pgxpool.Pool
+	builder squirrel.StatementBuilderType
+}
+
+func NewFocusRoomStorage(conn *pgxpool.Pool) FocusRoomStorage {
+	builder := squirrel.StatementBuilder.PlaceholderFormat(squirrel.Dollar)
+	return &focusRoomStorage{
+		conn:    conn,
+		builder: builder,
+	}
+}
+
+func (s *focusRoomStorage) Create(ctx context.Context, room models.FocusRoom) error {
+	query, args, err := s.builder.
+		Insert(focusRoomsTableName).
+		Columns(
+			"id",
+			"chat_id",
+			"host_user_id",
+			"duration",
+			"status",
+			"summary_message_id",
+			"started_at",
+			"created_at",
+			"updated_at",
+		).
+		Values(
+			room.ID,
+			room.ChatID,
+			room.HostUserID,
+			room.Duration,
+			room.Status,
+			room.SummaryMessageID,
+			room.StartedAt,
+			room.CreatedAt,
+			room.UpdatedAt,
+		).
+		ToSql()
+	if err != nil {
+		return apperrors.NewInternal().WithDescriptionAndCause("failed to build create focus room query", err)
+	}
+
+	_, err = s.conn.Exec(ctx, query, args...)
+	if err != nil {
+		return apperrors.NewInternal().WithDescriptionAndCause("failed to create focus room", err)
+	}
+
+	return nil
+}
+
+func (s *focusRoomStorage) List(ctx context.Context, filter ListFocusRoomFilter) ([]models.FocusRoom, int64, error) {
+	var rooms []models.FocusRoom
+	var totalCount int64
+
+	qb := s.builder.
+		Select(
+			"id",
+			"chat_id",
+			"host_user_id",
+			"duration",
+			"status",
+			"summary_message_id",
+			"started_at",
+			"created_at",
+			"updated_at",
+			"COUNT(*) OVER() AS total_count",
+		).
+		From(focusRoomsTableName)
+
+	if filter.ID != "" {
+		qb = qb.Where(squirrel.Eq{"id": filter.ID})
+	}
+	if filter.ChatID != "" {
+		qb = qb.Where(squirrel.Eq{"chat_id": filter.ChatID})
+	}
+	if filter.Status != "" {
+		qb = qb.Where(squirrel.Eq{"status": filter.Status})
+	}
+
+	query, args, err := qb.OrderBy("created_at DESC").ToSql()
+	if err != nil {
+		return nil, 0, apperrors.NewInternal().WithDescriptionAndCause("failed to build list focus rooms query", err)
+	}
+
+	rows, err := s.conn.Query(ctx, query, args...)
+	if err != nil {
+		return nil, 0, apperrors.NewInternal().WithDescriptionAndCause("failed to list focus rooms", err)
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var room models.FocusRoom
+		var count int64
+		if err := rows.Scan(
+			&room.ID,
+			&room.ChatID,
+			&room.HostUserID,
+			&room.Duration,
+			&room.Status,
+			&room.SummaryMessageID,
+			&room.StartedAt,
+			&room.CreatedAt,
+			&room.UpdatedAt,
+			&count,
+		); err != nil {
+			return nil, 0, apperrors.NewInternal().WithDescriptionAndCause("failed to scan focus room", err)
+		}
+		if totalCount == 0 {
+			totalCount = count
+		}
+		rooms = append(rooms, room)
+	}
+	if len(rooms) == 0 {
+		return nil, 0, apperrors.NewNotFound().WithDescription("no focus rooms found")
+	}
+
+	return rooms, totalCount, nil
+}
+
+func (s *focusRoomStorage) Update(ctx context.Context, room models.FocusRoom) error {
+	query, args, err := s.builder.
+		Update(focusRoomsTableName).
+		Set("status", room.Status).
+		Set("summary_message_id", room.SummaryMessageID).
+		Set("started_at", room.StartedAt).
+		Set("updated_at", time.Now()).
+		Where(squirrel.Eq{"id": room.ID}).
+		ToSql()
+	if err != nil {
+		return apperrors.NewInternal().WithDescriptionAndCause("failed to build update focus room query", err)
+	}
+
+	result, err := s.conn.Exec(ctx, query, args...)
+	if err != nil {
+		return apperrors.NewInternal().WithDescriptionAndCause("failed to update focus room", err)
+	}
+	if result.RowsAffected() == 0 {
+		return apperrors.NewNotFound().WithDescription("focus room not found")
+	}
+
+	return nil
+}
+
+func (s *focusRoomStorage) Transition(ctx context.Context, id string, from, to models.FocusRoomStatus) (bool, error) {
+	query, args, err := s.builder.
+		Update(focusRoomsTableName).
+		Set("status", to).
+		Set("updated_at", time.Now()).
+		Where(squirrel.Eq{
+			"id":     id,
+			"status": from,
+		}).
+		ToSql()
+	if err != nil {
+		return false, apperrors.NewInternal().WithDescriptionAndCause("failed to build transition focus room query", err)
+	}
+
+	result, err := s.conn.Exec(ctx, query, args...)
+	if err != nil {
+		return false, apperrors.NewInternal().WithDescriptionAndCause("failed to transition focus room", err)
+	}
+
+	return result.RowsAffected() == 1, nil
+}
+
+func (s *focusRoomStorage) AddParticipant(ctx context.Context, participant models.FocusRoomParticipant) error {
+	query, args, err := s.builder.
+		Insert(focusRoomParticipantsTableName).
+		Columns(
+			"room_id",
+			"user_id",
+			"joined_at",
+		).
+		Values(
+			participant.RoomID,
+			participant.UserID,
+			participant.JoinedAt,
+		).
+		ToSql()
+	if err != nil {
+		return apperrors.NewInternal().WithDescriptionAndCause("failed to build add focus room participant query", err)
+	}
+
+	_, err = s.conn.Exec(ctx, query, args...)
+	if err != nil {
+		var pgErr *pgconn.PgError
+		if errors.As(err, &pgErr) && pgErr.Code == codeUnique {
+			return apperrors.NewConflict().WithDescription("already joined the focus room")
+		}
+
+		return apperrors.NewInternal().WithDescriptionAndCause("failed to add focus room participant", err)
+	}
+
+	return nil
+}
+
+func (s *focusRoomStorage) ListParticipants(ctx context.Context, roomID string) ([]models.FocusRoomParticipant, error) {
+	var participants []models.FocusRoomParticipant
+
+	query, args, err := s.builder.
+		Select(
+			"p.room_id",
+			"p.user_id",
+			"u.vendor_id",
+			"u.name",
+			"p.joined_at",
+		).
+		From(focusRoomParticipantsTableName + " p").
+		Join(userTableName + " u ON u.id = p.user_id").
+		Where(squirrel.Eq{"p.room_id": roomID}).
+		OrderBy("p.joined_at ASC").
+		ToSql()
+	if err != nil {
+		return nil, apperrors.NewInternal().WithDescriptionAndCause("failed to build list focus room participants query", err)
+	}
+
+	rows, err := s.conn.Query(ctx, query, args...)
+	if err != nil {
+		return nil, apperrors.NewInternal().WithDescriptionAndCause("failed to list focus room participants", err)
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var participant models.FocusRoomParticipant
+		if err := rows.Scan(
+			&participant.RoomID,
+			&participant.UserID,
+			&participant.VendorID,
+			&participant.Name,
+			&participant.JoinedAt,
+		); err != nil {
+			return nil, apperrors.NewInternal().WithDescriptionAndCause("failed to scan focus room participant", err)
+		}
+		participants = append(participants, participant)
+	}
+	if len(participants) == 0 {
+		return nil, apperrors.NewNotFound().WithDescription("no focus room participants found")
+	}
+
+	return participants, nil
+}
@@ -0,0 +1,203 @@
+package storage
+
+import (
+	"attune/internal/models"
+	"attune/pkg/apperrors"
+	"context"
+	"github.com/Masterminds/squirrel"
+	"github.com/jackc/pgx/v5/pgxpool"
+	"time"
+)
+
+type FocusScheduleStorage interface {
+	Create(ctx context.Context, schedule models.FocusSchedule) error
+	List(ctx context.Context, filter ListFocusScheduleFilter) ([]models.FocusSchedule, int64, error)
+	// Claim stores the advanced schedule only if its run at prevRunAt has not been
+	// claimed yet, and reports whether it was.
+	Claim(ctx context.Context, schedule models.FocusSchedule, prevRunAt time.Time) (bool, error)
+	Delete(ctx context.Context, id string) error
+}
+
+type ListFocusScheduleFilter struct {
+	ID         string    `json:"id"`
+	UserID     string    `json:"userId"`
+	OnlyActive bool      `json:"onlyActive"`
+	DueBefore  time.Time `json:"dueBefore"`
+}
+
+type focusScheduleStorage struct {
+	conn    *pgxpool.Pool
+	builder squirrel.StatementBuilderType
+}
+
+func NewFocusScheduleStorage(conn *pgxpool.Pool) FocusScheduleStorage {
+	builder := squirrel.StatementBuilder.PlaceholderFormat(squirrel.Dollar)
+	return &focusScheduleStorage{
+		conn:    conn,
+		builder: builder,
+	}
+}
+
+func (s *focusScheduleStorage) Create(ctx context.Context, schedule models.FocusSchedule) error {
+	query, args, err := s.builder.
+		Insert(focusSchedulesTableName).
+		Columns(
+			"id",
+			"user_id",
+			"rule",
+			"start_time",
+			"weekday",
+			"duration",
+			"timezone",
+			"next_run_at",
+			"active",
+			"created_at",
+			"updated_at",
+		).
+		Values(
+			schedule.ID,
+			schedule.UserID,
+			schedule.Rule,
+			schedule.StartTime,
+			int(schedule.Weekday),
+			schedule.Duration,
+			schedule.Timezone,
+			schedule.NextRunAt,
+			schedule.Active,
+			schedule.CreatedAt,
+			schedule.UpdatedAt,
+		).
+		ToSql()
+	if err != nil {
+		return apperrors.NewInternal().WithDescriptionAndCause("failed to build create focus schedule query", err)
+	}
+
+	_, err = s.conn.Exec(ctx, query, args...)
+	if err != nil {
+		return apperrors.NewInternal().WithDescriptionAndCause("failed to create focus schedule", err)
+	}
+
+	return nil
+}
+
+func (s *focusScheduleStorage) List(ctx context.Context, filter ListFocusScheduleFilter) ([]models.FocusSchedule, int64, error) {
+	var schedules []models.FocusSchedule
+	var totalCount int64
+
+	qb := s.builder.
+		Select(
+			"id",
+			"user_id",
+			"rule",
+			"start_time",
+			"weekday",
+			"duration",
+			"timezone",
+			"next_run_at",
+			"active",
+			"created_at",
+			"updated_at",
+			"COUNT(*) OVER() AS total_count",
+		).
+		From(focusSchedulesTableName)
+
+	if filter.ID != "" {
+		qb = qb.Where(squirrel.Eq{"id": filter.ID})
+	}
+	if filter.UserID != "" {
+		qb = qb.Where(squirrel.Eq{"user_id": filter.UserID})
+	}
+	if filter.OnlyActive || !filter.DueBefore.IsZero() {
+		qb = qb.Where(squirrel.Eq{"active": true})
+	}
+	if !filter.DueBefore.IsZero() {
+		qb = qb.Where(squirrel.LtOrEq{"next_run_at": filter.DueBefore})
+	}
+
+	query, args, err := qb.OrderBy("next_run_at ASC").ToSql()
+	if err != nil {
+		return nil, 0, apperrors.NewInternal().WithDescriptionAndCause("failed to build list focus schedules query", err)
+	}
+
+	rows, err := s.conn.Query(ctx, query, args...)
+	if err != nil {
+		return nil, 0, apperrors.NewInternal().WithDescriptionAndCause("failed to list focus schedules", err)
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var schedule models.FocusSchedule
+		var weekday int
+		var count int64
+		if err := rows.Scan(
+			&schedule.ID,
+			&schedule.UserID,
+			&schedule.Rule,
+			&schedule.StartTime,
+			&weekday,
+			&schedule.Duration,
+			&schedule.Timezone,
+			&schedule.NextRunAt,
+			&schedule.Active,
+			&schedule.CreatedAt,
+			&schedule.UpdatedAt,
+			&count,
+		); err != nil {
+			return nil, 0, apperrors.NewInternal().WithDescriptionAndCause("failed to scan focus schedule", err)
+		}
+		schedule.Weekday = time.Weekday(weekday)
+		if totalCount == 0 {
+			totalCount = count
+		}
+		schedules = append(schedules, schedule)
+	}
+	if len(schedules) == 0 {
+		return nil, 0, apperrors.NewNotFound().WithDescription("no focus schedules found")
+	}
+
+	return schedules, totalCount, nil
+}
+
+func (s *focusScheduleStorage) Claim(ctx context.Context, schedule models.FocusSchedule, prevRunAt time.Time) (bool, error) {
+	query, args, err := s.builder.
+		Update(focusSchedulesTableName).
+		Set("next_run_at", schedule.NextRunAt).
+		Set("active", schedule.Active).
+		Set("updated_at", time.Now()).
+		Where(squirrel.Eq{
+			"id":          schedule.ID,
+			"next_run_at": prevRunAt,
+			"active":      true,
+		}).
+		ToSql()
+	if err != nil {
+		return false, apperrors.NewInternal().WithDescriptionAndCause("failed to build claim focus schedule query", err)
+	}
+
+	result, err := s.conn.Exec(ctx, query, args...)
+	if err != nil {
+		return false, apperrors.NewInternal().WithDescriptionAndCause("failed to claim focus schedule", err)
+	}
+
+	return result.RowsAffected() == 1, nil
+}
+
+func (s *focusScheduleStorage) Delete(ctx context.Context, id string) error {
+	query, args, err := s.builder.
+		Delete(focusSchedulesTableName).
+		Where(squirrel.Eq{"id": id}).
+		ToSql()
+	if err != nil {
+		return apperrors.NewInternal().WithDescriptionAndCause("failed to build delete focus schedule query", err)
+	}
+
+	result, err := s.conn.Exec(ctx, query, args...)
+	if err != nil {
+		return apperrors.NewInternal().WithDescriptionAndCause("failed to delete focus schedule", err)
+	}
+	if result.RowsAffected() == 0 {
+		return apperrors.NewNotFound().WithDescription("focus schedule not found")
+	}
+
+	return nil
+}
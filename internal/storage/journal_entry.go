@@ -0,0 +1,199 @@
+package storage
+
+import (
+	"attune/internal/models"
+	"attune/pkg/apperrors"
+	"context"
+	"github.com/Masterminds/squirrel"
+	"github.com/jackc/pgx/v5/pgxpool"
+	"time"
+)
+
+// The simple configuration does no stemming, so entries in any language are searchable.
+const journalSearchConfig = "simple"
+
+type JournalEntryStorage interface {
+	Create(ctx context.Context, entry models.JournalEntry) error
+	List(ctx context.Context, filter ListJournalEntryFilter) ([]models.JournalEntry, int64, error)
+	Update(ctx context.Context, entry models.JournalEntry) error
+	Delete(ctx context.Context, id string) error
+}
+
+type ListJournalEntryFilter struct {
+	ID       string    `json:"id"`
+	UserID   string    `json:"userId"`
+	DateFrom time.Time `json:"dateFrom"`
+	DateTo   time.Time `json:"dateTo"`
+	Query    string    `json:"query"`
+	Limit    uint64    `json:"limit"`
+}
+
+type journalEntryStorage struct {
+	conn    *pgxpool.Pool
+	builder squirrel.StatementBuilderType
+}
+
+func NewJournalEntryStorage(conn *pgxpool.Pool) JournalEntryStorage {
+	builder := squirrel.StatementBuilder.PlaceholderFormat(squirrel.Dollar)
+
+	return &journalEntryStorage{
+		conn:    conn,
+		builder: builder,
+	}
+}
+
+func (s *journalEntryStorage) Create(ctx context.Context, entry models.JournalEntry) error {
+	query, args, err := s.builder.
+		Insert(journalEntriesTableName).
+		Columns(
+			"id",
+			"user_id",
+			"local_date",
+			"text",
+			"created_at",
+			"updated_at",
+		).
+		Values(
+			entry.ID,
+			entry.UserID,
+			entry.LocalDate,
+			entry.Text,
+			entry.CreatedAt,
+			entry.UpdatedAt,
+		).
+		ToSql()
+	if err != nil {
+		return apperrors.NewInternal().WithDescriptionAndCause("failed to build create journal entry query", err)
+	}
+
+	_, err = s.conn.Exec(ctx, query, args...)
+	if err != nil {
+		return apperrors.NewInternal().WithDescriptionAndCause("failed to create journal entry", err)
+	}
+
+	return nil
+}
+
+func (s *journalEntryStorage) List(ctx context.Context, filter ListJournalEntryFilter) ([]models.JournalEntry, int64, error) {
+	var entries []models.JournalEntry
+	var totalCount int64
+
+	qb := s.builder.
+		Select(
+			"j.id",
+			"j.user_id",
+			"j.local_date",
+			"j.text",
+			"COALESCE(d.id::TEXT, '')",
+			"j.created_at",
+			"j.updated_at",
+			"COUNT(*) OVER() AS total_count",
+		).
+		From(journalEntriesTableName + " j").
+		LeftJoin(dayRecordsTableName + " d ON d.user_id = j.user_id AND d.local_date = j.local_date")
+
+	if filter.ID != "" {
+		qb = qb.Where(squirrel.Eq{"j.id": filter.ID})
+	}
+	if filter.UserID != "" {
+		qb = qb.Where(squirrel.Eq{"j.user_id": filter.UserID})
+	}
+	if !filter.DateFrom.IsZero() {
+		qb = qb.Where(squirrel.GtOrEq{"j.local_date": filter.DateFrom})
+	}
+	if !filter.DateTo.IsZero() {
+		qb = qb.Where(squirrel.LtOrEq{"j.local_date": filter.DateTo})
+	}
+	if filter.Query != "" {
+		tsQuery := "websearch_to_tsquery('" + journalSearchConfig + "', ?)"
+		qb = qb.
+			Where("j.search @@ "+tsQuery, filter.Query).
+			OrderByClause("ts_rank(j.search, "+tsQuery+") DESC", filter.Query)
+	}
+	qb = qb.OrderBy("j.created_at DESC")
+	if filter.Limit > 0 {
+		qb = qb.Limit(filter.Limit)
+	}
+
+	query, args, err := qb.ToSql()
+	if err != nil {
+		return nil, 0, apperrors.NewInternal().WithDescriptionAndCause("failed to build list journal entries query", err)
+	}
+
+	rows, err := s.conn.Query(ctx, query, args...)
+	if err != nil {
+		return nil, 0, apperrors.NewInternal().WithDescriptionAndCause("failed to list journal entries", err)
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var entry models.JournalEntry
+		var count int64
+		if err := rows.Scan(
+			&entry.ID,
+			&entry.UserID,
+			&entry.LocalDate,
+			&entry.Text,
+			&entry.DayRecordID,
+			&entry.CreatedAt,
+			&entry.UpdatedAt,
+			&count,
+		); err != nil {
+			return nil, 0, apperrors.NewInternal().WithDescriptionAndCause("failed to scan journal entry", err)
+		}
+		if totalCount == 0 {
+			totalCount = count
+		}
+		entries = append(entries, entry)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, 0, apperrors.NewInternal().WithDescriptionAndCause("failed to list journal entries", err)
+	}
+	if len(entries) == 0 {
+		return nil, 0, apperrors.NewNotFound().WithDescription("journal entries not found")
+	}
+
+	return entries, totalCount, nil
+}
+
+func (s *journalEntryStorage) Update(ctx context.Context, entry models.JournalEntry) error {
+	query, args, err := s.builder.
+		Update(journalEntriesTableName).
+		Set("text", entry.Text).
+		Set("updated_at", entry.UpdatedAt).
+		Where(squirrel.Eq{"id": entry.ID}).
+		ToSql()
+	if err != nil {
+		return apperrors.NewInternal().WithDescriptionAndCause("failed to build update journal entry query", err)
+	}
+
+	result, err := s.conn.Exec(ctx, query, args...)
+	if err != nil {
+		return apperrors.NewInternal().WithDescriptionAndCause("failed to update journal entry", err)
+	}
+	if result.RowsAffected() == 0 {
+		return apperrors.NewNotFound().WithDescription("journal entry not found")
+	}
+
+	return nil
+}
+
+func (s *journalEntryStorage) Delete(ctx context.Context, id string) error {
+	query, args, err := s.builder.
+		Delete(journalEntriesTableName).
+		Where(squirrel.Eq{"id": id}).
+		ToSql()
+	if err != nil {
+		return apperrors.NewInternal().WithDescriptionAndCause("failed to build delete journal entry query", err)
+	}
+
+	result, err := s.conn.Exec(ctx, query, args...)
+	if err != nil {
+		return apperrors.NewInternal().WithDescriptionAndCause("failed to delete journal entry", err)
+	}
+	if result.RowsAffected() == 0 {
+		return apperrors.NewNotFound().WithDescription("journal entry not found")
+	}
+
+	return nil
+}
@@ -5,26 +5,52 @@ import (
 )
 
 const (
-	userTableName          = "users"
-	userSettingsTableName  = "user_settings"
-	dayRecordsTableName    = "day_records"
-	focusSessionsTableName = "focus_sessions"
+	userTableName                  = "users"
+	userSettingsTableName          = "user_settings"
+	dayRecordsTableName            = "day_records"
+	dayRecordEmotionsTableName     = "day_record_emotions"
+	focusSessionsTableName         = "focus_sessions"
+	focusSessionEventsTableName    = "focus_session_events"
+	focusSchedulesTableName        = "focus_schedules"
+	focusLabelsTableName           = "focus_labels"
+	focusRoomsTableName            = "focus_rooms"
+	focusRoomParticipantsTableName = "focus_room_participants"
+	focusDistractionsTableName     = "focus_distractions"
+	journalEntriesTableName        = "journal_entries"
+	userStreaksTableName           = "user_streaks"
+	userBadgesTableName            = "user_badges"
 
 	codeUnique = "23505"
 )
 
 type Storages struct {
-	User         UserStorage
-	UserSettings UserSettingsStorage
-	DayRecord    DayRecordStorage
-	FocusSession FocusSessionStorage
+	User              UserStorage
+	UserSettings      UserSettingsStorage
+	DayRecord         DayRecordStorage
+	FocusSession      FocusSessionStorage
+	FocusSessionEvent FocusSessionEventStorage
+	FocusSchedule     FocusScheduleStorage
+	FocusLabel        FocusLabelStorage
+	FocusRoom         FocusRoomStorage
+	FocusDistraction  FocusDistractionStorage
+	JournalEntry      JournalEntryStorage
+	Streak            StreakStorage
+	UserBadge         UserBadgeStorage
 }
 
 func NewStorages(pool *pgxpool.Pool) Storages {
 	return Storages{
-		User:         NewUserStorage(pool),
-		UserSettings: NewUserSettingsStorage(pool),
-		DayRecord:    NewDayRecordStorage(pool),
-		FocusSession: NewFocusSessionStorage(pool),
+		User:              NewUserStorage(pool),
+		UserSettings:      NewUserSettingsStorage(pool),
+		DayRecord:         NewDayRecordStorage(pool),
+		FocusSession:      NewFocusSessionStorage(pool),
+		FocusSessionEvent: NewFocusSessionEventStorage(pool),
+		FocusSchedule:     NewFocusScheduleStorage(pool),
+		FocusLabel:        NewFocusLabelStorage(pool),
+		FocusRoom:         NewFocusRoomStorage(pool),
+		FocusDistraction:  NewFocusDistractionStorage(pool),
+		JournalEntry:      NewJournalEntryStorage(pool),
+		Streak:            NewStreakStorage(pool),
+		UserBadge:         NewUserBadgeStorage(pool),
 	}
 }
@@ -0,0 +1,128 @@
+package storage
+
+import (
+	"attune/internal/models"
+	"attune/pkg/apperrors"
+	"context"
+	"github.com/Masterminds/squirrel"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+type StreakStorage interface {
+	List(ctx context.Context, filter ListStreakFilter) ([]models.Streak, error)
+	Upsert(ctx context.Context, streak models.Streak) error
+}
+
+type ListStreakFilter struct {
+	UserID string            `json:"userId"`
+	Kind   models.StreakKind `json:"kind"`
+}
+
+type streakStorage struct {
+	conn    *pgxpool.Pool
+	builder squirrel.StatementBuilderType
+}
+
+func NewStreakStorage(conn *pgxpool.Pool) StreakStorage {
+	builder := squirrel.StatementBuilder.PlaceholderFormat(squirrel.Dollar)
+
+	return &streakStorage{
+		conn:    conn,
+		builder: builder,
+	}
+}
+
+func (s *streakStorage) List(ctx context.Context, filter ListStreakFilter) ([]models.Streak, error) {
+	var streaks []models.Streak
+
+	qb := s.builder.
+		Select(
+			"user_id",
+			"kind",
+			"current",
+			"longest",
+			"last_date",
+			"freezes",
+			"updated_at",
+		).
+		From(userStreaksTableName)
+
+	if filter.UserID != "" {
+		qb = qb.Where(squirrel.Eq{"user_id": filter.UserID})
+	}
+	if filter.Kind != "" {
+		qb = qb.Where(squirrel.Eq{"kind": filter.Kind})
+	}
+
+	query, args, err := qb.ToSql()
+	if err != nil {
+		return nil, apperrors.NewInternal().WithDescriptionAndCause("failed to build list streaks query", err)
+	}
+
+	rows, err := s.conn.Query(ctx, query, args...)
+	if err != nil {
+		return nil, apperrors.NewInternal().WithDescriptionAndCause("failed to list streaks", err)
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var streak models.Streak
+		if err := rows.Scan(
+			&streak.UserID,
+			&streak.Kind,
+			&streak.Current,
+			&streak.Longest,
+			&streak.LastDate,
+			&streak.Freezes,
+			&streak.UpdatedAt,
+		); err != nil {
+			return nil, apperrors.NewInternal().WithDescriptionAndCause("failed to scan streak", err)
+		}
+
+		streaks = append(streaks, streak)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, apperrors.NewInternal().WithDescriptionAndCause("failed to list streaks", err)
+	}
+	if len(streaks) == 0 {
+		return nil, apperrors.NewNotFound().WithDescription("no streaks found")
+	}
+
+	return streaks, nil
+}
+
+func (s *streakStorage) Upsert(ctx context.Context, streak models.Streak) error {
+	query, args, err := s.builder.
+		Insert(userStreaksTableName).
+		Columns(
+			"user_id",
+			"kind",
+			"current",
+			"longest",
+			"last_date",
+			"freezes",
+			"updated_at",
+		).
+		Values(
+			streak.UserID,
+			streak.Kind,
+			streak.Current,
+			streak.Longest,
+			streak.LastDate,
+			streak.Freezes,
+			streak.UpdatedAt,
+		).
+		Suffix(`ON CONFLICT (user_id, kind) DO UPDATE
+			SET current = EXCLUDED.current, longest = EXCLUDED.longest, last_date = EXCLUDED.last_date,
+				freezes = EXCLUDED.freezes, updated_at = EXCLUDED.updated_at`).
+		ToSql()
+	if err != nil {
+		return apperrors.NewInternal().WithDescriptionAndCause("failed to build upsert streak query", err)
+	}
+
+	if _, err := s.conn.Exec(ctx, query, args...); err != nil {
+		return apperrors.NewInternal().WithDescriptionAndCause("failed to upsert streak", err)
+	}
+
+	return nil
+}
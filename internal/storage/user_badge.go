@@ -0,0 +1,107 @@
+package storage
+
+import (
+	"attune/internal/models"
+	"attune/pkg/apperrors"
+	"context"
+	"github.com/Masterminds/squirrel"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+type UserBadgeStorage interface {
+	List(ctx context.Context, filter ListUserBadgeFilter) ([]models.UserBadge, error)
+	Award(ctx context.Context, badge models.UserBadge) (bool, error)
+}
+
+type ListUserBadgeFilter struct {
+	UserID string `json:"userId"`
+}
+
+type userBadgeStorage struct {
+	conn    *pgxpool.Pool
+	builder squirrel.StatementBuilderType
+}
+
+func NewUserBadgeStorage(conn *pgxpool.Pool) UserBadgeStorage {
+	builder := squirrel.StatementBuilder.PlaceholderFormat(squirrel.Dollar)
+
+	return &userBadgeStorage{
+		conn:    conn,
+		builder: builder,
+	}
+}
+
+func (s *userBadgeStorage) List(ctx context.Context, filter ListUserBadgeFilter) ([]models.UserBadge, error) {
+	var badges []models.UserBadge
+
+	qb := s.builder.
+		Select(
+			"user_id",
+			"code",
+			"awarded_at",
+		).
+		From(userBadgesTableName)
+
+	if filter.UserID != "" {
+		qb = qb.Where(squirrel.Eq{"user_id": filter.UserID})
+	}
+
+	query, args, err := qb.OrderBy("awarded_at").ToSql()
+	if err != nil {
+		return nil, apperrors.NewInternal().WithDescriptionAndCause("failed to build list user badges query", err)
+	}
+
+	rows, err := s.conn.Query(ctx, query, args...)
+	if err != nil {
+		return nil, apperrors.NewInternal().WithDescriptionAndCause("failed to list user badges", err)
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var badge models.UserBadge
+		if err := rows.Scan(
+			&badge.UserID,
+			&badge.Code,
+			&badge.AwardedAt,
+		); err != nil {
+			return nil, apperrors.NewInternal().WithDescriptionAndCause("failed to scan user badge", err)
+		}
+
+		badges = append(badges, badge)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, apperrors.NewInternal().WithDescriptionAndCause("failed to list user badges", err)
+	}
+	if len(badges) == 0 {
+		return nil, apperrors.NewNotFound().WithDescription("no user badges found")
+	}
+
+	return badges, nil
+}
+
+func (s *userBadgeStorage) Award(ctx context.Context, badge models.UserBadge) (bool, error) {
+	query, args, err := s.builder.
+		Insert(userBadgesTableName).
+		Columns(
+			"user_id",
+			"code",
+			"awarded_at",
+		).
+		Values(
+			badge.UserID,
+			badge.Code,
+			badge.AwardedAt,
+		).
+		Suffix("ON CONFLICT (user_id, code) DO NOTHING").
+		ToSql()
+	if err != nil {
+		return false, apperrors.NewInternal().WithDescriptionAndCause("failed to build award user badge query", err)
+	}
+
+	result, err := s.conn.Exec(ctx, query, args...)
+	if err != nil {
+		return false, apperrors.NewInternal().WithDescriptionAndCause("failed to award user badge", err)
+	}
+
+	return result.RowsAffected() > 0, nil
+}
@@ -13,6 +13,11 @@ type UserSettingsStorage interface {
 	Create(ctx context.Context, settings models.UserSettings) error
 	List(ctx context.Context, filter ListUserSettingsFilter) ([]models.UserSettings, error)
 	UpdateSentDailyStatsAt(ctx context.Context, userID string, sentDailyStatsAt time.Time) error
+	UpdateDailyFocusGoal(ctx context.Context, userID string, goal time.Duration) error
+	// MarkGoalReached and MarkReportSent report false when the moment was already recorded
+	// since dayStart or dueStart.
+	MarkGoalReached(ctx context.Context, userID string, at, dayStart time.Time) (bool, error)
+	MarkReportSent(ctx context.Context, userID string, period models.ReportPeriod, at, dueStart time.Time) (bool, error)
 	Delete(ctx context.Context, userID string) error
 }
 
@@ -23,6 +28,11 @@ type ListUserSettingsFilter struct {
 	SentDailyStatsBefore time.Time `json:"sentDailyStatsBefore"`
 }
 
+var reportSentColumns = map[models.ReportPeriod]string{
+	models.ReportPeriodWeek:  "week_report_sent_at",
+	models.ReportPeriodMonth: "month_report_sent_at",
+}
+
 type userSettingsStorage struct {
 	conn    *pgxpool.Pool
 	builder squirrel.StatementBuilderType
@@ -44,6 +54,10 @@ func (s *userSettingsStorage) Create(ctx context.Context, settings models.UserSe
 			"id",
 			"user_id",
 			"sent_daily_stats_at",
+			"daily_focus_goal",
+			"goal_reached_at",
+			"week_report_sent_at",
+			"month_report_sent_at",
 			"created_at",
 			"updated_at",
 		).
@@ -51,6 +65,10 @@ func (s *userSettingsStorage) Create(ctx context.Context, settings models.UserSe
 			settings.ID,
 			settings.UserID,
 			settings.SentDailyStatsAt,
+			settings.DailyFocusGoal,
+			settings.GoalReachedAt,
+			settings.WeekReportSentAt,
+			settings.MonthReportSentAt,
 			settings.CreatedAt,
 			settings.UpdatedAt,
 		).
@@ -75,6 +93,10 @@ func (s *userSettingsStorage) List(ctx context.Context, filter ListUserSettingsF
 			"id",
 			"user_id",
 			"sent_daily_stats_at",
+			"daily_focus_goal",
+			"goal_reached_at",
+			"week_report_sent_at",
+			"month_report_sent_at",
 			"created_at",
 			"updated_at",
 		).
@@ -110,6 +132,10 @@ func (s *userSettingsStorage) List(ctx context.Context, filter ListUserSettingsF
 			&settings.ID,
 			&settings.UserID,
 			&settings.SentDailyStatsAt,
+			&settings.DailyFocusGoal,
+			&settings.GoalReachedAt,
+			&settings.WeekReportSentAt,
+			&settings.MonthReportSentAt,
 			&settings.CreatedAt,
 			&settings.UpdatedAt,
 		); err != nil {
@@ -150,6 +176,85 @@ func (s *userSettingsStorage) UpdateSentDailyStatsAt(
 	return nil
 }
 
+func (s *userSettingsStorage) UpdateDailyFocusGoal(
+	ctx context.Context,
+	userID string,
+	goal time.Duration,
+) error {
+	query, args, err := s.builder.
+		Update(userSettingsTableName).
+		Set("daily_focus_goal", goal).
+		Set("updated_at", squirrel.Expr("NOW()")).
+		Where(squirrel.Eq{"user_id": userID}).
+		ToSql()
+	if err != nil {
+		return apperrors.NewInternal().WithDescriptionAndCause("failed to build update daily focus goal query", err)
+	}
+
+	result, err := s.conn.Exec(ctx, query, args...)
+	if err != nil {
+		return apperrors.NewInternal().WithDescriptionAndCause("failed to update daily focus goal", err)
+	}
+	if result.RowsAffected() == 0 {
+		return apperrors.NewNotFound().WithDescription("user settings not found")
+	}
+	return nil
+}
+
+func (s *userSettingsStorage) MarkGoalReached(
+	ctx context.Context,
+	userID string,
+	at, dayStart time.Time,
+) (bool, error) {
+	query, args, err := s.builder.
+		Update(userSettingsTableName).
+		Set("goal_reached_at", at).
+		Set("updated_at", squirrel.Expr("NOW()")).
+		Where(squirrel.Eq{"user_id": userID}).
+		Where(squirrel.Lt{"goal_reached_at": dayStart}).
+		ToSql()
+	if err != nil {
+		return false, apperrors.NewInternal().WithDescriptionAndCause("failed to build mark goal reached query", err)
+	}
+
+	result, err := s.conn.Exec(ctx, query, args...)
+	if err != nil {
+		return false, apperrors.NewInternal().WithDescriptionAndCause("failed to mark goal reached", err)
+	}
+
+	return result.RowsAffected() == 1, nil
+}
+
+func (s *userSettingsStorage) MarkReportSent(
+	ctx context.Context,
+	userID string,
+	period models.ReportPeriod,
+	at, dueStart time.Time,
+) (bool, error) {
+	column, ok := reportSentColumns[period]
+	if !ok {
+		return false, apperrors.NewInternal().WithDescription("unknown report period " + string(period))
+	}
+
+	query, args, err := s.builder.
+		Update(userSettingsTableName).
+		Set(column, at).
+		Set("updated_at", squirrel.Expr("NOW()")).
+		Where(squirrel.Eq{"user_id": userID}).
+		Where(squirrel.Lt{column: dueStart}).
+		ToSql()
+	if err != nil {
+		return false, apperrors.NewInternal().WithDescriptionAndCause("failed to build mark report sent query", err)
+	}
+
+	result, err := s.conn.Exec(ctx, query, args...)
+	if err != nil {
+		return false, apperrors.NewInternal().WithDescriptionAndCause("failed to mark report sent", err)
+	}
+
+	return result.RowsAffected() == 1, nil
+}
+
 func (s *userSettingsStorage) Delete(ctx context.Context, userID string) error {
 	query, args, err := s.builder.
 		Delete(userSettingsTableName).
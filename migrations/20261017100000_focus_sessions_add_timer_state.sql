@@ -0,0 +1,30 @@
+-- +goose Up
+-- +goose StatementBegin
+ALTER TABLE focus_sessions
+    ADD COLUMN IF NOT EXISTS remaining INTERVAL NOT NULL DEFAULT '0',
+    ADD COLUMN IF NOT EXISTS paused BOOLEAN NOT NULL DEFAULT FALSE,
+    ADD COLUMN IF NOT EXISTS last_started_at TIMESTAMPTZ NOT NULL DEFAULT NOW();
+-- +goose StatementEnd
+
+-- Sessions started before the timer state was stored cannot be resumed.
+-- +goose StatementBegin
+UPDATE focus_sessions
+SET status = 'stopped', ended_at = started_at, updated_at = NOW()
+WHERE status = 'active';
+-- +goose StatementEnd
+
+-- +goose StatementBegin
+CREATE INDEX IF NOT EXISTS idx_focus_sessions_status ON focus_sessions (status);
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP INDEX IF EXISTS idx_focus_sessions_status;
+-- +goose StatementEnd
+
+-- +goose StatementBegin
+ALTER TABLE focus_sessions
+    DROP COLUMN IF EXISTS remaining,
+    DROP COLUMN IF EXISTS paused,
+    DROP COLUMN IF EXISTS last_started_at;
+-- +goose StatementEnd
@@ -0,0 +1,23 @@
+-- +goose Up
+-- +goose StatementBegin
+ALTER TABLE focus_sessions
+    ADD COLUMN IF NOT EXISTS planned_duration INTERVAL NOT NULL DEFAULT '0',
+    ADD COLUMN IF NOT EXISTS paused_duration INTERVAL NOT NULL DEFAULT '0',
+    ADD COLUMN IF NOT EXISTS focused_duration INTERVAL NOT NULL DEFAULT '0',
+    ADD COLUMN IF NOT EXISTS paused_at TIMESTAMPTZ NOT NULL DEFAULT '0001-01-01 00:00:00+00';
+-- +goose StatementEnd
+
+-- +goose StatementBegin
+UPDATE focus_sessions
+SET focused_duration = ended_at - started_at
+WHERE status <> 'active' AND ended_at > started_at;
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+ALTER TABLE focus_sessions
+    DROP COLUMN IF EXISTS planned_duration,
+    DROP COLUMN IF EXISTS paused_duration,
+    DROP COLUMN IF EXISTS focused_duration,
+    DROP COLUMN IF EXISTS paused_at;
+-- +goose StatementEnd
@@ -0,0 +1,13 @@
+-- +goose Up
+-- +goose StatementBegin
+ALTER TABLE focus_sessions
+    ADD COLUMN IF NOT EXISTS mode VARCHAR(32) NOT NULL DEFAULT 'single',
+    ADD COLUMN IF NOT EXISTS pomodoro JSONB NULL;
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+ALTER TABLE focus_sessions
+    DROP COLUMN IF EXISTS mode,
+    DROP COLUMN IF EXISTS pomodoro;
+-- +goose StatementEnd
@@ -0,0 +1,25 @@
+-- +goose Up
+-- +goose StatementBegin
+UPDATE focus_sessions fs
+SET status = 'stopped',
+    paused = FALSE,
+    ended_at = NOW(),
+    updated_at = NOW()
+WHERE fs.status = 'active'
+  AND EXISTS (
+      SELECT 1
+      FROM focus_sessions newer
+      WHERE newer.user_id = fs.user_id
+        AND newer.status = 'active'
+        AND (newer.created_at, newer.id) > (fs.created_at, fs.id)
+  );
+-- +goose StatementEnd
+
+-- +goose StatementBegin
+CREATE UNIQUE INDEX IF NOT EXISTS idx_focus_sessions_user_id_active ON focus_sessions (user_id) WHERE status = 'active';
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP INDEX IF EXISTS idx_focus_sessions_user_id_active;
+-- +goose StatementEnd
@@ -0,0 +1,37 @@
+-- +goose Up
+-- +goose StatementBegin
+CREATE TABLE IF NOT EXISTS focus_schedules (
+    id UUID PRIMARY KEY,
+    user_id UUID NOT NULL,
+    rule VARCHAR(16) NOT NULL,
+    start_time VARCHAR(5) NOT NULL,
+    weekday SMALLINT NOT NULL DEFAULT 0,
+    duration INTERVAL NOT NULL,
+    timezone VARCHAR(64) NOT NULL DEFAULT 'UTC',
+    next_run_at TIMESTAMPTZ NOT NULL,
+    active BOOLEAN NOT NULL DEFAULT TRUE,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    updated_at TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+-- +goose StatementEnd
+
+-- +goose StatementBegin
+CREATE INDEX IF NOT EXISTS idx_focus_schedules_user_id ON focus_schedules (user_id);
+-- +goose StatementEnd
+
+-- +goose StatementBegin
+CREATE INDEX IF NOT EXISTS idx_focus_schedules_next_run_at ON focus_schedules (next_run_at) WHERE active;
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP INDEX IF EXISTS idx_focus_schedules_next_run_at;
+-- +goose StatementEnd
+
+-- +goose StatementBegin
+DROP INDEX IF EXISTS idx_focus_schedules_user_id;
+-- +goose StatementEnd
+
+-- +goose StatementBegin
+DROP TABLE IF EXISTS focus_schedules;
+-- +goose StatementEnd
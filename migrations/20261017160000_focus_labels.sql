@@ -0,0 +1,41 @@
+-- +goose Up
+-- +goose StatementBegin
+CREATE TABLE IF NOT EXISTS focus_labels (
+    id UUID PRIMARY KEY,
+    user_id UUID NOT NULL,
+    name VARCHAR(64) NOT NULL,
+    last_used_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    updated_at TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+-- +goose StatementEnd
+
+-- +goose StatementBegin
+CREATE UNIQUE INDEX IF NOT EXISTS idx_focus_labels_user_id_name ON focus_labels (user_id, LOWER(name));
+-- +goose StatementEnd
+
+-- +goose StatementBegin
+ALTER TABLE focus_sessions
+    ADD COLUMN IF NOT EXISTS label_id UUID REFERENCES focus_labels (id) ON DELETE SET NULL;
+-- +goose StatementEnd
+
+-- +goose StatementBegin
+CREATE INDEX IF NOT EXISTS idx_focus_sessions_label_id ON focus_sessions (label_id);
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP INDEX IF EXISTS idx_focus_sessions_label_id;
+-- +goose StatementEnd
+
+-- +goose StatementBegin
+ALTER TABLE focus_sessions DROP COLUMN IF EXISTS label_id;
+-- +goose StatementEnd
+
+-- +goose StatementBegin
+DROP INDEX IF EXISTS idx_focus_labels_user_id_name;
+-- +goose StatementEnd
+
+-- +goose StatementBegin
+DROP TABLE IF EXISTS focus_labels;
+-- +goose StatementEnd
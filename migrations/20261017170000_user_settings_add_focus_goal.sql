@@ -0,0 +1,13 @@
+-- +goose Up
+-- +goose StatementBegin
+ALTER TABLE user_settings
+    ADD COLUMN IF NOT EXISTS daily_focus_goal INTERVAL NOT NULL DEFAULT '0',
+    ADD COLUMN IF NOT EXISTS goal_reached_at TIMESTAMPTZ NOT NULL DEFAULT '0001-01-01 00:00:00+00';
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+ALTER TABLE user_settings
+    DROP COLUMN IF EXISTS goal_reached_at,
+    DROP COLUMN IF EXISTS daily_focus_goal;
+-- +goose StatementEnd
@@ -0,0 +1,49 @@
+-- +goose Up
+-- +goose StatementBegin
+CREATE TABLE IF NOT EXISTS focus_rooms (
+    id UUID PRIMARY KEY,
+    chat_id VARCHAR(64) NOT NULL,
+    host_user_id UUID NOT NULL,
+    duration INTERVAL NOT NULL,
+    status VARCHAR(16) NOT NULL DEFAULT 'open',
+    summary_message_id VARCHAR(64) NOT NULL DEFAULT '',
+    started_at TIMESTAMPTZ NOT NULL DEFAULT '0001-01-01 00:00:00+00',
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    updated_at TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+-- +goose StatementEnd
+
+-- +goose StatementBegin
+CREATE TABLE IF NOT EXISTS focus_room_participants (
+    room_id UUID NOT NULL REFERENCES focus_rooms (id) ON DELETE CASCADE,
+    user_id UUID NOT NULL,
+    joined_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    PRIMARY KEY (room_id, user_id)
+);
+-- +goose StatementEnd
+
+-- +goose StatementBegin
+ALTER TABLE focus_sessions
+    ADD COLUMN IF NOT EXISTS room_id UUID REFERENCES focus_rooms (id) ON DELETE SET NULL;
+-- +goose StatementEnd
+
+-- +goose StatementBegin
+CREATE INDEX IF NOT EXISTS idx_focus_sessions_room_id ON focus_sessions (room_id);
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP INDEX IF EXISTS idx_focus_sessions_room_id;
+-- +goose StatementEnd
+
+-- +goose StatementBegin
+ALTER TABLE focus_sessions DROP COLUMN IF EXISTS room_id;
+-- +goose StatementEnd
+
+-- +goose StatementBegin
+DROP TABLE IF EXISTS focus_room_participants;
+-- +goose StatementEnd
+
+-- +goose StatementBegin
+DROP TABLE IF EXISTS focus_rooms;
+-- +goose StatementEnd
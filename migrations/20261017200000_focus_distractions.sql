@@ -0,0 +1,31 @@
+-- +goose Up
+-- +goose StatementBegin
+CREATE TABLE IF NOT EXISTS focus_distractions (
+    id UUID PRIMARY KEY,
+    session_id UUID NOT NULL REFERENCES focus_sessions (id) ON DELETE CASCADE,
+    user_id UUID NOT NULL,
+    reason VARCHAR(64) NOT NULL DEFAULT '',
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+-- +goose StatementEnd
+
+-- +goose StatementBegin
+CREATE INDEX IF NOT EXISTS idx_focus_distractions_session_id ON focus_distractions (session_id);
+-- +goose StatementEnd
+
+-- +goose StatementBegin
+CREATE INDEX IF NOT EXISTS idx_focus_distractions_user_id_reason ON focus_distractions (user_id, reason);
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP INDEX IF EXISTS idx_focus_distractions_user_id_reason;
+-- +goose StatementEnd
+
+-- +goose StatementBegin
+DROP INDEX IF EXISTS idx_focus_distractions_session_id;
+-- +goose StatementEnd
+
+-- +goose StatementBegin
+DROP TABLE IF EXISTS focus_distractions;
+-- +goose StatementEnd
@@ -0,0 +1,11 @@
+-- +goose Up
+-- +goose StatementBegin
+ALTER TABLE focus_sessions
+    ADD COLUMN IF NOT EXISTS rest_duration INTERVAL NOT NULL DEFAULT '0';
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+ALTER TABLE focus_sessions
+    DROP COLUMN IF EXISTS rest_duration;
+-- +goose StatementEnd
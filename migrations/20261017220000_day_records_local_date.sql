@@ -0,0 +1,43 @@
+-- +goose Up
+-- +goose StatementBegin
+ALTER TABLE day_records
+    ADD COLUMN IF NOT EXISTS local_date DATE;
+-- +goose StatementEnd
+
+-- The session time zone is APP_TIMEZONE when migrations run from the app, which is the
+-- zone local dates are computed in.
+-- +goose StatementBegin
+UPDATE day_records
+SET local_date = (created_at AT TIME ZONE current_setting('TimeZone'))::DATE
+WHERE local_date IS NULL;
+-- +goose StatementEnd
+
+-- +goose StatementBegin
+DELETE FROM day_records dr
+WHERE EXISTS (
+    SELECT 1
+    FROM day_records newer
+    WHERE newer.user_id = dr.user_id
+      AND newer.local_date = dr.local_date
+      AND (newer.updated_at, newer.id) > (dr.updated_at, dr.id)
+);
+-- +goose StatementEnd
+
+-- +goose StatementBegin
+ALTER TABLE day_records
+    ALTER COLUMN local_date SET NOT NULL;
+-- +goose StatementEnd
+
+-- +goose StatementBegin
+CREATE UNIQUE INDEX IF NOT EXISTS idx_day_records_user_id_local_date ON day_records (user_id, local_date);
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP INDEX IF EXISTS idx_day_records_user_id_local_date;
+-- +goose StatementEnd
+
+-- +goose StatementBegin
+ALTER TABLE day_records
+    DROP COLUMN IF EXISTS local_date;
+-- +goose StatementEnd
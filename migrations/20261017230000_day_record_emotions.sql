@@ -0,0 +1,46 @@
+-- +goose Up
+-- +goose StatementBegin
+ALTER TABLE day_records
+    RENAME COLUMN mood TO mood_note;
+-- +goose StatementEnd
+
+-- +goose StatementBegin
+UPDATE day_records
+SET mood_note = ''
+WHERE mood_note IS NULL;
+-- +goose StatementEnd
+
+-- +goose StatementBegin
+ALTER TABLE day_records
+    ALTER COLUMN mood_note SET DEFAULT '',
+    ALTER COLUMN mood_note SET NOT NULL;
+-- +goose StatementEnd
+
+-- +goose StatementBegin
+CREATE TABLE IF NOT EXISTS day_record_emotions (
+    day_record_id UUID NOT NULL REFERENCES day_records (id) ON DELETE CASCADE,
+    emotion VARCHAR(32) NOT NULL,
+    intensity SMALLINT NOT NULL CHECK (intensity BETWEEN 1 AND 3),
+    PRIMARY KEY (day_record_id, emotion)
+);
+-- +goose StatementEnd
+
+-- +goose StatementBegin
+CREATE INDEX IF NOT EXISTS idx_day_record_emotions_emotion ON day_record_emotions (emotion);
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP TABLE IF EXISTS day_record_emotions;
+-- +goose StatementEnd
+
+-- +goose StatementBegin
+ALTER TABLE day_records
+    ALTER COLUMN mood_note DROP NOT NULL,
+    ALTER COLUMN mood_note DROP DEFAULT;
+-- +goose StatementEnd
+
+-- +goose StatementBegin
+ALTER TABLE day_records
+    RENAME COLUMN mood_note TO mood;
+-- +goose StatementEnd
@@ -0,0 +1,13 @@
+-- +goose Up
+-- +goose StatementBegin
+ALTER TABLE user_settings
+    ADD COLUMN IF NOT EXISTS week_report_sent_at TIMESTAMPTZ NOT NULL DEFAULT '0001-01-01 00:00:00+00',
+    ADD COLUMN IF NOT EXISTS month_report_sent_at TIMESTAMPTZ NOT NULL DEFAULT '0001-01-01 00:00:00+00';
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+ALTER TABLE user_settings
+    DROP COLUMN IF EXISTS month_report_sent_at,
+    DROP COLUMN IF EXISTS week_report_sent_at;
+-- +goose StatementEnd
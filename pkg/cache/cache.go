@@ -2,6 +2,8 @@ package cache
 
 import "time"
 
+type EvictionHandler func(key string, value any)
+
 type Cache interface {
 	Set(key string, value any)
 	SetWithTTL(key string, value any, ttl time.Duration)
@@ -9,4 +11,6 @@ type Cache interface {
 	Keys() []string
 	Delete(key string)
 	Clear()
+	// Handlers run on the goroutine that noticed the expiry, e.g. a Get or the cache worker.
+	OnEvict(handler EvictionHandler)
 }
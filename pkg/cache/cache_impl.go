@@ -3,6 +3,8 @@ package cache
 import (
 	"sync"
 	"time"
+
+	"attune/pkg/clock"
 )
 
 type cachedItem struct {
@@ -11,14 +13,30 @@ type cachedItem struct {
 }
 
 type inMemoryCache struct {
-	mu    sync.RWMutex
-	items map[string]cachedItem
+	mu       sync.RWMutex
+	items    map[string]cachedItem
+	clock    clock.Clock
+	handlers []EvictionHandler
+}
+
+type Option func(*inMemoryCache)
+
+func WithClock(clk clock.Clock) Option {
+	return func(c *inMemoryCache) {
+		c.clock = clk
+	}
 }
 
-func NewCache() Cache {
-	return &inMemoryCache{
+func NewCache(opts ...Option) Cache {
+	c := &inMemoryCache{
 		items: make(map[string]cachedItem),
+		clock: clock.NewRealClock(),
 	}
+	for _, opt := range opts {
+		opt(c)
+	}
+
+	return c
 }
 
 func (c *inMemoryCache) Get(key string) (any, bool) {
@@ -30,8 +48,8 @@ func (c *inMemoryCache) Get(key string) (any, bool) {
 		return nil, false
 	}
 
-	if !item.expiration.IsZero() && time.Now().After(item.expiration) {
-		c.Delete(key)
+	if !item.expiration.IsZero() && c.clock.Now().After(item.expiration) {
+		c.evict(key)
 		return nil, false
 	}
 
@@ -59,7 +77,7 @@ func (c *inMemoryCache) SetWithTTL(key string, value any, ttl time.Duration) {
 
 	var expiration time.Time
 	if ttl > 0 {
-		expiration = time.Now().Add(ttl)
+		expiration = c.clock.Now().Add(ttl)
 	}
 	c.items[key] = cachedItem{
 		value:      value,
@@ -74,6 +92,29 @@ func (c *inMemoryCache) Delete(key string) {
 	delete(c.items, key)
 }
 
+func (c *inMemoryCache) OnEvict(handler EvictionHandler) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.handlers = append(c.handlers, handler)
+}
+
+func (c *inMemoryCache) evict(key string) {
+	c.mu.Lock()
+	item, ok := c.items[key]
+	if !ok || item.expiration.IsZero() || !c.clock.Now().After(item.expiration) {
+		c.mu.Unlock()
+		return
+	}
+	delete(c.items, key)
+	handlers := c.handlers
+	c.mu.Unlock()
+
+	for _, handler := range handlers {
+		handler(key, item.value)
+	}
+}
+
 func (c *inMemoryCache) Clear() {
 	c.mu.Lock()
 	defer c.mu.Unlock()
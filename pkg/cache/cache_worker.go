@@ -42,13 +42,7 @@ func cleanCache(cacheInstance Cache) {
 		return
 	}
 
-	now := time.Now()
-	memCache.mu.Lock()
-	defer memCache.mu.Unlock()
-
-	for key, item := range memCache.items {
-		if !item.expiration.IsZero() && now.After(item.expiration) {
-			delete(memCache.items, key)
-		}
+	for _, key := range memCache.Keys() {
+		memCache.evict(key)
 	}
 }
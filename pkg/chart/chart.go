@@ -0,0 +1,216 @@
+package chart
+
+import (
+	"image"
+	"image/color"
+	"image/png"
+	"io"
+	"math"
+	"strconv"
+)
+
+const (
+	width  = 800
+	height = 450
+
+	marginLeft   = 70
+	marginRight  = 30
+	marginTop    = 60
+	marginBottom = 50
+
+	labelGap = 16
+	yTicks   = 5
+)
+
+var (
+	colorBackground = color.RGBA{R: 0xff, G: 0xff, B: 0xff, A: 0xff}
+	colorText       = color.RGBA{R: 0x11, G: 0x18, B: 0x27, A: 0xff}
+	colorAxis       = color.RGBA{R: 0x6b, G: 0x72, B: 0x80, A: 0xff}
+	colorGrid       = color.RGBA{R: 0xe5, G: 0xe7, B: 0xeb, A: 0xff}
+	colorData       = color.RGBA{R: 0x3b, G: 0x82, B: 0xf6, A: 0xff}
+)
+
+// A slot without data holds math.NaN().
+type Chart struct {
+	Title  string
+	Labels []string
+	Values []float64
+	// Zero picks a round number above the largest value.
+	Max float64
+}
+
+func WriteLine(w io.Writer, c Chart) error {
+	img, plot := c.canvas()
+
+	var prev image.Point
+	hasPrev := false
+	for i, value := range c.Values {
+		if math.IsNaN(value) {
+			continue
+		}
+
+		point := image.Point{X: plot.slotCenter(i), Y: plot.y(value)}
+		if hasPrev {
+			drawLine(img, prev, point, colorData)
+		}
+		fillRect(img, point.X-3, point.Y-3, 7, 7, colorData)
+		prev, hasPrev = point, true
+	}
+
+	return png.Encode(w, img)
+}
+
+func WriteBar(w io.Writer, c Chart) error {
+	img, plot := c.canvas()
+
+	barWidth := max(plot.slotWidth()*2/3, 1)
+	for i, value := range c.Values {
+		if math.IsNaN(value) || value <= 0 {
+			continue
+		}
+
+		top := plot.y(value)
+		fillRect(img, plot.slotCenter(i)-barWidth/2, top, barWidth, plot.rect.Max.Y-top, colorData)
+	}
+
+	return png.Encode(w, img)
+}
+
+type plot struct {
+	rect  image.Rectangle
+	slots int
+	max   float64
+}
+
+func (p plot) slotWidth() int {
+	return p.rect.Dx() / max(p.slots, 1)
+}
+
+func (p plot) slotCenter(i int) int {
+	return p.rect.Min.X + p.rect.Dx()*(2*i+1)/(2*max(p.slots, 1))
+}
+
+func (p plot) y(value float64) int {
+	value = math.Min(math.Max(value, 0), p.max)
+
+	return p.rect.Max.Y - int(math.Round(value/p.max*float64(p.rect.Dy())))
+}
+
+func (c Chart) canvas() (*image.RGBA, plot) {
+	img := image.NewRGBA(image.Rect(0, 0, width, height))
+	fillRect(img, 0, 0, width, height, colorBackground)
+
+	top, step := c.yAxis()
+	p := plot{
+		rect:  image.Rect(marginLeft, marginTop, width-marginRight, height-marginBottom),
+		slots: len(c.Values),
+		max:   top,
+	}
+
+	drawText(img, (width-textWidth(c.Title))/2, (marginTop-textHeight)/2, c.Title, colorText)
+
+	for tick := 0.0; tick <= top+step/2; tick += step {
+		y := p.y(tick)
+		fillRect(img, p.rect.Min.X, y, p.rect.Dx(), 1, colorGrid)
+
+		label := strconv.FormatFloat(tick, 'f', -1, 64)
+		drawText(img, p.rect.Min.X-10-textWidth(label), y-textHeight/2, label, colorAxis)
+	}
+	fillRect(img, p.rect.Min.X, p.rect.Min.Y, 2, p.rect.Dy()+1, colorAxis)
+	fillRect(img, p.rect.Min.X, p.rect.Max.Y, p.rect.Dx(), 2, colorAxis)
+
+	labelWidth := 0
+	for _, label := range c.Labels {
+		labelWidth = max(labelWidth, textWidth(label))
+	}
+	every := (labelWidth + labelGap + p.slotWidth() - 1) / max(p.slotWidth(), 1)
+	for i, label := range c.Labels {
+		if every > 1 && i%every != 0 {
+			continue
+		}
+		drawText(img, p.slotCenter(i)-textWidth(label)/2, p.rect.Max.Y+12, label, colorAxis)
+	}
+
+	return img, p
+}
+
+func (c Chart) yAxis() (float64, float64) {
+	top := c.Max
+	if top <= 0 {
+		for _, value := range c.Values {
+			if !math.IsNaN(value) {
+				top = math.Max(top, value)
+			}
+		}
+	}
+	if top <= 0 {
+		top = 1
+	}
+
+	step := niceStep(top / yTicks)
+	if c.Max <= 0 {
+		top = math.Ceil(top/step) * step
+	}
+
+	return top, step
+}
+
+func niceStep(x float64) float64 {
+	magnitude := math.Pow(10, math.Floor(math.Log10(x)))
+	for _, factor := range []float64{1, 2, 5} {
+		if factor*magnitude >= x {
+			return factor * magnitude
+		}
+	}
+
+	return 10 * magnitude
+}
+
+func fillRect(img *image.RGBA, x, y, w, h int, c color.Color) {
+	for py := y; py < y+h; py++ {
+		for px := x; px < x+w; px++ {
+			img.Set(px, py, c)
+		}
+	}
+}
+
+func drawLine(img *image.RGBA, a, b image.Point, c color.Color) {
+	dx, dy := abs(b.X-a.X), -abs(b.Y-a.Y)
+	sx, sy := sign(b.X-a.X), sign(b.Y-a.Y)
+	err := dx + dy
+
+	for x, y := a.X, a.Y; ; {
+		fillRect(img, x-1, y-1, 3, 3, c)
+		if x == b.X && y == b.Y {
+			return
+		}
+		e2 := 2 * err
+		if e2 >= dy {
+			err += dy
+			x += sx
+		}
+		if e2 <= dx {
+			err += dx
+			y += sy
+		}
+	}
+}
+
+func abs(x int) int {
+	if x < 0 {
+		return -x
+	}
+
+	return x
+}
+
+func sign(x int) int {
+	switch {
+	case x < 0:
+		return -1
+	case x > 0:
+		return 1
+	}
+
+	return 0
+}
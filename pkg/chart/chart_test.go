@@ -0,0 +1,86 @@
+package chart
+
+import (
+	"math"
+	"testing"
+)
+
+func TestNiceStep(t *testing.T) {
+	tests := []struct {
+		x    float64
+		want float64
+	}{
+		{x: 0.03, want: 0.05},
+		{x: 0.2, want: 0.2},
+		{x: 1, want: 1},
+		{x: 1.2, want: 2},
+		{x: 2, want: 2},
+		{x: 3, want: 5},
+		{x: 5.5, want: 10},
+		{x: 12, want: 20},
+		{x: 70, want: 100},
+		{x: 240, want: 500},
+	}
+
+	for _, tt := range tests {
+		if got := niceStep(tt.x); math.Abs(got-tt.want) > 1e-9 {
+			t.Errorf("niceStep(%v): got %v, want %v", tt.x, got, tt.want)
+		}
+	}
+}
+
+func TestChart_YAxis(t *testing.T) {
+	tests := []struct {
+		name     string
+		chart    Chart
+		wantTop  float64
+		wantStep float64
+	}{
+		{
+			name:     "rounded above the largest value",
+			chart:    Chart{Values: []float64{12, 47, 30}},
+			wantTop:  50,
+			wantStep: 10,
+		},
+		{
+			name:     "gaps are skipped",
+			chart:    Chart{Values: []float64{math.NaN(), 180, math.NaN()}},
+			wantTop:  200,
+			wantStep: 50,
+		},
+		{
+			name:     "fractional values",
+			chart:    Chart{Values: []float64{0.3, 0.8}},
+			wantTop:  0.8,
+			wantStep: 0.2,
+		},
+		{
+			name:     "fixed maximum",
+			chart:    Chart{Values: []float64{3, 7}, Max: 10},
+			wantTop:  10,
+			wantStep: 2,
+		},
+		{
+			// A fixed maximum is kept even when the steps do not reach it evenly.
+			name:     "fixed maximum off the steps",
+			chart:    Chart{Values: []float64{3, 7}, Max: 7},
+			wantTop:  7,
+			wantStep: 2,
+		},
+		{
+			name:     "no data",
+			chart:    Chart{Values: []float64{0, math.NaN()}},
+			wantTop:  1,
+			wantStep: 0.2,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			top, step := tt.chart.yAxis()
+			if math.Abs(top-tt.wantTop) > 1e-9 || math.Abs(step-tt.wantStep) > 1e-9 {
+				t.Fatalf("y axis: got top %v step %v, want top %v step %v", top, step, tt.wantTop, tt.wantStep)
+			}
+		})
+	}
+}